@@ -0,0 +1,45 @@
+package main
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/madelet"
+	"maden/pkg/networking"
+
+	"go.uber.org/dig"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func buildContainer(config madelet.NodeAgentConfig, proxyConfig networking.ServiceProxyConfig, ipamConfig networking.IPAMConfig, etcdEndpoints []string) *dig.Container {
+	container := dig.New()
+
+	container.Provide(func() madelet.NodeAgentConfig {
+		return config
+	})
+	container.Provide(func() networking.ServiceProxyConfig {
+		return proxyConfig
+	})
+	container.Provide(func() networking.IPAMConfig {
+		return ipamConfig
+	})
+	container.Provide(func() *clientv3.Client {
+		return etcd.NewClientv3WithEndpoints(etcdEndpoints)
+	})
+	container.Provide(etcd.NewEtcdClient)
+	container.Provide(madelet.NewClient)
+	container.Provide(madelet.NewDockerClient)
+	container.Provide(etcd.NewEtcdPodRepository)
+	container.Provide(etcd.NewEtcdNodeRepository)
+	container.Provide(etcd.NewEtcdNodeLeaseRepository)
+	container.Provide(etcd.NewEtcdServiceRepository)
+	container.Provide(etcd.NewEtcdEndpointsRepository)
+	container.Provide(etcd.NewEtcdIPAllocationRepository)
+	container.Provide(etcd.NewEtcdTransactionRepository)
+	container.Provide(madelet.NewContainerRuntimeInterface)
+	container.Provide(madelet.NewContainerProber)
+	container.Provide(madelet.NewPodNetwork)
+	container.Provide(madelet.NewPodLifecycleManager)
+	container.Provide(madelet.NewNodeAgent)
+	container.Provide(networking.NewDefaultServiceProxy)
+
+	return container
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"maden/pkg/madelet"
+	"maden/pkg/networking"
+	"maden/pkg/shared"
+
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+func main() {
+	hostname, _ := os.Hostname()
+
+	nodeID := flag.String("node-id", hostname, "ID under which the node registers itself")
+	nodeName := flag.String("node-name", hostname, "Display name of the node")
+	labels := flag.String("labels", "", "Comma-separated node labels, e.g. zone=eu,disk=ssd")
+	etcdEndpoints := flag.String("etcd-endpoints", "etcd:2379", "Comma-separated etcd endpoints")
+	heartbeatInterval := flag.Duration("heartbeat-interval", madelet.DefaultHeartbeatInterval, "Interval between node lease renewals")
+	podStatusInterval := flag.Duration("pod-status-interval", madelet.DefaultPodStatusInterval, "Interval between checks of the containers of running pods")
+	proxyInterface := flag.String("proxy-interface", networking.DefaultServiceInterface, "Dummy interface the IPs of the services are added to, none when empty")
+	podCIDR := flag.String("pod-cidr", networking.DefaultPodCIDR, "CIDR the IPs of the pods are handed out from, the same on every node and the API server")
+	flag.Parse()
+
+	config := madelet.NodeAgentConfig{
+		NodeID:            *nodeID,
+		NodeName:          *nodeName,
+		Labels:            parseLabels(*labels),
+		HeartbeatInterval: *heartbeatInterval,
+		PodStatusInterval: *podStatusInterval,
+	}
+
+	proxyConfig := networking.ServiceProxyConfig{Interface: *proxyInterface}
+	ipamConfig := networking.IPAMConfig{PodCIDR: *podCIDR}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	container := buildContainer(config, proxyConfig, ipamConfig, strings.Split(*etcdEndpoints, ","))
+	err := container.Invoke(func(agent *madelet.NodeAgent, proxy networking.ServiceProxy) error {
+		// Every node forwards the traffic of the services, like it runs the pods, for the pods it can reach
+		go proxy.Run(ctx)
+		return agent.Run(ctx)
+	})
+	if err != nil {
+		shared.Log.Errorf("Node agent stopped: %v", err)
+		os.Exit(1)
+	}
+}
+
+func parseLabels(labels string) map[string]string {
+	if labels == "" {
+		return nil
+	}
+
+	parsedLabels := make(map[string]string)
+	for _, label := range strings.Split(labels, ",") {
+		key, value, _ := strings.Cut(label, "=")
+		parsedLabels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return parsedLabels
+}
@@ -19,41 +19,59 @@ func buildContainer() *dig.Container {
 	container.Provide(etcd.ProvideEtcdClient)
 	container.Provide(madelet.NewClient)
 	container.Provide(madelet.NewDockerClient)
+	container.Provide(etcd.NewEtcdNamespaceRepository)
 	container.Provide(etcd.NewEtcdPodRepository)
 	container.Provide(etcd.NewEtcdNodeRepository)
+	container.Provide(etcd.NewEtcdNodeLeaseRepository)
 	container.Provide(etcd.NewEtcdDeploymentRepository)
+	container.Provide(etcd.NewEtcdDeploymentRevisionRepository)
 	container.Provide(etcd.NewEtcdServiceRepository)
+	container.Provide(etcd.NewEtcdEndpointsRepository)
+	container.Provide(etcd.NewEtcdIPAllocationRepository)
 	container.Provide(etcd.NewEtcdPersistentVolumeRepository)
 	container.Provide(etcd.NewEtcdPersistentVolumeClaimRepository)
 	container.Provide(etcd.NewEtcdTransactionRepository)
 	container.Provide(etcd.NewEtcdDNSRepository)
 	container.Provide(madelet.NewContainerRuntimeInterface)
+	container.Provide(scheduler.NewSchedulerConfig)
 	container.Provide(scheduler.NewPodScheduler)
+	container.Provide(scheduler.NewPodSchedulingQueue)
 	container.Provide(orchestrator.NewDefaultPodOrchestrator)
 	container.Provide(orchestrator.NewDefaultServiceOrchestrator)
 	container.Provide(orchestrator.NewDefaultPersistentVolumeOrchestrator)
 	container.Provide(orchestrator.NewDefaultPersistentVolumeClaimOrchestrator)
+	container.Provide(controller.NewDefaultNamespaceController)
 	container.Provide(controller.NewDefaultDeploymentController)
 	container.Provide(controller.NewDefaultDeploymentUpdaterController)
+	container.Provide(controller.NewDefaultDeploymentReconciler)
+	container.Provide(controller.NewDefaultDeploymentRolloutEngine)
+	container.Provide(controller.NewDefaultGarbageCollector)
+	container.Provide(controller.NewNodeLifecycleConfig)
+	container.Provide(controller.NewDefaultNodeLifecycleController)
 	container.Provide(controller.NewDefaultServiceController)
 	container.Provide(controller.NewDefaultServiceUpdaterController)
-	container.Provide(controller.NewDefaultPodUpdaterController)
+	container.Provide(controller.NewDefaultEndpointsController)
+	container.Provide(controller.NewDefaultNodeUpdaterController)
 	container.Provide(controller.NewDefaultPersistentVolumeController)
 	container.Provide(controller.NewDefaultPersistentVolumeClaimController)
 	container.Provide(controller.NewEtcdChangeListener)
 	container.Provide(madelet.NewPodLifecycleManager)
-	container.Provide(func() networking.IPManager {
-		return networking.NewSimpleIPManager()
-	})
+	container.Provide(networking.NewIPAMConfig)
+	container.Provide(networking.NewServiceIPManager)
+	container.Provide(madelet.NewPodNetwork)
+	container.Provide(apiserver.NewNamespaceHandler)
 	container.Provide(apiserver.NewPodHandler)
 	container.Provide(apiserver.NewNodeHandler)
 	container.Provide(apiserver.NewDeploymentHandler)
 	container.Provide(apiserver.NewServiceHandler)
+	container.Provide(apiserver.NewEndpointsHandler)
 	container.Provide(apiserver.NewPersistentVolumeHandler)
 	container.Provide(apiserver.NewPersistentVolumeClaimHandler)
 	container.Provide(apiserver.NewManifestHandler)
-	container.Provide(apiserver.NewDNSHandler)
 	container.Provide(apiserver.NewServer)
+	container.Provide(apiserver.NewDNSConfig)
+	container.Provide(apiserver.NewDNSRecordCache)
+	container.Provide(apiserver.NewDNSHandler)
 	container.Provide(apiserver.NewDNSServer)
 
 	return container
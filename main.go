@@ -4,38 +4,43 @@ import (
 	"maden/pkg/apiserver"
 	"maden/pkg/shared"
 
-	"sync"
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
 	container := buildContainer()
 
-	var wg sync.WaitGroup
+	// Both are built by a single invocation, the container is not meant to be used from several goroutines
+	var server *apiserver.Server
+	var dnsServer *apiserver.DNSServer
+	err := container.Invoke(func(s *apiserver.Server, d *apiserver.DNSServer) {
+		server, dnsServer = s, d
+	})
+	if err != nil {
+		shared.Log.Errorf("Failed to invoke DI container: %v", err)
+		os.Exit(1)
+	}
 
-	// DNS Server currently causes concurrency issues, so it is disabled for now
-	// wg.Add(1)
-	// go func() {
-	// 	defer wg.Done()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 	err := container.Invoke(func(dnsServer *apiserver.DNSServer) {
-	// 		dnsServer.StartDNSServer()
-	// 	})
-	// 	if err != nil {
-	// 		shared.Log.Errorf("Failed to invoke DI container: %v", err)
-	// 	}
-	// }()
-
-	wg.Add(1)
 	go func() {
-		defer wg.Done()
+		server.Start()
+		// The process ends along with the API server
+		stop()
+	}()
 
-		err := container.Invoke(func(server *apiserver.Server) {
-			server.Start()
-		})
-		if err != nil {
-			shared.Log.Errorf("Failed to invoke DI container: %v", err)
+	dnsStopped := make(chan struct{})
+	go func() {
+		defer close(dnsStopped)
+		if err := dnsServer.Run(ctx); err != nil {
+			shared.Log.Errorf("DNS server stopped: %v", err)
 		}
 	}()
 
-	wg.Wait()
+	<-ctx.Done()
+	<-dnsStopped
 }
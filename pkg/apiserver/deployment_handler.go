@@ -14,32 +14,47 @@ import (
 
 type DeploymentHandler struct {
 	Repo etcd.DeploymentRepository
+	RevisionRepo etcd.DeploymentRevisionRepository
 	UpdateController controller.DeploymentUpdaterController
+	GarbageCollector controller.GarbageCollector
 }
 
 func NewDeploymentHandler(
 	repo etcd.DeploymentRepository,
+	revisionRepo etcd.DeploymentRevisionRepository,
 	updateController controller.DeploymentUpdaterController,
+	garbageCollector controller.GarbageCollector,
 	) *DeploymentHandler {
-	return &DeploymentHandler{Repo: repo, UpdateController: updateController}
+	return &DeploymentHandler{Repo: repo, RevisionRepo: revisionRepo, UpdateController: updateController, GarbageCollector: garbageCollector}
 }
 
 func (h *DeploymentHandler) listDeploymentsHandler(w http.ResponseWriter, r *http.Request) {
-	deployments, err := h.Repo.ListDeployments()
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	deployments, err := h.Repo.ListDeployments(r.Context(), vars["namespace"])
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(deployments)
+	json.NewEncoder(w).Encode(shared.FilterBySelector(deployments, selector))
 }
 
 func (h *DeploymentHandler) deleteDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	policy, ok := parsePropagationPolicy(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
 	deploymentName := vars["name"]
 
-	if err := h.Repo.DeleteDeployment(deploymentName); err != nil {
+	if err := h.GarbageCollector.DeleteDeployment(r.Context(), vars["namespace"], deploymentName, policy); err != nil {
 		var errNotFound *shared.ErrNotFound
 		if errors.As(err, &errNotFound) {
 			w.WriteHeader(http.StatusNotFound)
@@ -56,7 +71,7 @@ func (h *DeploymentHandler) rolloutRestartDeploymentHandler(w http.ResponseWrite
 	vars := mux.Vars(r)
 	deploymentName := vars["name"]
 
-	deployment, err := h.Repo.GetDeploymentByName(deploymentName)
+	deployment, err := h.Repo.GetDeploymentByName(r.Context(), vars["namespace"], deploymentName)
 
 	if err != nil {
 		var errNotFound *shared.ErrNotFound
@@ -68,7 +83,7 @@ func (h *DeploymentHandler) rolloutRestartDeploymentHandler(w http.ResponseWrite
 		return
 	}
 
-	err = h.UpdateController.HandleDeploymentRolloutRestart(deployment)
+	err = h.UpdateController.HandleDeploymentRolloutRestart(r.Context(), deployment)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -77,11 +92,111 @@ func (h *DeploymentHandler) rolloutRestartDeploymentHandler(w http.ResponseWrite
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *DeploymentHandler) listDeploymentRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deploymentName := vars["name"]
+
+	if _, err := h.Repo.GetDeploymentByName(r.Context(), vars["namespace"], deploymentName); err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	revisions, err := h.RevisionRepo.ListRevisions(r.Context(), vars["namespace"], deploymentName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(revisions)
+}
+
+func (h *DeploymentHandler) rollbackDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deploymentName := vars["name"]
+
+	var rollbackRequest shared.RollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&rollbackRequest); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deployment, err := h.Repo.GetDeploymentByName(r.Context(), vars["namespace"], deploymentName)
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := h.UpdateController.HandleDeploymentRollback(r.Context(), deployment, rollbackRequest.Revision); err != nil {
+		var errNotFound *shared.ErrNotFound
+		var errConflict *shared.ErrConflict
+		if errors.As(err, &errNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else if errors.As(err, &errConflict) {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DeploymentHandler) pauseRolloutHandler(w http.ResponseWriter, r *http.Request) {
+	h.setDeploymentPaused(w, r, true)
+}
+
+func (h *DeploymentHandler) resumeRolloutHandler(w http.ResponseWriter, r *http.Request) {
+	h.setDeploymentPaused(w, r, false)
+}
+
+func (h *DeploymentHandler) setDeploymentPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	vars := mux.Vars(r)
+	deploymentName := vars["name"]
+
+	deployment, err := h.Repo.GetDeploymentByName(r.Context(), vars["namespace"], deploymentName)
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if deployment.Paused != paused {
+		deployment.Paused = paused
+		if err := h.Repo.UpdateDeployment(r.Context(), deployment); err != nil {
+			var errConflict *shared.ErrConflict
+			if errors.As(err, &errConflict) {
+				http.Error(w, err.Error(), http.StatusConflict)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *DeploymentHandler) scaleDeploymentHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	deploymentName := vars["name"]
 
-	deployment, err := h.Repo.GetDeploymentByName(deploymentName)
+	deployment, err := h.Repo.GetDeploymentByName(r.Context(), vars["namespace"], deploymentName)
 	if err != nil {
 		var errNotFound *shared.ErrNotFound
 		if errors.As(err, &errNotFound) {
@@ -100,9 +215,14 @@ func (h *DeploymentHandler) scaleDeploymentHandler(w http.ResponseWriter, r *htt
 
 	deployment.Replicas = scaleRequest.Replicas
 
-	err = h.Repo.UpdateDeployment(deployment)
+	err = h.Repo.UpdateDeployment(r.Context(), deployment)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		var errConflict *shared.ErrConflict
+		if errors.As(err, &errConflict) {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 
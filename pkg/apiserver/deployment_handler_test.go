@@ -2,6 +2,7 @@ package apiserver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"maden/pkg/mocks"
@@ -22,11 +23,11 @@ func TestDeploymentHandlerListDeploymentsHandler(t *testing.T) {
 
     mockRepo := mocks.NewMockDeploymentRepository(ctrl)
 	mockUpdateController := mocks.NewMockDeploymentUpdaterController(ctrl)
-    handler := NewDeploymentHandler(mockRepo, mockUpdateController)
+    handler := NewDeploymentHandler(mockRepo, nil, mockUpdateController, nil)
 
     // Prepare mock data
-    deployments := []shared.Deployment{{ID: "1", Name: "Deployment1"}}
-    mockRepo.EXPECT().ListDeployments().Return(deployments, nil)
+    deployments := []shared.Deployment{{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "Deployment1"}}}
+    mockRepo.EXPECT().ListDeployments(gomock.Any(), "").Return(deployments, nil)
 
     // Create a request and response recorder
     req, err := http.NewRequest("GET", "/deployments", nil)
@@ -50,7 +51,8 @@ func TestDeploymentHandlerDeleteDeploymentHandler(t *testing.T) {
 
     mockRepo := mocks.NewMockDeploymentRepository(ctrl)
 	mockUpdateController := mocks.NewMockDeploymentUpdaterController(ctrl)
-    handler := NewDeploymentHandler(mockRepo, mockUpdateController)
+	mockGarbageCollector := mocks.NewMockGarbageCollector(ctrl)
+    handler := NewDeploymentHandler(mockRepo, nil, mockUpdateController, mockGarbageCollector)
 
     deploymentName := "test-dep"
 
@@ -59,23 +61,42 @@ func TestDeploymentHandlerDeleteDeploymentHandler(t *testing.T) {
     if err != nil {
         t.Fatal(err)
     }
-    req = mux.SetURLVars(req, map[string]string{"name": deploymentName})
+    req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
     rr := httptest.NewRecorder()
 
     // Expectations and call
-    mockRepo.EXPECT().DeleteDeployment(deploymentName).Return(nil)
+    mockGarbageCollector.EXPECT().DeleteDeployment(gomock.Any(), "default", deploymentName, shared.DeletePropagationBackground).Return(nil)
 
     handler.deleteDeploymentHandler(rr, req)
 
     assert.Equal(t, http.StatusNoContent, rr.Code)
 
     // Test not found error
-    mockRepo.EXPECT().DeleteDeployment(deploymentName).Return(&shared.ErrNotFound{})
+    mockGarbageCollector.EXPECT().DeleteDeployment(gomock.Any(), "default", deploymentName, shared.DeletePropagationBackground).Return(&shared.ErrNotFound{})
     rr = httptest.NewRecorder()
 
     handler.deleteDeploymentHandler(rr, req)
 
     assert.Equal(t, http.StatusNotFound, rr.Code)
+
+    // Test propagation policy
+    mockGarbageCollector.EXPECT().DeleteDeployment(gomock.Any(), "default", deploymentName, shared.DeletePropagationOrphan).Return(nil)
+    req, _ = http.NewRequest("DELETE", "/deployments/"+deploymentName+"?propagationPolicy=Orphan", nil)
+    req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
+    rr = httptest.NewRecorder()
+
+    handler.deleteDeploymentHandler(rr, req)
+
+    assert.Equal(t, http.StatusNoContent, rr.Code)
+
+    // Test unknown propagation policy
+    req, _ = http.NewRequest("DELETE", "/deployments/"+deploymentName+"?propagationPolicy=Sideways", nil)
+    req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
+    rr = httptest.NewRecorder()
+
+    handler.deleteDeploymentHandler(rr, req)
+
+    assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
 func TestDeploymentHandlerRolloutRestartDeploymentHandler(t *testing.T) {
@@ -84,50 +105,94 @@ func TestDeploymentHandlerRolloutRestartDeploymentHandler(t *testing.T) {
 
     mockRepo := mocks.NewMockDeploymentRepository(ctrl)
     mockUpdateController := mocks.NewMockDeploymentUpdaterController(ctrl)
-    handler := NewDeploymentHandler(mockRepo, mockUpdateController)
+    handler := NewDeploymentHandler(mockRepo, nil, mockUpdateController, nil)
 
     deploymentName := "test-deployment"
-    deployment := &shared.Deployment{Name: deploymentName, Replicas: 2}
+    deployment := &shared.Deployment{ObjectMeta: shared.ObjectMeta{Name: deploymentName}, Replicas: 2}
 
     req, err := http.NewRequest("POST", "/deployments/"+deploymentName+"/rollout-restart", nil)
     if err != nil {
         t.Fatal(err)
     }
-    req = mux.SetURLVars(req, map[string]string{"name": deploymentName})
+    req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
     rr := httptest.NewRecorder()
 
     // Successful restart
-    mockRepo.EXPECT().GetDeploymentByName(deploymentName).Return(deployment, nil)
-    mockUpdateController.EXPECT().HandleDeploymentRolloutRestart(deployment).Return(nil)
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil)
+    mockUpdateController.EXPECT().HandleDeploymentRolloutRestart(gomock.Any(), deployment).Return(nil)
 
     handler.rolloutRestartDeploymentHandler(rr, req)
     assert.Equal(t, http.StatusNoContent, rr.Code)
 
     // Test not found error
     rr = httptest.NewRecorder()
-    mockRepo.EXPECT().GetDeploymentByName(deploymentName).Return(nil, &shared.ErrNotFound{})
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(nil, &shared.ErrNotFound{})
 
     handler.rolloutRestartDeploymentHandler(rr, req)
     assert.Equal(t, http.StatusNotFound, rr.Code)
 
     // Test internal server error
     rr = httptest.NewRecorder()
-    mockRepo.EXPECT().GetDeploymentByName(deploymentName).Return(deployment, nil)
-    mockUpdateController.EXPECT().HandleDeploymentRolloutRestart(deployment).Return(fmt.Errorf("failed to restart"))
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil)
+    mockUpdateController.EXPECT().HandleDeploymentRolloutRestart(gomock.Any(), deployment).Return(fmt.Errorf("failed to restart"))
 
     handler.rolloutRestartDeploymentHandler(rr, req)
     assert.Equal(t, http.StatusInternalServerError, rr.Code)
 }
 
+func TestDeploymentHandlerPauseAndResumeRolloutHandlers(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockRepo := mocks.NewMockDeploymentRepository(ctrl)
+    handler := NewDeploymentHandler(mockRepo, nil, nil, nil)
+
+    deploymentName := "test-deployment"
+    deployment := &shared.Deployment{ObjectMeta: shared.ObjectMeta{Name: deploymentName}, Replicas: 2}
+
+    // Pause
+    req, err := http.NewRequest("POST", "/deployments/"+deploymentName+"/rollout/pause", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
+    rr := httptest.NewRecorder()
+
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil)
+    mockRepo.EXPECT().UpdateDeployment(gomock.Any(), gomock.Any()).Do(func(_ context.Context, d *shared.Deployment) {
+        assert.True(t, d.Paused)
+    }).Return(nil)
+
+    handler.pauseRolloutHandler(rr, req)
+    assert.Equal(t, http.StatusNoContent, rr.Code)
+
+    // Resume
+    rr = httptest.NewRecorder()
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil)
+    mockRepo.EXPECT().UpdateDeployment(gomock.Any(), gomock.Any()).Do(func(_ context.Context, d *shared.Deployment) {
+        assert.False(t, d.Paused)
+    }).Return(nil)
+
+    handler.resumeRolloutHandler(rr, req)
+    assert.Equal(t, http.StatusNoContent, rr.Code)
+
+    // Not found
+    rr = httptest.NewRecorder()
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(nil, &shared.ErrNotFound{})
+
+    handler.pauseRolloutHandler(rr, req)
+    assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
 func TestDeploymentHandlerScaleDeploymentHandler(t *testing.T) {
     ctrl := gomock.NewController(t)
     defer ctrl.Finish()
 
     mockRepo := mocks.NewMockDeploymentRepository(ctrl)
-    handler := NewDeploymentHandler(mockRepo, nil) 
+    handler := NewDeploymentHandler(mockRepo, nil, nil, nil) 
 
     deploymentName := "test-deployment"
-    deployment := &shared.Deployment{Name: deploymentName, Replicas: 2}
+    deployment := &shared.Deployment{ObjectMeta: shared.ObjectMeta{Name: deploymentName}, Replicas: 2}
     newReplicas := 5
     requestBody, _ := json.Marshal(shared.ScaleRequest{Replicas: newReplicas})
 
@@ -137,11 +202,11 @@ func TestDeploymentHandlerScaleDeploymentHandler(t *testing.T) {
         if err != nil {
             t.Fatal(err)
         }
-        req = mux.SetURLVars(req, map[string]string{"name": deploymentName})
+        req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
         rr := httptest.NewRecorder()
 
-        mockRepo.EXPECT().GetDeploymentByName(deploymentName).Return(deployment, nil).Times(1)
-        mockRepo.EXPECT().UpdateDeployment(deployment).Return(nil).Times(1)
+        mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil).Times(1)
+        mockRepo.EXPECT().UpdateDeployment(gomock.Any(), deployment).Return(nil).Times(1)
 
         handler.scaleDeploymentHandler(rr, req)
         assert.Equal(t, http.StatusNoContent, rr.Code)
@@ -153,12 +218,101 @@ func TestDeploymentHandlerScaleDeploymentHandler(t *testing.T) {
         if err != nil {
             t.Fatal(err)
         }
-        req = mux.SetURLVars(req, map[string]string{"name": deploymentName})
+        req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
+        rr := httptest.NewRecorder()
+
+        mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(nil, &shared.ErrNotFound{}).Times(1)
+
+        handler.scaleDeploymentHandler(rr, req)
+        assert.Equal(t, http.StatusNotFound, rr.Code)
+    })
+
+    // Test Case: Deployment modified concurrently
+    t.Run("conflict", func(t *testing.T) {
+        req, err := http.NewRequest("POST", "/deployments/"+deploymentName+"/scale", bytes.NewBuffer(requestBody))
+        if err != nil {
+            t.Fatal(err)
+        }
+        req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
         rr := httptest.NewRecorder()
 
-        mockRepo.EXPECT().GetDeploymentByName(deploymentName).Return(nil, &shared.ErrNotFound{}).Times(1)
+        mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil).Times(1)
+        mockRepo.EXPECT().UpdateDeployment(gomock.Any(), deployment).Return(&shared.ErrConflict{ID: deploymentName, ResourceType: shared.DeploymentResource}).Times(1)
 
         handler.scaleDeploymentHandler(rr, req)
+        assert.Equal(t, http.StatusConflict, rr.Code)
+    })
+}
+
+func TestDeploymentHandlerListDeploymentRevisionsHandler(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockRepo := mocks.NewMockDeploymentRepository(ctrl)
+    mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+    handler := NewDeploymentHandler(mockRepo, mockRevisionRepo, nil, nil)
+
+    deploymentName := "test-deployment"
+    revisions := []shared.DeploymentRevision{{DeploymentName: deploymentName, Revision: 1, TemplateHash: "abcd1234"}}
+
+    req, err := http.NewRequest("GET", "/deployments/"+deploymentName+"/revisions", nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
+    rr := httptest.NewRecorder()
+
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(&shared.Deployment{ObjectMeta: shared.ObjectMeta{Name: deploymentName}}, nil)
+    mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", deploymentName).Return(revisions, nil)
+
+    handler.listDeploymentRevisionsHandler(rr, req)
+
+    assert.Equal(t, http.StatusOK, rr.Code)
+    expectedBytes, _ := json.Marshal(revisions)
+    assert.Equal(t, string(expectedBytes)+"\n", rr.Body.String())
+}
+
+func TestDeploymentHandlerRollbackDeploymentHandler(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockRepo := mocks.NewMockDeploymentRepository(ctrl)
+    mockUpdateController := mocks.NewMockDeploymentUpdaterController(ctrl)
+    handler := NewDeploymentHandler(mockRepo, nil, mockUpdateController, nil)
+
+    deploymentName := "test-deployment"
+    deployment := &shared.Deployment{ObjectMeta: shared.ObjectMeta{Name: deploymentName}, Replicas: 2}
+    requestBody, _ := json.Marshal(shared.RollbackRequest{Revision: 2})
+
+    // Test Case: Successful rollback
+    t.Run("success", func(t *testing.T) {
+        req, err := http.NewRequest("POST", "/deployments/"+deploymentName+"/rollback", bytes.NewBuffer(requestBody))
+        if err != nil {
+            t.Fatal(err)
+        }
+        req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
+        rr := httptest.NewRecorder()
+
+        mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil)
+        mockUpdateController.EXPECT().HandleDeploymentRollback(gomock.Any(), deployment, 2).Return(nil)
+
+        handler.rollbackDeploymentHandler(rr, req)
+        assert.Equal(t, http.StatusNoContent, rr.Code)
+    })
+
+    // Test Case: Unknown revision
+    t.Run("revision not found", func(t *testing.T) {
+        req, err := http.NewRequest("POST", "/deployments/"+deploymentName+"/rollback", bytes.NewBuffer(requestBody))
+        if err != nil {
+            t.Fatal(err)
+        }
+        req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": deploymentName})
+        rr := httptest.NewRecorder()
+
+        mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", deploymentName).Return(deployment, nil)
+        mockUpdateController.EXPECT().HandleDeploymentRollback(gomock.Any(), deployment, 2).Return(&shared.ErrNotFound{ResourceType: shared.DeploymentRevisionResource})
+
+        handler.rollbackDeploymentHandler(rr, req)
         assert.Equal(t, http.StatusNotFound, rr.Code)
     })
 }
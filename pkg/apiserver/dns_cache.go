@@ -0,0 +1,114 @@
+package apiserver
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/shared"
+
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const dnsRelistDelay = 5 * time.Second
+
+// Records of the services kept in memory, so that queries never wait for etcd. Filled by listing the records
+// and kept up to date by watching them from the revision of the list; they are listed anew whenever the watch ends
+type DNSRecordCache struct {
+	Repo etcd.DNSRepository
+
+	mutex   sync.RWMutex
+	records map[string]shared.DNSRecord // By namespace and name, see recordKey
+	keyByIP map[string]string
+}
+
+func NewDNSRecordCache(repo etcd.DNSRepository) *DNSRecordCache {
+	return &DNSRecordCache{
+		Repo:    repo,
+		records: make(map[string]shared.DNSRecord),
+		keyByIP: make(map[string]string),
+	}
+}
+
+func (c *DNSRecordCache) Run(ctx context.Context) {
+	for {
+		records, revision, err := c.Repo.ListRecords(ctx)
+		if err != nil {
+			shared.Log.Errorf("Failed to list DNS records: %v", err)
+		} else {
+			c.replace(records)
+			for event := range c.Repo.WatchRecords(ctx, revision) {
+				c.apply(event)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dnsRelistDelay):
+		}
+	}
+}
+
+func (c *DNSRecordCache) Lookup(namespace string, serviceName string) (shared.DNSRecord, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	record, ok := c.records[recordKey(namespace, serviceName)]
+	return record, ok
+}
+
+// The record of the service with the IP, for reverse lookups
+func (c *DNSRecordCache) LookupIP(ip string) (shared.DNSRecord, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	key, ok := c.keyByIP[ip]
+	if !ok {
+		return shared.DNSRecord{}, false
+	}
+	return c.records[key], true
+}
+
+func (c *DNSRecordCache) replace(records []shared.DNSRecord) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.records = make(map[string]shared.DNSRecord, len(records))
+	c.keyByIP = make(map[string]string, len(records))
+	for _, record := range records {
+		c.set(record)
+	}
+}
+
+func (c *DNSRecordCache) apply(event shared.WatchEvent[shared.DNSRecord]) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if event.Type == shared.WatchEventDelete {
+		c.remove(recordKey(event.Object.Namespace, event.Object.Name))
+		return
+	}
+	c.set(*event.Object)
+}
+
+func (c *DNSRecordCache) set(record shared.DNSRecord) {
+	key := recordKey(record.Namespace, record.Name)
+	c.remove(key)
+	c.records[key] = record
+	if record.IP != "" {
+		c.keyByIP[record.IP] = key
+	}
+}
+
+func (c *DNSRecordCache) remove(key string) {
+	if record, ok := c.records[key]; ok && c.keyByIP[record.IP] == key {
+		delete(c.keyByIP, record.IP)
+	}
+	delete(c.records, key)
+}
+
+// Lowercased like the names of DNS queries, which are case-insensitive
+func recordKey(namespace string, name string) string {
+	return strings.ToLower(etcd.ObjectKey(namespace, name))
+}
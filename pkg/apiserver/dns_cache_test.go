@@ -0,0 +1,64 @@
+package apiserver
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSRecordCacheFollowsWatch(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockDNSRepository(ctrl)
+	cache := NewDNSRecordCache(mockRepo)
+	events := make(chan shared.WatchEvent[shared.DNSRecord])
+
+	mockRepo.EXPECT().ListRecords(gomock.Any()).Return([]shared.DNSRecord{{Namespace: "default", Name: "web", IP: "10.96.0.10"}}, int64(7), nil)
+	mockRepo.EXPECT().WatchRecords(gomock.Any(), int64(7)).Return(events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	go cache.Run(ctx)
+	events <- shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventUpdate, Object: &shared.DNSRecord{Namespace: "default", Name: "web", IP: "10.96.0.11"}}
+	events <- shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventCreate, Object: &shared.DNSRecord{Namespace: "default", Name: "db", IP: "10.96.0.12"}}
+	events <- shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventDelete, Object: &shared.DNSRecord{Namespace: "default", Name: "db"}}
+	events <- shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventCreate, Object: &shared.DNSRecord{Namespace: "default", Name: "api", IP: "10.96.0.13"}}
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		_, ok := cache.Lookup("default", "api")
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	web, _ := cache.Lookup("default", "web")
+	assert.Equal(t, "10.96.0.11", web.IP)
+	_, ok := cache.Lookup("default", "db")
+	assert.False(t, ok)
+	_, ok = cache.LookupIP("10.96.0.10")
+	assert.False(t, ok)
+}
+
+func TestDNSRecordCacheLookupIgnoresCase(t *testing.T) {
+	// Arrange
+	cache := NewDNSRecordCache(nil)
+	cache.replace([]shared.DNSRecord{{Namespace: "Default", Name: "WebApp", IP: "10.96.0.10"}})
+
+	// Act
+	record, ok := cache.Lookup("default", "webapp")
+	cache.apply(shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventDelete, Object: &shared.DNSRecord{Namespace: "Default", Name: "WebApp"}})
+	_, okAfterDelete := cache.Lookup("default", "webapp")
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, "10.96.0.10", record.IP)
+	assert.False(t, okAfterDelete)
+}
@@ -1,41 +1,210 @@
 package apiserver
 
 import (
-	"maden/pkg/etcd"
 	"maden/pkg/shared"
 
 	"net"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/miekg/dns"
 )
 
+const (
+	dnsRecordTTL       = 5 // Seconds, short so that clients notice services moving soon
+	dnsUpstreamTimeout = 2 * time.Second
+)
+
+// Answers the names of the services from the record cache and forwards every other name to the upstream servers.
+// A service is known as <service>.<namespace>.svc.<domain>, each named port of it as _<port>._<protocol>.<service>...
 type DNSHandler struct {
-	Repo etcd.DNSRepository
+	Cache  *DNSRecordCache
+	Config DNSConfig
+}
+
+func NewDNSHandler(cache *DNSRecordCache, config DNSConfig) *DNSHandler {
+	return &DNSHandler{Cache: cache, Config: config}
+}
+
+func (h *DNSHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	if len(r.Question) != 1 {
+		msg := new(dns.Msg)
+		msg.SetRcode(r, dns.RcodeFormatError)
+		h.writeMsg(w, msg)
+		return
+	}
+
+	question := r.Question[0]
+	name := dns.CanonicalName(question.Name)
+	switch {
+	case dns.IsSubDomain(dns.Fqdn(h.Config.Domain), name):
+		h.writeMsg(w, h.answerServiceName(r, name, question.Qtype))
+	case question.Qtype == dns.TypePTR && h.knowsReverseName(name):
+		h.writeMsg(w, h.answerReverseName(r, name))
+	default:
+		h.forward(w, r)
+	}
 }
 
-func NewDNSHandler(repo etcd.DNSRepository) *DNSHandler {
-	return &DNSHandler{Repo: repo}
+func (h *DNSHandler) writeMsg(w dns.ResponseWriter, msg *dns.Msg) {
+	if err := w.WriteMsg(msg); err != nil {
+		shared.Log.Errorf("Failed to write DNS response to %s: %v", w.RemoteAddr(), err)
+	}
 }
 
-func (h *DNSHandler) DNSQueryHandler(w dns.ResponseWriter, r *dns.Msg) {
-	msg := dns.Msg{}
+// Every name of the cluster domain that is not a service or a port of one does not exist.
+// Names that exist answer queries of types they have no records of without any
+func (h *DNSHandler) answerServiceName(r *dns.Msg, name string, qtype uint16) *dns.Msg {
+	msg := new(dns.Msg)
 	msg.SetReply(r)
-	switch r.Question[0].Qtype {
+	msg.Authoritative = true
+
+	record, port, ok := h.lookupServiceName(name)
+	if !ok {
+		msg.Rcode = dns.RcodeNameError
+		return msg
+	}
+
+	serviceName := h.serviceName(record)
+	switch qtype {
 	case dns.TypeA:
-		msg.Authoritative = true
-		domain := r.Question[0].Name
-		ip, err := h.Repo.ResolveService(domain)
-		if err != nil {
-			shared.Log.Errorf("Failed to resolve service: %v", err)
-			w.WriteMsg(&msg)
-			return
+		// Ports have SRV records only
+		if port == nil {
+			msg.Answer = append(msg.Answer, h.aRecord(serviceName, record)...)
 		}
+	case dns.TypeSRV:
+		ports := record.Ports
+		if port != nil {
+			ports = []shared.ServicePort{*port}
+		}
+		for _, port := range ports {
+			msg.Answer = append(msg.Answer, &dns.SRV{
+				Hdr:      dns.RR_Header{Name: name, Rrtype: dns.TypeSRV, Class: dns.ClassINET, Ttl: dnsRecordTTL},
+				Priority: 0,
+				Weight:   100,
+				Port:     uint16(port.Port),
+				Target:   serviceName,
+			})
+		}
+		if len(msg.Answer) > 0 {
+			msg.Extra = append(msg.Extra, h.aRecord(serviceName, record)...)
+		}
+	}
+	return msg
+}
+
+// The record of the service a name refers to, along with the port for the names of ports
+func (h *DNSHandler) lookupServiceName(name string) (shared.DNSRecord, *shared.ServicePort, bool) {
+	zoneSuffix := "." + h.serviceZone()
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, zoneSuffix))
+	if !strings.HasSuffix(name, zoneSuffix) || (len(labels) != 2 && len(labels) != 4) {
+		return shared.DNSRecord{}, nil, false
+	}
 
-		msg.Answer = append(msg.Answer, &dns.A{
-			Hdr: dns.RR_Header{Name: domain, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
-			A:   net.ParseIP(ip),
-		})
+	namespace, serviceName := labels[len(labels)-1], labels[len(labels)-2]
+	record, ok := h.Cache.Lookup(namespace, serviceName)
+	if !ok {
+		return shared.DNSRecord{}, nil, false
+	}
+	if len(labels) == 2 {
+		return record, nil, true
+	}
+
+	portName, protocol := labels[0], labels[1]
+	if !strings.HasPrefix(portName, "_") || !strings.HasPrefix(protocol, "_") {
+		return shared.DNSRecord{}, nil, false
+	}
+	for _, port := range record.Ports {
+		if port.Name != "" && strings.EqualFold(port.Name, portName[1:]) && dnsPortProtocol(port) == protocol[1:] {
+			return record, &port, true
+		}
+	}
+	return shared.DNSRecord{}, nil, false
+}
 
+func dnsPortProtocol(port shared.ServicePort) string {
+	if port.Protocol == "" {
+		return "tcp"
 	}
-	w.WriteMsg(&msg)
+	return strings.ToLower(port.Protocol)
+}
+
+func (h *DNSHandler) serviceZone() string {
+	return "svc." + dns.Fqdn(h.Config.Domain)
+}
+
+func (h *DNSHandler) serviceName(record shared.DNSRecord) string {
+	return strings.ToLower(record.Name + "." + record.Namespace + "." + h.serviceZone())
+}
+
+func (h *DNSHandler) aRecord(name string, record shared.DNSRecord) []dns.RR {
+	ip := net.ParseIP(record.IP).To4()
+	if ip == nil {
+		return nil
+	}
+	return []dns.RR{&dns.A{
+		Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: dnsRecordTTL},
+		A:   ip,
+	}}
+}
+
+// Reverse names of IPs no service has are left to the upstream servers
+func (h *DNSHandler) knowsReverseName(name string) bool {
+	ip := reverseNameIP(name)
+	if ip == "" {
+		return false
+	}
+	_, ok := h.Cache.LookupIP(ip)
+	return ok
+}
+
+func (h *DNSHandler) answerReverseName(r *dns.Msg, name string) *dns.Msg {
+	msg := new(dns.Msg)
+	msg.SetReply(r)
+	msg.Authoritative = true
+
+	record, _ := h.Cache.LookupIP(reverseNameIP(name))
+	msg.Answer = append(msg.Answer, &dns.PTR{
+		Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypePTR, Class: dns.ClassINET, Ttl: dnsRecordTTL},
+		Ptr: h.serviceName(record),
+	})
+	return msg
+}
+
+// IP of a reverse name such as 2.0.96.10.in-addr.arpa., empty for other names
+func reverseNameIP(name string) string {
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, ".in-addr.arpa."))
+	if !strings.HasSuffix(name, ".in-addr.arpa.") || len(labels) != 4 {
+		return ""
+	}
+
+	slices.Reverse(labels)
+	ip := net.ParseIP(strings.Join(labels, ".")).To4()
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// Asks the upstream servers in turn, over the protocol the query came in with
+func (h *DNSHandler) forward(w dns.ResponseWriter, r *dns.Msg) {
+	client := &dns.Client{Net: "udp", Timeout: dnsUpstreamTimeout}
+	if _, ok := w.RemoteAddr().(*net.TCPAddr); ok {
+		client.Net = "tcp"
+	}
+
+	for _, upstream := range h.Config.Upstreams {
+		response, _, err := client.Exchange(r, upstream)
+		if err != nil {
+			shared.Log.Errorf("Failed to forward DNS query for %s to %s: %v", r.Question[0].Name, upstream, err)
+			continue
+		}
+		h.writeMsg(w, response)
+		return
+	}
+
+	msg := new(dns.Msg)
+	msg.SetRcode(r, dns.RcodeServerFailure)
+	h.writeMsg(w, msg)
 }
@@ -0,0 +1,136 @@
+package apiserver
+
+import (
+	"maden/pkg/shared"
+
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Keeps the response instead of sending it
+type recordingResponseWriter struct {
+	remoteAddr net.Addr
+	msg        *dns.Msg
+}
+
+func (w *recordingResponseWriter) LocalAddr() net.Addr         { return &net.UDPAddr{} }
+func (w *recordingResponseWriter) RemoteAddr() net.Addr        { return w.remoteAddr }
+func (w *recordingResponseWriter) WriteMsg(msg *dns.Msg) error { w.msg = msg; return nil }
+func (w *recordingResponseWriter) Write([]byte) (int, error)   { return 0, errors.New("not supported") }
+func (w *recordingResponseWriter) Close() error                { return nil }
+func (w *recordingResponseWriter) TsigStatus() error           { return nil }
+func (w *recordingResponseWriter) TsigTimersOnly(bool)         {}
+func (w *recordingResponseWriter) Hijack()                     {}
+
+func newTestDNSHandler(upstreams ...string) *DNSHandler {
+	cache := NewDNSRecordCache(nil)
+	cache.replace([]shared.DNSRecord{{
+		Namespace: "default",
+		Name:      "web",
+		IP:        "10.96.0.10",
+		Ports:     []shared.ServicePort{{Name: "http", Port: 80}, {Name: "dns", Port: 53, Protocol: "UDP"}, {Port: 9090}},
+	}})
+	return NewDNSHandler(cache, DNSConfig{Domain: DefaultClusterDomain, Upstreams: upstreams})
+}
+
+func query(handler *DNSHandler, name string, qtype uint16) *dns.Msg {
+	request := new(dns.Msg)
+	request.SetQuestion(name, qtype)
+	w := &recordingResponseWriter{remoteAddr: &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5353}}
+	handler.ServeDNS(w, request)
+	return w.msg
+}
+
+func TestDNSHandlerAnswersServiceA(t *testing.T) {
+	handler := newTestDNSHandler()
+
+	response := query(handler, "WEB.default.svc.cluster.local.", dns.TypeA)
+
+	assert.Equal(t, dns.RcodeSuccess, response.Rcode)
+	assert.True(t, response.Authoritative)
+	require.Len(t, response.Answer, 1)
+	assert.Equal(t, "10.96.0.10", response.Answer[0].(*dns.A).A.String())
+}
+
+func TestDNSHandlerAnswersServiceSRV(t *testing.T) {
+	handler := newTestDNSHandler()
+
+	named := query(handler, "_dns._udp.web.default.svc.cluster.local.", dns.TypeSRV)
+	all := query(handler, "web.default.svc.cluster.local.", dns.TypeSRV)
+	wrongProtocol := query(handler, "_dns._tcp.web.default.svc.cluster.local.", dns.TypeSRV)
+
+	require.Len(t, named.Answer, 1)
+	assert.Equal(t, uint16(53), named.Answer[0].(*dns.SRV).Port)
+	assert.Equal(t, "web.default.svc.cluster.local.", named.Answer[0].(*dns.SRV).Target)
+	require.Len(t, named.Extra, 1)
+	assert.Equal(t, "10.96.0.10", named.Extra[0].(*dns.A).A.String())
+	assert.Len(t, all.Answer, 3)
+	assert.Equal(t, dns.RcodeNameError, wrongProtocol.Rcode)
+}
+
+func TestDNSHandlerAnswersPTR(t *testing.T) {
+	handler := newTestDNSHandler()
+
+	response := query(handler, "10.0.96.10.in-addr.arpa.", dns.TypePTR)
+
+	require.Len(t, response.Answer, 1)
+	assert.Equal(t, "web.default.svc.cluster.local.", response.Answer[0].(*dns.PTR).Ptr)
+}
+
+func TestDNSHandlerUnknownNames(t *testing.T) {
+	handler := newTestDNSHandler()
+
+	unknownService := query(handler, "db.default.svc.cluster.local.", dns.TypeA)
+	wrongNamespace := query(handler, "web.other.svc.cluster.local.", dns.TypeA)
+	noData := query(handler, "web.default.svc.cluster.local.", dns.TypeAAAA)
+
+	assert.Equal(t, dns.RcodeNameError, unknownService.Rcode)
+	assert.Equal(t, dns.RcodeNameError, wrongNamespace.Rcode)
+	assert.Equal(t, dns.RcodeSuccess, noData.Rcode)
+	assert.Empty(t, noData.Answer)
+}
+
+func TestDNSHandlerRejectsQueriesWithoutQuestion(t *testing.T) {
+	handler := newTestDNSHandler()
+	w := &recordingResponseWriter{remoteAddr: &net.UDPAddr{}}
+
+	handler.ServeDNS(w, &dns.Msg{MsgHdr: dns.MsgHdr{Id: 1}})
+
+	assert.Equal(t, dns.RcodeFormatError, w.msg.Rcode)
+}
+
+func TestDNSHandlerForwardsOtherNames(t *testing.T) {
+	// Arrange
+	upstream, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{PacketConn: upstream, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		msg := new(dns.Msg)
+		msg.SetReply(r)
+		msg.Answer = append(msg.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+			A:   net.IPv4(93, 184, 216, 34),
+		})
+		w.WriteMsg(msg)
+	})}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go server.ActivateAndServe()
+	<-started
+	defer server.Shutdown()
+
+	// The unreachable upstream is skipped
+	handler := newTestDNSHandler("127.0.0.1:1", upstream.LocalAddr().String())
+
+	// Act
+	response := query(handler, "example.com.", dns.TypeA)
+
+	// Assert
+	require.Len(t, response.Answer, 1)
+	assert.Equal(t, "93.184.216.34", response.Answer[0].(*dns.A).A.String())
+	assert.Equal(t, dns.RcodeServerFailure, query(newTestDNSHandler(), "example.com.", dns.TypeA).Rcode)
+}
@@ -1,25 +1,135 @@
 package apiserver
 
 import (
-	"log"
+	"maden/pkg/shared"
+
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/miekg/dns"
 )
 
+const (
+	DefaultDNSPort       = 53
+	DefaultClusterDomain = "cluster.local"
+	dnsShutdownTimeout   = 5 * time.Second
+)
+
+type DNSConfig struct {
+	Port      int
+	Domain    string   // Services are named <service>.<namespace>.svc.<domain>
+	Upstreams []string // Servers the names outside the domain are forwarded to, as host:port
+}
+
+// Reads DNS_PORT, CLUSTER_DOMAIN and DNS_UPSTREAMS, a comma-separated list, from the environment.
+// The upstream servers default to the nameservers of the host
+func NewDNSConfig() DNSConfig {
+	config := DNSConfig{Port: DefaultDNSPort, Domain: DefaultClusterDomain}
+
+	if value, ok := os.LookupEnv("DNS_PORT"); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			shared.Log.Errorf("Invalid DNS_PORT %q, using %d: %v", value, DefaultDNSPort, err)
+		} else {
+			config.Port = port
+		}
+	}
+	if value, ok := os.LookupEnv("CLUSTER_DOMAIN"); ok {
+		config.Domain = strings.Trim(value, ".")
+	}
+
+	if value, ok := os.LookupEnv("DNS_UPSTREAMS"); ok {
+		for _, upstream := range strings.Split(value, ",") {
+			config.Upstreams = append(config.Upstreams, withDefaultDNSPort(strings.TrimSpace(upstream)))
+		}
+	} else if resolvConf, err := dns.ClientConfigFromFile("/etc/resolv.conf"); err == nil {
+		for _, server := range resolvConf.Servers {
+			config.Upstreams = append(config.Upstreams, net.JoinHostPort(server, resolvConf.Port))
+		}
+	}
+	return config
+}
+
+func withDefaultDNSPort(address string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+	return net.JoinHostPort(address, strconv.Itoa(DefaultDNSPort))
+}
+
 type DNSServer struct {
-	DNSHandler *DNSHandler
+	Config  DNSConfig
+	Cache   *DNSRecordCache
+	Handler *DNSHandler
 }
 
-func NewDNSServer(dnsHandler *DNSHandler) *DNSServer {
-	return &DNSServer{DNSHandler: dnsHandler}
+func NewDNSServer(config DNSConfig, cache *DNSRecordCache, handler *DNSHandler) *DNSServer {
+	return &DNSServer{Config: config, Cache: cache, Handler: handler}
 }
 
-func (s *DNSServer) StartDNSServer() {
-	dns.HandleFunc("cluster.local.", s.DNSHandler.DNSQueryHandler)
-	server := &dns.Server{Addr: ":53", Net: "udp"}
-	log.Printf("Starting DNS server on %s", server.Addr)
-	err := server.ListenAndServe()
+// Serves DNS over UDP and TCP until ctx is done, then waits for the queries in flight to be answered.
+// Fails right away if the port cannot be opened
+func (s *DNSServer) Run(ctx context.Context) error {
+	address := net.JoinHostPort("", strconv.Itoa(s.Config.Port))
+	packetConn, err := net.ListenPacket("udp", address)
 	if err != nil {
-		log.Fatalf("Failed to start DNS server: %v", err)
+		return err
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		packetConn.Close()
+		return err
+	}
+
+	cacheCtx, stopCache := context.WithCancel(ctx)
+	defer stopCache()
+	go s.Cache.Run(cacheCtx)
+
+	mux := dns.NewServeMux()
+	mux.Handle(".", s.Handler)
+	servers := []*dns.Server{
+		{PacketConn: packetConn, Handler: mux},
+		{Listener: listener, Handler: mux},
+	}
+
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		started := make(chan struct{})
+		server.NotifyStartedFunc = func() { close(started) }
+		go func(server *dns.Server) {
+			errs <- server.ActivateAndServe()
+		}(server)
+		// Shutting a server down before it started leaves it running
+		select {
+		case <-started:
+		case err := <-errs:
+			shutdownDNSServers(servers)
+			return err
+		}
+	}
+	shared.Log.Infof("Serving DNS for %s on port %d", s.Config.Domain, s.Config.Port)
+
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+		shared.Log.Errorf("DNS server failed: %v", err)
+	}
+
+	shutdownDNSServers(servers)
+	shared.Log.Infof("DNS server stopped")
+	return err
+}
+
+// Servers that never started are skipped
+func shutdownDNSServers(servers []*dns.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsShutdownTimeout)
+	defer cancel()
+
+	for _, server := range servers {
+		_ = server.ShutdownContext(ctx)
 	}
 }
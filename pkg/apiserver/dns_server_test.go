@@ -0,0 +1,68 @@
+package apiserver
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSServerServesUntilStopped(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	mockRepo := mocks.NewMockDNSRepository(ctrl)
+	records := []shared.DNSRecord{{Namespace: "default", Name: "web", IP: "10.96.0.10"}}
+	mockRepo.EXPECT().ListRecords(gomock.Any()).Return(records, int64(1), nil)
+	mockRepo.EXPECT().WatchRecords(gomock.Any(), int64(1)).Return(make(chan shared.WatchEvent[shared.DNSRecord]))
+
+	config := DNSConfig{Port: port, Domain: DefaultClusterDomain}
+	cache := NewDNSRecordCache(mockRepo)
+	server := NewDNSServer(config, cache, NewDNSHandler(cache, config))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- server.Run(ctx)
+	}()
+
+	// Act
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	request := new(dns.Msg)
+	request.SetQuestion("web.default.svc.cluster.local.", dns.TypeA)
+	var answers []*dns.Msg
+	for _, network := range []string{"udp", "tcp"} {
+		client := &dns.Client{Net: network, Timeout: time.Second}
+		var response *dns.Msg
+		assert.Eventually(t, func() bool {
+			response, _, err = client.Exchange(request, address)
+			return err == nil && len(response.Answer) == 1
+		}, 2*time.Second, 20*time.Millisecond)
+		answers = append(answers, response)
+	}
+	cancel()
+
+	// Assert
+	assert.Len(t, answers, 2)
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(dnsShutdownTimeout):
+		t.Fatal("DNS server did not stop")
+	}
+}
@@ -0,0 +1,55 @@
+package apiserver
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/shared"
+
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Endpoints are read-only, the endpoints controller maintains them
+type EndpointsHandler struct {
+	Repo etcd.EndpointsRepository
+}
+
+func NewEndpointsHandler(repo etcd.EndpointsRepository) *EndpointsHandler {
+	return &EndpointsHandler{Repo: repo}
+}
+
+func (h *EndpointsHandler) listEndpointsHandler(w http.ResponseWriter, r *http.Request) {
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	endpoints, err := h.Repo.ListEndpoints(r.Context(), vars["namespace"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(shared.FilterBySelector(endpoints, selector))
+}
+
+func (h *EndpointsHandler) getEndpointsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	endpoints, err := h.Repo.GetEndpoints(r.Context(), vars["namespace"], vars["name"])
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(endpoints)
+}
@@ -0,0 +1,73 @@
+package apiserver
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointsHandlerListEndpointsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEndpointsRepository(ctrl)
+	handler := NewEndpointsHandler(mockRepo)
+
+	endpoints := []shared.Endpoints{
+		{ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		{ObjectMeta: shared.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	}
+	mockRepo.EXPECT().ListEndpoints(gomock.Any(), "default").Return(endpoints, nil)
+
+	req, _ := http.NewRequest("GET", "/namespaces/default/endpoints?labelSelector=app%3Dweb", nil)
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default"})
+	rr := httptest.NewRecorder()
+	handler.listEndpointsHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response []shared.Endpoints
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "web", response[0].Name)
+}
+
+func TestEndpointsHandlerGetEndpointsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEndpointsRepository(ctrl)
+	handler := NewEndpointsHandler(mockRepo)
+
+	endpoints := shared.Endpoints{
+		ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default"},
+		Addresses:  []shared.EndpointAddress{{IP: "172.17.0.2", PodID: "pod-1"}},
+	}
+	mockRepo.EXPECT().GetEndpoints(gomock.Any(), "default", "web").Return(&endpoints, nil)
+
+	req, _ := http.NewRequest("GET", "/namespaces/default/endpoints/web", nil)
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "web"})
+	rr := httptest.NewRecorder()
+	handler.getEndpointsHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response shared.Endpoints
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "172.17.0.2", response.Addresses[0].IP)
+
+	// Test not found error
+	mockRepo.EXPECT().GetEndpoints(gomock.Any(), "default", "missing").Return(nil, &shared.ErrNotFound{})
+
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "missing"})
+	rr = httptest.NewRecorder()
+	handler.getEndpointsHandler(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -5,15 +5,19 @@ import (
 	"maden/pkg/shared"
 
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/gorilla/mux"
 	"gopkg.in/yaml.v3"
 )
 
 type ManifestHandler struct {
+	NController controller.NamespaceController
 	DController controller.DeploymentController
 	SController controller.ServiceController
 	VController controller.PersistentVolumeController
@@ -21,18 +25,22 @@ type ManifestHandler struct {
 }
 
 func NewManifestHandler(
+	nController controller.NamespaceController,
 	dController controller.DeploymentController,
 	sController controller.ServiceController,
 	vController controller.PersistentVolumeController,
 	vcController controller.PersistentVolumeClaimController,
 ) *ManifestHandler {
-	return &ManifestHandler{DController: dController, SController: sController, VController: vController, VCController: vcController}
+	return &ManifestHandler{NController: nController, DController: dController, SController: sController, VController: vController, VCController: vcController}
 }
 
 /*
- * Handler responsible for allocating Maden resources according to a received manifest file
+ * Handler responsible for allocating Maden resources according to a received manifest file.
+ * Namespaced resources that do not name their namespace go to the one of the request, if any, or the default one
  */
 func (h *ManifestHandler) handleMadenResources(w http.ResponseWriter, r *http.Request) {
+	requestNamespace := mux.Vars(r)["namespace"]
+
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, "Failed to read request body", http.StatusBadRequest)
@@ -53,46 +61,83 @@ func (h *ManifestHandler) handleMadenResources(w http.ResponseWriter, r *http.Re
 			return
 		}
 
-		err = h.handleIncomingResource(resource)
+		err = h.handleIncomingResource(r.Context(), resource, requestNamespace)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			var errConflict *shared.ErrConflict
+			var errTerminating *shared.ErrNamespaceTerminating
+			var errNotFound *shared.ErrNotFound
+			var errIPInUse *shared.ErrIPInUse
+			var errIPNotInRange *shared.ErrIPNotInRange
+			var errUnsupportedKind *shared.ErrUnsupportedKind
+			if errors.As(err, &errConflict) {
+				// Changed by someone else while being applied, the client can apply the manifest again
+				http.Error(w, err.Error(), http.StatusConflict)
+			} else if errors.As(err, &errTerminating) || errors.As(err, &errIPInUse) {
+				http.Error(w, err.Error(), http.StatusConflict)
+			} else if errors.As(err, &errIPNotInRange) || errors.As(err, &errUnsupportedKind) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			} else if errors.As(err, &errNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
 		}
 	}
 
 	w.WriteHeader(http.StatusCreated)
 }
 
-func (h *ManifestHandler) handleIncomingResource(resource shared.MadenResource) error {
+func (h *ManifestHandler) handleIncomingResource(ctx context.Context, resource shared.MadenResource, requestNamespace string) error {
 	switch resource.Kind {
+	case "Namespace":
+		err := h.handleIncomingNamespace(ctx, resource)
+		if err != nil {
+			return err
+		}
 	case "Deployment":
-		err := h.handleIncomingDeployment(resource)
+		err := h.handleIncomingDeployment(ctx, resource, requestNamespace)
 		if err != nil {
 			return err
 		}
 	case "Service":
-		err := h.handleIncomingService(resource)
+		err := h.handleIncomingService(ctx, resource, requestNamespace)
 		if err != nil {
 			return err
 		}
 	case "PersistentVolume":
-		err := h.handleIncomingPersistentVolume(resource)
+		err := h.handleIncomingPersistentVolume(ctx, resource)
 		if err != nil {
 			return err
 		}
 	case "PersistentVolumeClaim":
-		err := h.handleIncomingPersistentVolumeClaim(resource)
+		err := h.handleIncomingPersistentVolumeClaim(ctx, resource, requestNamespace)
 		if err != nil {
 			return err
 		}
 	default:
-		errorMsg := fmt.Sprintf("Unsupported kind: %s", resource.Kind)
-		return fmt.Errorf(errorMsg)
+		return &shared.ErrUnsupportedKind{Kind: resource.Kind}
 	}
 
 	return nil
 }
 
-func (h *ManifestHandler) handleIncomingDeployment(resource shared.MadenResource) error {
+func (h *ManifestHandler) handleIncomingNamespace(ctx context.Context, resource shared.MadenResource) error {
+	var namespaceSpec shared.NamespaceSpec
+	specBytes, err := json.Marshal(resource.Spec)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(specBytes, &namespaceSpec)
+	if err != nil {
+		return err
+	}
+
+	return h.NController.HandleIncomingNamespace(ctx, namespaceSpec)
+}
+
+func (h *ManifestHandler) handleIncomingDeployment(ctx context.Context, resource shared.MadenResource, requestNamespace string) error {
 	var deploymentSpec shared.DeploymentSpec
 	specBytes, err := json.Marshal(resource.Spec)
 	if err != nil {
@@ -106,12 +151,17 @@ func (h *ManifestHandler) handleIncomingDeployment(resource shared.MadenResource
 		return err
 	}
 
+	deploymentSpec.Namespace, err = h.resolveNamespace(ctx, deploymentSpec.Namespace, requestNamespace)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("Handling Deployment: %+v\n", deploymentSpec)
 
-	return h.DController.HandleIncomingDeployment(deploymentSpec)
+	return h.DController.HandleIncomingDeployment(ctx, deploymentSpec)
 }
 
-func (h *ManifestHandler) handleIncomingService(resource shared.MadenResource) error {
+func (h *ManifestHandler) handleIncomingService(ctx context.Context, resource shared.MadenResource, requestNamespace string) error {
 	var serviceSpec shared.ServiceSpec
 	specBytes, err := json.Marshal(resource.Spec)
 	if err != nil {
@@ -125,12 +175,17 @@ func (h *ManifestHandler) handleIncomingService(resource shared.MadenResource) e
 		return err
 	}
 
+	serviceSpec.Namespace, err = h.resolveNamespace(ctx, serviceSpec.Namespace, requestNamespace)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("Handling Service: %+v\n", serviceSpec)
 
-	return h.SController.HandleIncomingService(serviceSpec)
+	return h.SController.HandleIncomingService(ctx, serviceSpec)
 }
 
-func (h *ManifestHandler) handleIncomingPersistentVolume(resource shared.MadenResource) error {
+func (h *ManifestHandler) handleIncomingPersistentVolume(ctx context.Context, resource shared.MadenResource) error {
 	var pvSpec shared.PersistentVolumeSpec
 	specBytes, err := json.Marshal(resource.Spec)
 	if err != nil {
@@ -142,7 +197,7 @@ func (h *ManifestHandler) handleIncomingPersistentVolume(resource shared.MadenRe
 		return err
 	}
 
-	err = h.VController.HandleIncomingPersistentVolume(pvSpec)
+	err = h.VController.HandleIncomingPersistentVolume(ctx, pvSpec)
 	if err != nil {
 		return err
 	}
@@ -150,7 +205,7 @@ func (h *ManifestHandler) handleIncomingPersistentVolume(resource shared.MadenRe
 	return nil
 }
 
-func (h *ManifestHandler) handleIncomingPersistentVolumeClaim(resource shared.MadenResource) error {
+func (h *ManifestHandler) handleIncomingPersistentVolumeClaim(ctx context.Context, resource shared.MadenResource, requestNamespace string) error {
 	var pvcSpec shared.PersistentVolumeClaimSpec
 	specBytes, err := json.Marshal(resource.Spec)
 	if err != nil {
@@ -162,10 +217,23 @@ func (h *ManifestHandler) handleIncomingPersistentVolumeClaim(resource shared.Ma
 		return err
 	}
 
-	err = h.VCController.HandleIncomingPersistentVolumeClaim(pvcSpec)
+	pvcSpec.Namespace, err = h.resolveNamespace(ctx, pvcSpec.Namespace, requestNamespace)
+	if err != nil {
+		return err
+	}
+
+	err = h.VCController.HandleIncomingPersistentVolumeClaim(ctx, pvcSpec)
 	if err != nil {
 		return err
 	}
 	
 	return nil
 }
+
+// The namespace has to exist before anything is created in it
+func (h *ManifestHandler) resolveNamespace(ctx context.Context, namespace string, requestNamespace string) (string, error) {
+	if namespace == "" {
+		namespace = shared.NamespaceOrDefault(requestNamespace)
+	}
+	return namespace, h.NController.ValidateNamespace(ctx, namespace)
+}
@@ -3,6 +3,7 @@ package apiserver
 import (
 	"bytes"
 	"maden/pkg/mocks"
+	"maden/pkg/shared"
 	"net/http"
 	"net/http/httptest"
 
@@ -17,11 +18,12 @@ func TestManifestHandlerHandleMadenResources(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	mockNamespaceController := mocks.NewMockNamespaceController(ctrl)
 	mockDeploymentController := mocks.NewMockDeploymentController(ctrl)
 	mockServiceController := mocks.NewMockServiceController(ctrl)
 	mockPersistentVolumeController := mocks.NewMockPersistentVolumeController(ctrl)
 	mockPersistentVolumeClaimController := mocks.NewMockPersistentVolumeClaimController(ctrl)
-	handler := NewManifestHandler(mockDeploymentController, mockServiceController, mockPersistentVolumeController, mockPersistentVolumeClaimController)
+	handler := NewManifestHandler(mockNamespaceController, mockDeploymentController, mockServiceController, mockPersistentVolumeController, mockPersistentVolumeClaimController)
 
 	deploymentYAML := `
 kind: Deployment
@@ -36,12 +38,14 @@ spec:
 `
 	malformedYAML := `kind: Unknown\n`
 
+	mockNamespaceController.EXPECT().ValidateNamespace(gomock.Any(), shared.DefaultNamespace).Return(nil).AnyTimes()
+
 	// Test valid deployment handling
 	req, _ := http.NewRequest("POST", "/maden-resources", bytes.NewBufferString(deploymentYAML))
 	rr := httptest.NewRecorder()
 
 	mockDeploymentController.EXPECT().
-		HandleIncomingDeployment(gomock.Any()).
+		HandleIncomingDeployment(gomock.Any(), gomock.Any()).
 		Return(nil).Times(1)
 
 	handler.handleMadenResources(rr, req)
@@ -52,7 +56,7 @@ spec:
 	rr = httptest.NewRecorder()
 
 	mockServiceController.EXPECT().
-		HandleIncomingService(gomock.Any()).
+		HandleIncomingService(gomock.Any(), gomock.Any()).
 		Return(nil).Times(1)
 
 	handler.handleMadenResources(rr, req)
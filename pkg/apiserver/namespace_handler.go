@@ -0,0 +1,92 @@
+package apiserver
+
+import (
+	"maden/pkg/controller"
+	"maden/pkg/etcd"
+	"maden/pkg/shared"
+
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type NamespaceHandler struct {
+	Repo       etcd.NamespaceRepository
+	Controller controller.NamespaceController
+}
+
+func NewNamespaceHandler(
+	repo etcd.NamespaceRepository,
+	controller controller.NamespaceController,
+) *NamespaceHandler {
+	return &NamespaceHandler{Repo: repo, Controller: controller}
+}
+
+func (h *NamespaceHandler) listNamespacesHandler(w http.ResponseWriter, r *http.Request) {
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	namespaces, err := h.Repo.ListNamespaces(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(shared.FilterBySelector(namespaces, selector))
+}
+
+func (h *NamespaceHandler) createNamespaceHandler(w http.ResponseWriter, r *http.Request) {
+	var namespaceSpec shared.NamespaceSpec
+	if err := json.NewDecoder(r.Body).Decode(&namespaceSpec); err != nil || namespaceSpec.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Controller.HandleIncomingNamespace(r.Context(), namespaceSpec); err != nil {
+		writeNamespaceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Deletes the namespace along with everything in it
+func (h *NamespaceHandler) deleteNamespaceHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	namespaceName := vars["namespace"]
+
+	if namespaceName == shared.DefaultNamespace {
+		http.Error(w, "the default namespace cannot be deleted", http.StatusForbidden)
+		return
+	}
+
+	if err := h.Controller.HandleNamespaceDeletion(r.Context(), namespaceName); err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Objects can only be created in namespaces that exist and are not being deleted
+func writeNamespaceError(w http.ResponseWriter, err error) {
+	var errNotFound *shared.ErrNotFound
+	var errTerminating *shared.ErrNamespaceTerminating
+	if errors.As(err, &errNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if errors.As(err, &errTerminating) {
+		http.Error(w, err.Error(), http.StatusConflict)
+	} else {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,67 @@
+package apiserver
+
+import (
+	"bytes"
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+	"net/http"
+	"net/http/httptest"
+
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceHandlerCreateNamespaceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockController := mocks.NewMockNamespaceController(ctrl)
+	handler := NewNamespaceHandler(nil, mockController)
+
+	mockController.EXPECT().HandleIncomingNamespace(gomock.Any(), shared.NamespaceSpec{ObjectMeta: shared.ObjectMeta{Name: "team-a"}}).Return(nil)
+
+	req, _ := http.NewRequest("POST", "/namespaces", bytes.NewBufferString(`{"name": "team-a"}`))
+	rr := httptest.NewRecorder()
+	handler.createNamespaceHandler(rr, req)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	// A namespace needs a name
+	req, _ = http.NewRequest("POST", "/namespaces", bytes.NewBufferString(`{}`))
+	rr = httptest.NewRecorder()
+	handler.createNamespaceHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestNamespaceHandlerDeleteNamespaceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockController := mocks.NewMockNamespaceController(ctrl)
+	handler := NewNamespaceHandler(nil, mockController)
+
+	// Test case 1: Successful deletion
+	mockController.EXPECT().HandleNamespaceDeletion(gomock.Any(), "team-a").Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/namespaces/team-a", nil)
+	req = mux.SetURLVars(req, map[string]string{"namespace": "team-a"})
+	rr := httptest.NewRecorder()
+	handler.deleteNamespaceHandler(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	// Test case 2: Namespace not found
+	mockController.EXPECT().HandleNamespaceDeletion(gomock.Any(), "team-a").Return(&shared.ErrNotFound{})
+
+	rr = httptest.NewRecorder()
+	handler.deleteNamespaceHandler(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Test case 3: The default namespace is kept
+	req, _ = http.NewRequest("DELETE", "/namespaces/default", nil)
+	req = mux.SetURLVars(req, map[string]string{"namespace": shared.DefaultNamespace})
+	rr = httptest.NewRecorder()
+	handler.deleteNamespaceHandler(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
@@ -21,14 +21,19 @@ func NewNodeHandler(repo etcd.NodeRepository) *NodeHandler {
 }
 
 func (h *NodeHandler) listNodesHandler(w http.ResponseWriter, r *http.Request) {
-	nodes, err := h.Repo.ListNodes()
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	nodes, err := h.Repo.ListNodes(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(nodes)
+	json.NewEncoder(w).Encode(shared.FilterBySelector(nodes, selector))
 }
 
 func (h *NodeHandler) createNodeHandler(w http.ResponseWriter, r *http.Request) {
@@ -38,7 +43,7 @@ func (h *NodeHandler) createNodeHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.Repo.CreateNode(&node); err != nil {
+	if err := h.Repo.CreateNode(r.Context(), &node); err != nil {
 		var dupErr *shared.ErrDuplicateResource
 		if errors.As(err, &dupErr) {
 			http.Error(w, dupErr.Error(), http.StatusConflict)
@@ -57,7 +62,7 @@ func (h *NodeHandler) deleteNodeHandler(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	nodeID := vars["id"]
 
-	if err := h.Repo.DeleteNode(nodeID); err != nil {
+	if err := h.Repo.DeleteNode(r.Context(), nodeID); err != nil {
 		var notFoundErr *shared.ErrNotFound
 		if errors.As(err, &notFoundErr) {
 			w.WriteHeader(http.StatusNotFound)
@@ -23,8 +23,8 @@ func TestNodeHandlerListNodesHandler(t *testing.T) {
     handler := NewNodeHandler(mockRepo)
 
     // Prepare mock data
-    nodes := []shared.Node{{ID: "1", Name: "Node1"}}
-    mockRepo.EXPECT().ListNodes().Return(nodes, nil)
+    nodes := []shared.Node{{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "Node1"}}}
+    mockRepo.EXPECT().ListNodes(gomock.Any()).Return(nodes, nil)
 
     // Create a request and response recorder
     req, err := http.NewRequest("GET", "/nodes", nil)
@@ -49,7 +49,7 @@ func TestNodeHandlerCreateNodeHandler(t *testing.T) {
     mockRepo := mocks.NewMockNodeRepository(ctrl)
     handler := NewNodeHandler(mockRepo)
 
-    node := shared.Node{ID: "1", Name: "Node1"}
+    node := shared.Node{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "Node1"}}
     nodeBytes, _ := json.Marshal(node)
     reader := bytes.NewReader(nodeBytes)
 
@@ -60,7 +60,7 @@ func TestNodeHandlerCreateNodeHandler(t *testing.T) {
     }
     rr := httptest.NewRecorder()
 
-    mockRepo.EXPECT().CreateNode(gomock.Any()).Return(nil)
+    mockRepo.EXPECT().CreateNode(gomock.Any(), gomock.Any()).Return(nil)
     
     handler.createNodeHandler(rr, req)
     
@@ -97,14 +97,14 @@ func TestNodeHandlerDeleteNodeHandler(t *testing.T) {
     rr := httptest.NewRecorder()
 
     // Expectations and call
-    mockRepo.EXPECT().DeleteNode(nodeID).Return(nil)
+    mockRepo.EXPECT().DeleteNode(gomock.Any(), nodeID).Return(nil)
 
     handler.deleteNodeHandler(rr, req)
 
     assert.Equal(t, http.StatusNoContent, rr.Code)
 
     // Test not found error
-    mockRepo.EXPECT().DeleteNode(nodeID).Return(&shared.ErrNotFound{})
+    mockRepo.EXPECT().DeleteNode(gomock.Any(), nodeID).Return(&shared.ErrNotFound{})
     rr = httptest.NewRecorder()
 
     handler.deleteNodeHandler(rr, req)
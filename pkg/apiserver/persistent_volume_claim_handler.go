@@ -25,21 +25,27 @@ func NewPersistentVolumeClaimHandler(
 }
 
 func (h *PersistentVolumeClaimHandler) listPersistentVolumeClaimsHandler(w http.ResponseWriter, r *http.Request) {
-	persistentVolumeClaims, err := h.Repo.ListPersistentVolumeClaims()
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	persistentVolumeClaims, err := h.Repo.ListPersistentVolumeClaims(r.Context(), vars["namespace"])
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(persistentVolumeClaims)
+	json.NewEncoder(w).Encode(shared.FilterBySelector(persistentVolumeClaims, selector))
 }
 
 func (h *PersistentVolumeClaimHandler) deletePersistentVolumeClaimHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	persistentVolumeClaimID := vars["iD"]
+	persistentVolumeClaimID := vars["id"]
 
-	if err := h.Repo.DeletePersistentVolumeClaim(persistentVolumeClaimID); err != nil {
+	if err := h.Repo.DeletePersistentVolumeClaim(r.Context(), vars["namespace"], persistentVolumeClaimID); err != nil {
 		var errNotFound *shared.ErrNotFound
 		if errors.As(err, &errNotFound) {
 			w.WriteHeader(http.StatusNotFound)
@@ -15,31 +15,43 @@ import (
 type PersistentVolumeHandler struct {
 	Repo       etcd.PersistentVolumeRepository
 	Controller controller.PersistentVolumeController
+	GarbageCollector controller.GarbageCollector
 }
 
 func NewPersistentVolumeHandler(
 	repo etcd.PersistentVolumeRepository,
 	Controller controller.PersistentVolumeController,
+	garbageCollector controller.GarbageCollector,
 ) *PersistentVolumeHandler {
-	return &PersistentVolumeHandler{Repo: repo, Controller: Controller}
+	return &PersistentVolumeHandler{Repo: repo, Controller: Controller, GarbageCollector: garbageCollector}
 }
 
 func (h *PersistentVolumeHandler) listPersistentVolumesHandler(w http.ResponseWriter, r *http.Request) {
-	persistentVolumes, err := h.Repo.ListPersistentVolumes()
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	persistentVolumes, err := h.Repo.ListPersistentVolumes(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(persistentVolumes)
+	json.NewEncoder(w).Encode(shared.FilterBySelector(persistentVolumes, selector))
 }
 
 func (h *PersistentVolumeHandler) deletePersistentVolumeHandler(w http.ResponseWriter, r *http.Request) {
+	policy, ok := parsePropagationPolicy(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
-	persistentVolumeID := vars["iD"]
+	persistentVolumeID := vars["id"]
 
-	if err := h.Repo.DeletePersistentVolume(persistentVolumeID); err != nil {
+	if err := h.GarbageCollector.DeletePersistentVolume(r.Context(), persistentVolumeID, policy); err != nil {
 		var errNotFound *shared.ErrNotFound
 		if errors.As(err, &errNotFound) {
 			w.WriteHeader(http.StatusNotFound)
@@ -1,6 +1,7 @@
 package apiserver
 
 import (
+	"maden/pkg/controller"
 	"maden/pkg/etcd"
 	"maden/pkg/orchestrator"
 	"maden/pkg/shared"
@@ -15,36 +16,69 @@ import (
 )
 
 type PodHandler struct {
-	Repo         etcd.PodRepository
-	Orchestrator orchestrator.PodOrchestrator
+	Repo                etcd.PodRepository
+	Orchestrator        orchestrator.PodOrchestrator
+	NamespaceController controller.NamespaceController
 }
 
 func NewPodHandler(
 	repo etcd.PodRepository,
 	orchestrator orchestrator.PodOrchestrator,
+	namespaceController controller.NamespaceController,
 ) *PodHandler {
-	return &PodHandler{Repo: repo, Orchestrator: orchestrator}
+	return &PodHandler{Repo: repo, Orchestrator: orchestrator, NamespaceController: namespaceController}
 }
 
 func (h *PodHandler) listPodsHandler(w http.ResponseWriter, r *http.Request) {
-	pods, err := h.Repo.ListPods()
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	pods, err := h.Repo.ListPods(r.Context(), vars["namespace"])
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(pods)
+	json.NewEncoder(w).Encode(shared.FilterBySelector(pods, selector))
+}
+
+func (h *PodHandler) getPodHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pod, err := h.Repo.GetPodByID(r.Context(), vars["namespace"], vars["id"])
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pod)
 }
 
 func (h *PodHandler) createPodHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
 	var pod shared.Pod
 	if err := json.NewDecoder(r.Body).Decode(&pod); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	err := h.Orchestrator.OrchestratePodCreation(&pod)
+	pod.Namespace = vars["namespace"]
+	if err := h.NamespaceController.ValidateNamespace(r.Context(), pod.Namespace); err != nil {
+		writeNamespaceError(w, err)
+		return
+	}
+
+	err := h.Orchestrator.OrchestratePodCreation(r.Context(), &pod)
 	if err != nil {
 		var dupErr *shared.ErrDuplicateResource
 		if errors.As(err, &dupErr) {
@@ -64,12 +98,13 @@ func (h *PodHandler) deletePodHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	podID := vars["id"]
 
-	pod, err := h.Repo.GetPodByID(podID)
+	pod, err := h.Repo.GetPodByID(r.Context(), vars["namespace"], podID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
+		return
 	}
 
-	if err := h.Orchestrator.OrchestratePodDeletion(pod); err != nil {
+	if err := h.Orchestrator.OrchestratePodDeletion(r.Context(), pod); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -84,7 +119,7 @@ func (h *PodHandler) getPodLogsHandler(w http.ResponseWriter, r *http.Request) {
 	follow := r.URL.Query().Get("follow") == "true"
 
 	ctx := r.Context()
-	logsReader, err := h.Orchestrator.GetPodLogs(ctx, podID, containerID, follow)
+	logsReader, err := h.Orchestrator.GetPodLogs(ctx, vars["namespace"], podID, containerID, follow)
 	if err != nil {
 		shared.Log.Errorf("Failed to retrieve logs: %v", err)
 		http.Error(w, "Failed to get logs", http.StatusInternalServerError)
@@ -161,7 +196,7 @@ func (h *PodHandler) execWebSocketHandler(w http.ResponseWriter, r *http.Request
 			break
 		}
 
-		output, execErr := h.Orchestrator.OrchestrateContainerCommandExecution(ctx, podID, containerID, string(message))
+		output, execErr := h.Orchestrator.OrchestrateContainerCommandExecution(ctx, vars["namespace"], podID, containerID, string(message))
 		if execErr != nil {
 			shared.Log.Errorf("Error executing in container: %v", execErr)
 			continue
@@ -20,10 +20,10 @@ func TestPodHandlerListPodsHandler(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockPodRepository(ctrl)
-	handler := NewPodHandler(mockRepo, nil)
+	handler := NewPodHandler(mockRepo, nil, nil)
 
-	pods := []shared.Pod{{ID: "1", Name: "test-pod"}}
-	mockRepo.EXPECT().ListPods().Return(pods, nil)
+	pods := []shared.Pod{{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "test-pod"}}}
+	mockRepo.EXPECT().ListPods(gomock.Any(), "").Return(pods, nil)
 
 	req, err := http.NewRequest("GET", "/pods", nil)
 	if err != nil {
@@ -41,15 +41,44 @@ func TestPodHandlerListPodsHandler(t *testing.T) {
 	assert.Equal(t, expected, rr.Body.String())
 }
 
+func TestPodHandlerListPodsHandlerLabelSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	handler := NewPodHandler(mockRepo, nil, nil)
+
+	web := shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "web", Labels: map[string]string{"app": "web"}}}
+	db := shared.Pod{ID: "2", ObjectMeta: shared.ObjectMeta{Name: "db", Labels: map[string]string{"app": "db"}}}
+	mockRepo.EXPECT().ListPods(gomock.Any(), "default").Return([]shared.Pod{web, db}, nil)
+
+	req, _ := http.NewRequest("GET", "/namespaces/default/pods?labelSelector=app%3Dweb", nil)
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default"})
+	rr := httptest.NewRecorder()
+	handler.listPodsHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	expectedBytes, _ := json.Marshal([]shared.Pod{web})
+	assert.Equal(t, string(expectedBytes)+"\n", rr.Body.String())
+
+	// Invalid selectors are rejected before listing anything
+	req, _ = http.NewRequest("GET", "/pods?labelSelector=%3Dweb", nil)
+	rr = httptest.NewRecorder()
+	handler.listPodsHandler(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
 func TestPodHandlerCreatePodHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockPodRepository(ctrl)
 	mockOrchestrator := mocks.NewMockPodOrchestrator(ctrl)
-	handler := NewPodHandler(mockRepo, mockOrchestrator)
+	mockNamespaceController := mocks.NewMockNamespaceController(ctrl)
+	handler := NewPodHandler(mockRepo, mockOrchestrator, mockNamespaceController)
 
-	pod := shared.Pod{ID: "1", Name: "test-pod"}
+	pod := shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "test-pod", Namespace: "default"}}
 	podBytes, _ := json.Marshal(pod)
 	reader := bytes.NewReader(podBytes)
 	
@@ -58,9 +87,12 @@ func TestPodHandlerCreatePodHandler(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default"})
+
 	rr := httptest.NewRecorder()
 
-	mockOrchestrator.EXPECT().OrchestratePodCreation(gomock.Any()).Return(nil)
+	mockNamespaceController.EXPECT().ValidateNamespace(gomock.Any(), "default").Return(nil)
+	mockOrchestrator.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Return(nil)
 
 	handler.createPodHandler(rr, req)
 
@@ -70,23 +102,53 @@ func TestPodHandlerCreatePodHandler(t *testing.T) {
 	assert.Equal(t, expected, rr.Body.String())
 }
 
+func TestPodHandlerGetPodHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	handler := NewPodHandler(mockRepo, nil, nil)
+
+	pod := shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "test-pod"}, Status: shared.PodStatus{Phase: shared.PodRunning}}
+	mockRepo.EXPECT().GetPodByID(gomock.Any(), "default", pod.ID).Return(&pod, nil)
+
+	req, _ := http.NewRequest("GET", "/pods/"+pod.ID, nil)
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "id": pod.ID})
+	rr := httptest.NewRecorder()
+	handler.getPodHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response shared.Pod
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, shared.PodRunning, response.Status.Phase)
+
+	// Test not found error
+	mockRepo.EXPECT().GetPodByID(gomock.Any(), "default", "missing").Return(nil, &shared.ErrNotFound{})
+
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "id": "missing"})
+	rr = httptest.NewRecorder()
+	handler.getPodHandler(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
 func TestPodHandlerDeletePodHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockPodRepository(ctrl)
 	mockOrchestrator := mocks.NewMockPodOrchestrator(ctrl)
-	handler := NewPodHandler(mockRepo, mockOrchestrator)
+	handler := NewPodHandler(mockRepo, mockOrchestrator, nil)
 
-	pod := shared.Pod{ID: "1", Name: "test-pod"}
-	mockRepo.EXPECT().GetPodByID(pod.ID).Return(&pod, nil)
-	mockOrchestrator.EXPECT().OrchestratePodDeletion(&pod).Return(nil)
+	pod := shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "test-pod"}}
+	mockRepo.EXPECT().GetPodByID(gomock.Any(), "default", pod.ID).Return(&pod, nil)
+	mockOrchestrator.EXPECT().OrchestratePodDeletion(gomock.Any(), &pod).Return(nil)
 
 	req, err := http.NewRequest("DELETE", "/pods/"+pod.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	req = mux.SetURLVars(req, map[string]string{"id": pod.ID})
+	req = mux.SetURLVars(req, map[string]string{"namespace": "default", "id": pod.ID})
 
 	rr := httptest.NewRecorder()
 
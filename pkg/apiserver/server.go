@@ -2,8 +2,10 @@ package apiserver
 
 import (
 	"maden/pkg/controller"
+	"maden/pkg/orchestrator"
 	"maden/pkg/shared"
 
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -13,69 +15,134 @@ import (
 
 type Server struct {
 	router            *mux.Router
+	NamespaceHandler  *NamespaceHandler
 	PodHandler        *PodHandler
 	NodeHandler       *NodeHandler
 	DeploymentHandler *DeploymentHandler
 	ServiceHandler    *ServiceHandler
+	EndpointsHandler  *EndpointsHandler
 	PersistentVolumeHandler *PersistentVolumeHandler
 	PermanentVolumeClaimHandler *PersistentVolumeClaimHandler
 	ManifestHandler   *ManifestHandler
 
 	ChangeListener *controller.EtcdChangeListener
+	NamespaceController controller.NamespaceController
+	DeploymentReconciler controller.DeploymentReconciler
+	NodeLifecycleController controller.NodeLifecycleController
+	GarbageCollector controller.GarbageCollector
+	EndpointsController controller.EndpointsController
+	PodOrchestrator orchestrator.PodOrchestrator
+	ServiceOrchestrator orchestrator.ServiceOrchestrator
 }
 
 func NewServer(
+	namespaceHandler *NamespaceHandler,
 	podHandler *PodHandler,
 	nodeHandler *NodeHandler,
 	deploymentHandler *DeploymentHandler,
 	serviceHandler *ServiceHandler,
+	endpointsHandler *EndpointsHandler,
 	persistentVolumeHandler *PersistentVolumeHandler,
 	persistentVolumeClaimHandler *PersistentVolumeClaimHandler,
 	manifestHandler *ManifestHandler,
 	changeListener *controller.EtcdChangeListener,
+	namespaceController controller.NamespaceController,
+	deploymentReconciler controller.DeploymentReconciler,
+	nodeLifecycleController controller.NodeLifecycleController,
+	garbageCollector controller.GarbageCollector,
+	endpointsController controller.EndpointsController,
+	podOrchestrator orchestrator.PodOrchestrator,
+	serviceOrchestrator orchestrator.ServiceOrchestrator,
 ) *Server {
 	s := &Server{
 		router:            mux.NewRouter(),
+		NamespaceHandler:  namespaceHandler,
 		PodHandler:        podHandler,
 		NodeHandler:       nodeHandler,
 		DeploymentHandler: deploymentHandler,
 		ServiceHandler:    serviceHandler,
+		EndpointsHandler:  endpointsHandler,
 		PersistentVolumeHandler: persistentVolumeHandler,
 		PermanentVolumeClaimHandler: persistentVolumeClaimHandler,
 		ManifestHandler:   manifestHandler,
 		ChangeListener:    changeListener,
+		NamespaceController: namespaceController,
+		DeploymentReconciler: deploymentReconciler,
+		NodeLifecycleController: nodeLifecycleController,
+		GarbageCollector: garbageCollector,
+		EndpointsController: endpointsController,
+		PodOrchestrator: podOrchestrator,
+		ServiceOrchestrator: serviceOrchestrator,
 	}
 	s.routes()
 	return s
 }
 
+// Namespaced resources are listed across all namespaces through their top-level routes,
+// everything else about them goes through the routes of their namespace
 func (s *Server) routes() {
 	s.router.HandleFunc("/", HomeHandler)
+	s.router.HandleFunc("/namespaces", s.NamespaceHandler.listNamespacesHandler).Methods("GET")
+	s.router.HandleFunc("/namespaces", s.NamespaceHandler.createNamespaceHandler).Methods("POST")
+	s.router.HandleFunc("/namespaces/{namespace}", s.NamespaceHandler.deleteNamespaceHandler).Methods("DELETE")
 	s.router.HandleFunc("/pods", s.PodHandler.listPodsHandler).Methods("GET")
-	s.router.HandleFunc("/pods", s.PodHandler.createPodHandler).Methods("POST")
-	s.router.HandleFunc("/pods/{id}", s.PodHandler.deletePodHandler).Methods("DELETE")
-	s.router.HandleFunc("/pods/{id}/logs", s.PodHandler.getPodLogsHandler).Methods("GET")
-	s.router.HandleFunc("/pods/{id}/exec", s.PodHandler.execWebSocketHandler).Methods("GET")
+	s.router.HandleFunc("/deployments", s.DeploymentHandler.listDeploymentsHandler).Methods("GET")
+	s.router.HandleFunc("/services", s.ServiceHandler.listServicesHandler).Methods("GET")
+	s.router.HandleFunc("/endpoints", s.EndpointsHandler.listEndpointsHandler).Methods("GET")
+	s.router.HandleFunc("/persistent-volume-claims", s.PermanentVolumeClaimHandler.listPersistentVolumeClaimsHandler).Methods("GET")
 	s.router.HandleFunc("/nodes", s.NodeHandler.listNodesHandler).Methods("GET")
 	s.router.HandleFunc("/nodes", s.NodeHandler.createNodeHandler).Methods("POST")
 	s.router.HandleFunc("/nodes/{id}", s.NodeHandler.deleteNodeHandler).Methods("DELETE")
-	s.router.HandleFunc("/deployments", s.DeploymentHandler.listDeploymentsHandler).Methods("GET")
-	s.router.HandleFunc("/deployments/{name}", s.DeploymentHandler.deleteDeploymentHandler).Methods("DELETE")
-	s.router.HandleFunc("/deployments/{name}/rollout-restart", s.DeploymentHandler.rolloutRestartDeploymentHandler).Methods("POST")
-	s.router.HandleFunc("/deployments/{name}/scale", s.DeploymentHandler.scaleDeploymentHandler).Methods("POST")
-	s.router.HandleFunc("/services", s.ServiceHandler.listServicesHandler).Methods("GET")
-	s.router.HandleFunc("/services/{name}", s.ServiceHandler.deleteServiceHandler).Methods("DELETE")
 	s.router.HandleFunc("/persistent-volumes", s.PersistentVolumeHandler.listPersistentVolumesHandler).Methods("GET")
 	s.router.HandleFunc("/persistent-volumes/{id}", s.PersistentVolumeHandler.deletePersistentVolumeHandler).Methods("DELETE")
-	s.router.HandleFunc("/persistent-volume-claims", s.PermanentVolumeClaimHandler.listPersistentVolumeClaimsHandler).Methods("GET")
-	s.router.HandleFunc("/persistent-volume-claims/{id}", s.PermanentVolumeClaimHandler.deletePersistentVolumeClaimHandler).Methods("DELETE")
 	s.router.HandleFunc("/manifests", s.ManifestHandler.handleMadenResources).Methods("POST")
+
+	ns := s.router.PathPrefix("/namespaces/{namespace}").Subrouter()
+	ns.HandleFunc("/pods", s.PodHandler.listPodsHandler).Methods("GET")
+	ns.HandleFunc("/pods", s.PodHandler.createPodHandler).Methods("POST")
+	ns.HandleFunc("/pods/{id}", s.PodHandler.getPodHandler).Methods("GET")
+	ns.HandleFunc("/pods/{id}", s.PodHandler.deletePodHandler).Methods("DELETE")
+	ns.HandleFunc("/pods/{id}/logs", s.PodHandler.getPodLogsHandler).Methods("GET")
+	ns.HandleFunc("/pods/{id}/exec", s.PodHandler.execWebSocketHandler).Methods("GET")
+	ns.HandleFunc("/deployments", s.DeploymentHandler.listDeploymentsHandler).Methods("GET")
+	ns.HandleFunc("/deployments/{name}", s.DeploymentHandler.deleteDeploymentHandler).Methods("DELETE")
+	ns.HandleFunc("/deployments/{name}/rollout-restart", s.DeploymentHandler.rolloutRestartDeploymentHandler).Methods("POST")
+	ns.HandleFunc("/deployments/{name}/rollout/pause", s.DeploymentHandler.pauseRolloutHandler).Methods("POST")
+	ns.HandleFunc("/deployments/{name}/rollout/resume", s.DeploymentHandler.resumeRolloutHandler).Methods("POST")
+	ns.HandleFunc("/deployments/{name}/revisions", s.DeploymentHandler.listDeploymentRevisionsHandler).Methods("GET")
+	ns.HandleFunc("/deployments/{name}/rollback", s.DeploymentHandler.rollbackDeploymentHandler).Methods("POST")
+	ns.HandleFunc("/deployments/{name}/scale", s.DeploymentHandler.scaleDeploymentHandler).Methods("POST")
+	ns.HandleFunc("/services", s.ServiceHandler.listServicesHandler).Methods("GET")
+	ns.HandleFunc("/services/{name}", s.ServiceHandler.deleteServiceHandler).Methods("DELETE")
+	ns.HandleFunc("/endpoints", s.EndpointsHandler.listEndpointsHandler).Methods("GET")
+	ns.HandleFunc("/endpoints/{name}", s.EndpointsHandler.getEndpointsHandler).Methods("GET")
+	ns.HandleFunc("/persistent-volume-claims", s.PermanentVolumeClaimHandler.listPersistentVolumeClaimsHandler).Methods("GET")
+	ns.HandleFunc("/persistent-volume-claims/{id}", s.PermanentVolumeClaimHandler.deletePersistentVolumeClaimHandler).Methods("DELETE")
+	ns.HandleFunc("/manifests", s.ManifestHandler.handleMadenResources).Methods("POST")
 }
 
 func (s *Server) Start() {
+	if err := s.NamespaceController.EnsureDefaultNamespace(context.Background()); err != nil {
+		shared.Log.Errorf("Failed to create the default namespace: %v", err)
+	}
+	// Before any request can allocate a service IP
+	if err := s.ServiceOrchestrator.RepairServiceIPs(context.Background()); err != nil {
+		shared.Log.Errorf("Failed to repair the IPs of the services: %v", err)
+	}
+	// Before any pod is started by this process
+	if err := s.PodOrchestrator.RepairPodIPs(context.Background()); err != nil {
+		shared.Log.Errorf("Failed to repair the IPs of the pods: %v", err)
+	}
+
 	go s.ChangeListener.WatchDeployments()
 	go s.ChangeListener.WatchServices()
-	go s.ChangeListener.WatchPodStatusChanges()
+	go s.ChangeListener.WatchNodes()
+	go s.DeploymentReconciler.Run(context.Background())
+	go s.NodeLifecycleController.Run(context.Background())
+	go s.GarbageCollector.Run(context.Background())
+	go s.EndpointsController.Run(context.Background())
+	go s.PodOrchestrator.RunSchedulingLoop(context.Background())
+	go s.PodOrchestrator.RunPodStatusLoop(context.Background())
 
 	server := &http.Server{
 		Addr:         ":8080",
@@ -95,3 +162,23 @@ func (s *Server) Start() {
 func HomeHandler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "Welcome to the Maden API Server")
 }
+
+// Every list endpoint can be narrowed down with ?labelSelector=app=web,tier!=db
+func parseLabelSelector(w http.ResponseWriter, r *http.Request) (shared.Selector, bool) {
+	selector, err := shared.ParseSelector(r.URL.Query().Get("labelSelector"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return selector, true
+}
+
+// Deletions of owners can choose what happens to their dependents with ?propagationPolicy=Foreground, Background or Orphan
+func parsePropagationPolicy(w http.ResponseWriter, r *http.Request) (shared.DeletionPropagation, bool) {
+	policy, err := shared.ParseDeletionPropagation(r.URL.Query().Get("propagationPolicy"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return policy, false
+	}
+	return policy, true
+}
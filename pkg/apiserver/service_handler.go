@@ -25,21 +25,27 @@ func NewServiceHandler(
 }
 
 func (h *ServiceHandler) listServicesHandler(w http.ResponseWriter, r *http.Request) {
-	services, err := h.Repo.ListServices()
+	selector, ok := parseLabelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	services, err := h.Repo.ListServices(r.Context(), vars["namespace"])
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(services)
+	json.NewEncoder(w).Encode(shared.FilterBySelector(services, selector))
 }
 
 func (h *ServiceHandler) deleteServiceHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	serviceName := vars["name"]
 
-	if err := h.SvcOrchestrator.OrchestrateServiceDeletion(serviceName); err != nil {
+	if err := h.SvcOrchestrator.OrchestrateServiceDeletion(r.Context(), vars["namespace"], serviceName); err != nil {
 		var errNotFound *shared.ErrNotFound
 		if errors.As(err, &errNotFound) {
 			w.WriteHeader(http.StatusNotFound)
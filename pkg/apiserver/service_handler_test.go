@@ -23,8 +23,8 @@ func TestServiceHandlerListServicesHandler(t *testing.T) {
     handler := NewServiceHandler(mockRepo, nil)
 
     // Prepare mock data
-    services := []shared.Service{{ID: "1", Name: "Service1"}}
-    mockRepo.EXPECT().ListServices().Return(services, nil)
+    services := []shared.Service{{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "Service1"}}}
+    mockRepo.EXPECT().ListServices(gomock.Any(), "").Return(services, nil)
 
     // Create a request and response recorder
     req, err := http.NewRequest("GET", "/services", nil)
@@ -52,13 +52,13 @@ func TestServiceHandlerDeleteServiceHandler(t *testing.T) {
     serviceName := "example-service"
 
     // Test case 1: Successful deletion
-    mockOrchestrator.EXPECT().OrchestrateServiceDeletion(serviceName).Return(nil)
+    mockOrchestrator.EXPECT().OrchestrateServiceDeletion(gomock.Any(), "default", serviceName).Return(nil)
 
     req, err := http.NewRequest("DELETE", "/services/"+serviceName, nil)
     if err != nil {
         t.Fatal(err)
     }
-    req = mux.SetURLVars(req, map[string]string{"name": serviceName})
+    req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": serviceName})
 
     rr := httptest.NewRecorder()
 
@@ -67,7 +67,7 @@ func TestServiceHandlerDeleteServiceHandler(t *testing.T) {
     assert.Equal(t, http.StatusNoContent, rr.Code)
 
     // Test case 2: Service not found
-    mockOrchestrator.EXPECT().OrchestrateServiceDeletion(serviceName).Return(&shared.ErrNotFound{})
+    mockOrchestrator.EXPECT().OrchestrateServiceDeletion(gomock.Any(), "default", serviceName).Return(&shared.ErrNotFound{})
 
     rr = httptest.NewRecorder()
 
@@ -76,7 +76,7 @@ func TestServiceHandlerDeleteServiceHandler(t *testing.T) {
     assert.Equal(t, http.StatusNotFound, rr.Code)
 
     // Test case 3: Other errors
-    mockOrchestrator.EXPECT().OrchestrateServiceDeletion(serviceName).Return(errors.New("internal error"))
+    mockOrchestrator.EXPECT().OrchestrateServiceDeletion(gomock.Any(), "default", serviceName).Return(errors.New("internal error"))
 
     rr = httptest.NewRecorder()
 
@@ -22,7 +22,7 @@ var getDeploymentsCmd = &cobra.Command{
 	Short: "Fetches current Maden deployments",
 	Long: `Fetches and displays the currently active Maden deployments along with their details`,
 	Run: func(cmd *cobra.Command, args []string) {
-		response, err := http.Get("http://localhost:8080/deployments")
+		response, err := http.Get(listURL("deployments"))
 		if err != nil {
 			fmt.Println("Error fetching data: ", err)
 			return
@@ -47,11 +47,12 @@ var getDeploymentsCmd = &cobra.Command{
 
 func displayDeployments(deployments []shared.Deployment) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Replicas", "Container Port"})
+	table.SetHeader([]string{"Namespace", "ID", "Name", "Replicas", "Container Port"})
 	table.SetBorder(false)
 
 	for _, deployment := range deployments {
 		table.Append([]string{
+			deployment.Namespace,
 			deployment.ID,
 			deployment.Name,
 			fmt.Sprint(deployment.Replicas),
@@ -70,7 +71,8 @@ var deleteDeploymentCmd = &cobra.Command{
 	
 maden delete deployment 1234
 
-This command will delete the deployment with name 1234 from the system`,
+This command will delete the deployment with name 1234 from the system. Its pods are deleted afterwards by default,
+before the deployment with --cascade=foreground, or left running with --cascade=orphan`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		deploymentName := args[0]
@@ -80,18 +82,20 @@ This command will delete the deployment with name 1234 from the system`,
 			return
 		}
 		
-		err := deleteDeployment(deploymentName)
+		namespaces, err := deleteInNamespaces(func(namespaceName string) error {
+			return deleteDeployment(namespaceName, deploymentName)
+		})
 		if err != nil {
 			fmt.Printf("Error deleting deployment: %s\n", err)
 			return
 		}
-		fmt.Printf("Deployment %s deleted successfully\n", deploymentName)
+		fmt.Printf("Deployment %s deleted successfully from %s\n", deploymentName, strings.Join(namespaces, ", "))
 	},
 }
 
 func addDeploymentConfirmationPrompt(deploymentName string) bool {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Warning: This will delete deployment %s and all associated pods. Continue? (y/n): ", deploymentName)
+	fmt.Printf("Warning: This will delete deployment %s %s and all associated pods. Continue? (y/n): ", deploymentName, deleteScope())
 	
 	response, err := reader.ReadString('\n')
 	response = strings.TrimSpace(response)
@@ -107,8 +111,13 @@ func addDeploymentConfirmationPrompt(deploymentName string) bool {
 	return true
 }
 
-func deleteDeployment(deploymentName string) error {
-	request, err := http.NewRequest("DELETE", fmt.Sprintf("http://localhost:8080/deployments/%s", deploymentName), nil)
+func deleteDeployment(namespaceName string, deploymentName string) error {
+	deleteURL, err := withPropagationPolicy(namespaceURL(namespaceName, "deployments", deploymentName))
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("DELETE", deleteURL, nil)
 	if err != nil {
 		return err
 	}
@@ -120,6 +129,9 @@ func deleteDeployment(deploymentName string) error {
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNotFound {
+		return errResourceNotFound
+	}
 	if response.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("failed to delete deployment with status: %s", response.Status)
 	}
@@ -155,7 +167,7 @@ This command will restart the deployment named 'example-deployment'.`,
 }
 
 func rolloutRestartDeployment(deploymentName string) error {
-	request, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:8080/deployments/%s/rollout-restart", deploymentName), nil)
+	request, err := http.NewRequest("POST", namespacedURL("deployments", deploymentName, "rollout-restart"), nil)
 	if err != nil {
 		return err
 	}
@@ -174,6 +186,187 @@ func rolloutRestartDeployment(deploymentName string) error {
 	return nil
 }
 
+var rolloutPauseDeploymentCmd = &cobra.Command{
+	Use:   "pause [deploymentName]",
+	Short: "Pauses the rollout of a Maden deployment",
+	Long: `Pauses the rollout of a Maden deployment by name. Pods created from an outdated template are left in place until the rollout is resumed. For example:
+
+maden rollout pause example-deployment
+
+This command will pause the rollout of the deployment named 'example-deployment'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentName := args[0]
+
+		err := setRolloutPaused(deploymentName, "pause")
+		if err != nil {
+			fmt.Printf("Error pausing deployment rollout: %s\n", err)
+			return
+		}
+		fmt.Printf("Deployment '%s' rollout paused\n", deploymentName)
+	},
+}
+
+var rolloutResumeDeploymentCmd = &cobra.Command{
+	Use:   "resume [deploymentName]",
+	Short: "Resumes the rollout of a Maden deployment",
+	Long: `Resumes a paused rollout of a Maden deployment by name. For example:
+
+maden rollout resume example-deployment
+
+This command will resume the rollout of the deployment named 'example-deployment'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentName := args[0]
+
+		err := setRolloutPaused(deploymentName, "resume")
+		if err != nil {
+			fmt.Printf("Error resuming deployment rollout: %s\n", err)
+			return
+		}
+		fmt.Printf("Deployment '%s' rollout resumed\n", deploymentName)
+	},
+}
+
+func setRolloutPaused(deploymentName string, action string) error {
+	request, err := http.NewRequest("POST", namespacedURL("deployments", deploymentName, "rollout", action), nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to %s deployment rollout with status: %s", action, response.Status)
+	}
+
+	return nil
+}
+
+var rolloutHistoryDeploymentCmd = &cobra.Command{
+	Use:   "history [deploymentName]",
+	Short: "Shows the rollout history of a Maden deployment",
+	Long: `Lists the recorded revisions of a Maden deployment by name, oldest first. For example:
+
+maden rollout history example-deployment
+
+This command will show the revisions of the deployment named 'example-deployment'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentName := args[0]
+
+		revisions, err := getDeploymentRevisions(deploymentName)
+		if err != nil {
+			fmt.Printf("Error fetching deployment history: %s\n", err)
+			return
+		}
+
+		displayDeploymentRevisions(revisions)
+	},
+}
+
+func getDeploymentRevisions(deploymentName string) ([]shared.DeploymentRevision, error) {
+	response, err := http.Get(namespacedURL("deployments", deploymentName, "revisions"))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch deployment revisions with status: %s", response.Status)
+	}
+
+	var revisions []shared.DeploymentRevision
+	if err := json.NewDecoder(response.Body).Decode(&revisions); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func displayDeploymentRevisions(revisions []shared.DeploymentRevision) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Revision", "Template Hash", "Images", "Created"})
+	table.SetBorder(false)
+
+	for i, revision := range revisions {
+		images := make([]string, 0)
+		for _, container := range revision.Template.Spec.Containers {
+			images = append(images, container.Image)
+		}
+
+		revisionNumber := fmt.Sprint(revision.Revision)
+		if i == len(revisions)-1 {
+			revisionNumber += " (current)"
+		}
+
+		table.Append([]string{
+			revisionNumber,
+			revision.TemplateHash,
+			strings.Join(images, ", "),
+			revision.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	table.Render()
+}
+
+var toRevision int
+
+var rolloutUndoDeploymentCmd = &cobra.Command{
+	Use:   "undo [deploymentName]",
+	Short: "Rolls a Maden deployment back to a previous revision",
+	Long: `Rolls a Maden deployment back to a previous revision by name. Without --to-revision, the revision before the current one is used. For example:
+
+maden rollout undo example-deployment --to-revision 2
+
+This command will roll the deployment named 'example-deployment' back to the template of revision 2.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deploymentName := args[0]
+
+		err := rollbackDeployment(deploymentName, toRevision)
+		if err != nil {
+			fmt.Printf("Error rolling back deployment: %s\n", err)
+			return
+		}
+		fmt.Printf("Deployment '%s' rolled back\n", deploymentName)
+	},
+}
+
+func rollbackDeployment(deploymentName string, revision int) error {
+	rollbackRequest := shared.RollbackRequest{Revision: revision}
+	requestBody, err := json.Marshal(rollbackRequest)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", namespacedURL("deployments", deploymentName, "rollback"), bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		responseBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("failed to roll back deployment with status %s: %s", response.Status, string(responseBody))
+	}
+
+	return nil
+}
+
 var scaleDeploymentCmd = &cobra.Command{
 	Use:   "scale [deploymentName] [replicas]",
 	Short: "Scales a Maden deployment",
@@ -207,7 +400,7 @@ func scaleDeployment(deploymentName string, replicas int) error {
 		return err
 	}
 
-	request, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:8080/deployments/%s/scale", deploymentName), bytes.NewBuffer(requestBody))
+	request, err := http.NewRequest("POST", namespacedURL("deployments", deploymentName, "scale"), bytes.NewBuffer(requestBody))
 	if err != nil {
 		return err
 	}
@@ -233,5 +426,10 @@ func init() {
 	deleteCmd.AddCommand(deleteDeploymentCmd)
 	rootCmd.AddCommand(rolloutCmd)
 	rolloutCmd.AddCommand(rolloutRestartDeploymentCmd)
+	rolloutCmd.AddCommand(rolloutPauseDeploymentCmd)
+	rolloutCmd.AddCommand(rolloutResumeDeploymentCmd)
+	rolloutCmd.AddCommand(rolloutHistoryDeploymentCmd)
+	rolloutCmd.AddCommand(rolloutUndoDeploymentCmd)
+	rolloutUndoDeploymentCmd.Flags().IntVar(&toRevision, "to-revision", 0, "The revision to roll back to. Defaults to the previous revision")
 	rootCmd.AddCommand(scaleDeploymentCmd)
 }
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"maden/pkg/shared"
+
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var getEndpointsCmd = &cobra.Command{
+	Use:   "endpoints",
+	Short: "Fetches the endpoints of the Maden services",
+	Long:  `Fetches the pods currently backing every Maden service, by calling the API server, and displays their addresses.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		response, err := http.Get(listURL("endpoints"))
+		if err != nil {
+			fmt.Println("Error fetching data: ", err)
+			return
+		}
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			fmt.Println("Error reading response: ", err)
+			return
+		}
+
+		var endpoints []shared.Endpoints
+		if err := json.Unmarshal(body, &endpoints); err != nil {
+			fmt.Println("Error decoding JSON: ", err)
+			return
+		}
+
+		displayEndpoints(endpoints)
+	},
+}
+
+func displayEndpoints(endpointsList []shared.Endpoints) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Namespace", "Name", "Endpoints", "Not Ready"})
+	table.SetBorder(false)
+
+	for _, endpoints := range endpointsList {
+		table.Append([]string{
+			endpoints.Namespace,
+			endpoints.Name,
+			formatEndpointAddresses(endpoints),
+			fmt.Sprint(len(endpoints.NotReadyAddresses)),
+		})
+	}
+
+	table.Render()
+}
+
+// Every ready address at every port, e.g. "172.17.0.2:8080,172.17.0.3:8080"
+func formatEndpointAddresses(endpoints shared.Endpoints) string {
+	var addresses []string
+	for _, address := range endpoints.Addresses {
+		for _, port := range endpoints.Ports {
+			addresses = append(addresses, net.JoinHostPort(address.IP, strconv.Itoa(port.Port)))
+		}
+	}
+	if len(addresses) == 0 {
+		return "<none>"
+	}
+	return strings.Join(addresses, ",")
+}
+
+func init() {
+	getCmd.AddCommand(getEndpointsCmd)
+}
@@ -45,7 +45,7 @@ This command will create deployments and services in the Maden cluster based on
 }
 
 func applyResources(fileContent []byte) error {
-	request, err := http.NewRequest("POST", "http://localhost:8080/manifests", bytes.NewBuffer(fileContent))
+	request, err := http.NewRequest("POST", namespacedURL("manifests"), bytes.NewBuffer(fileContent))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"maden/pkg/shared"
+	"strings"
+
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var getNamespacesCmd = &cobra.Command{
+	Use: "namespace",
+	Short: "Fetches current Maden namespaces",
+	Long: `Fetches and displays the Maden namespaces along with their status.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		response, err := http.Get(withLabelSelector(apiServerURL + "/namespaces"))
+		if err != nil {
+			fmt.Println("Error fetching data: ", err)
+			return
+		}
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			fmt.Println("Error reading response: ", err)
+			return
+		}
+
+		var namespaces []shared.Namespace
+		if err := json.Unmarshal(body, &namespaces); err != nil {
+			fmt.Println("Error decoding JSON: ", err)
+			return
+		}
+
+		displayNamespaces(namespaces)
+	},
+}
+
+func displayNamespaces(namespaces []shared.Namespace) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Status", "Created"})
+	table.SetBorder(false)
+
+	for _, namespace := range namespaces {
+		table.Append([]string{
+			namespace.Name,
+			namespace.Phase.String(),
+			namespace.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	table.Render()
+}
+
+var deleteNamespaceCmd = &cobra.Command{
+	Use: "namespace [name]",
+	Short: "Deletes a Maden namespace",
+	Long: `Deletes a Maden namespace by name. For example:
+
+maden delete namespace team-a
+
+This command will delete the namespace team-a along with all deployments, pods, services and persistent volume claims in it. The default namespace cannot be deleted.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		namespaceName := args[0]
+
+		continueDelete := addNamespaceConfirmationPrompt(namespaceName)
+		if !continueDelete {
+			return
+		}
+
+		err := deleteNamespace(namespaceName)
+		if err != nil {
+			fmt.Printf("Error deleting namespace: %s\n", err)
+			return
+		}
+		fmt.Printf("Namespace %s deleted successfully\n", namespaceName)
+	},
+}
+
+func addNamespaceConfirmationPrompt(namespaceName string) bool {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("Warning: This will delete namespace %s and everything in it. Continue? (y/n): ", namespaceName)
+
+	response, err := reader.ReadString('\n')
+	response = strings.TrimSpace(response)
+	if err != nil {
+		fmt.Printf("Error reading input: %s\n", err)
+		return false
+	}
+	if strings.ToLower(response) != "y" {
+		fmt.Println("Deletion aborted.")
+		return false
+	}
+
+	return true
+}
+
+func deleteNamespace(namespaceName string) error {
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/namespaces/%s", apiServerURL, namespaceName), nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete namespace with status: %s", response.Status)
+	}
+
+	return nil
+}
+
+func init() {
+	getCmd.AddCommand(getNamespacesCmd)
+	deleteCmd.AddCommand(deleteNamespaceCmd)
+}
@@ -20,7 +20,7 @@ var getNodesCmd = &cobra.Command{
 	Short: "Fetches current Maden nodes",
 	Long: `Fetches and displays the currently active Maden nodes, along with their details.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		response, err := http.Get("http://localhost:8080/nodes")
+		response, err := http.Get(withLabelSelector(apiServerURL + "/nodes"))
 		if err != nil {
 			fmt.Println("Error fetching data: ", err)
 			return
@@ -20,7 +20,7 @@ var getPersistentVolumesCmd = &cobra.Command{
 	Short: "Fetches current Maden persistentVolumes",
 	Long:  `Fetches and displays the currently active Maden persistentVolumes along with their details`,
 	Run: func(cmd *cobra.Command, args []string) {
-		response, err := http.Get("http://localhost:8080/persistentVolumes")
+		response, err := http.Get(withLabelSelector(apiServerURL + "/persistent-volumes"))
 		if err != nil {
 			fmt.Println("Error fetching data: ", err)
 			return
@@ -67,7 +67,7 @@ var deletePersistentVolumeCmd = &cobra.Command{
 	
 maden delete persistentVolume 1234
 
-This command will delete the persistentVolume with name 1234 from the system`,
+This command will delete the persistentVolume with name 1234 from the system. The volume claims bound to it are deleted as well, unless --cascade=orphan is given`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		persistentVolumeName := args[0]
@@ -88,7 +88,7 @@ This command will delete the persistentVolume with name 1234 from the system`,
 
 func addPersistentVolumeConfirmationPrompt(persistentVolumeName string) bool {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Warning: This will delete persistentVolume %s and all associated pods. Continue? (y/n): ", persistentVolumeName)
+	fmt.Printf("Warning: This will delete persistentVolume %s and the volume claims bound to it. Continue? (y/n): ", persistentVolumeName)
 
 	response, err := reader.ReadString('\n')
 	response = strings.TrimSpace(response)
@@ -105,7 +105,12 @@ func addPersistentVolumeConfirmationPrompt(persistentVolumeName string) bool {
 }
 
 func deletePersistentVolume(persistentVolumeName string) error {
-	request, err := http.NewRequest("DELETE", fmt.Sprintf("http://localhost:8080/persistentVolumes/%s", persistentVolumeName), nil)
+	deleteURL, err := withPropagationPolicy(fmt.Sprintf("%s/persistent-volumes/%s", apiServerURL, persistentVolumeName))
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("DELETE", deleteURL, nil)
 	if err != nil {
 		return err
 	}
@@ -20,7 +20,7 @@ var getPersistentVolumeClaimsCmd = &cobra.Command{
 	Short: "Fetches current Maden persistentVolumeClaims",
 	Long:  `Fetches and displays the currently active Maden persistentVolumeClaims along with their details`,
 	Run: func(cmd *cobra.Command, args []string) {
-		response, err := http.Get("http://localhost:8080/persistentVolumeClaims")
+		response, err := http.Get(listURL("persistent-volume-claims"))
 		if err != nil {
 			fmt.Println("Error fetching data: ", err)
 			return
@@ -45,11 +45,12 @@ var getPersistentVolumeClaimsCmd = &cobra.Command{
 
 func displayPersistentVolumeClaims(persistentVolumeClaims []shared.PersistentVolumeClaim) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Replicas", "Container Port"})
+	table.SetHeader([]string{"Namespace", "ID", "Name", "Volume Name", "Access Modes"})
 	table.SetBorder(false)
 
 	for _, persistentVolumeClaim := range persistentVolumeClaims {
 		table.Append([]string{
+			persistentVolumeClaim.Namespace,
 			persistentVolumeClaim.ID,
 			persistentVolumeClaim.Name,
 			fmt.Sprint(persistentVolumeClaim.VolumeName),
@@ -77,18 +78,20 @@ This command will delete the persistentVolumeClaim with name 1234 from the syste
 			return
 		}
 
-		err := deletePersistentVolumeClaim(persistentVolumeClaimName)
+		namespaces, err := deleteInNamespaces(func(namespaceName string) error {
+			return deletePersistentVolumeClaim(namespaceName, persistentVolumeClaimName)
+		})
 		if err != nil {
 			fmt.Printf("Error deleting persistentVolumeClaim: %s\n", err)
 			return
 		}
-		fmt.Printf("PersistentVolumeClaim %s deleted successfully\n", persistentVolumeClaimName)
+		fmt.Printf("PersistentVolumeClaim %s deleted successfully from %s\n", persistentVolumeClaimName, strings.Join(namespaces, ", "))
 	},
 }
 
 func addPersistentVolumeClaimConfirmationPrompt(persistentVolumeClaimName string) bool {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Warning: This will delete persistentVolumeClaim %s and all associated pods. Continue? (y/n): ", persistentVolumeClaimName)
+	fmt.Printf("Warning: This will delete persistentVolumeClaim %s %s and all associated pods. Continue? (y/n): ", persistentVolumeClaimName, deleteScope())
 
 	response, err := reader.ReadString('\n')
 	response = strings.TrimSpace(response)
@@ -104,8 +107,8 @@ func addPersistentVolumeClaimConfirmationPrompt(persistentVolumeClaimName string
 	return true
 }
 
-func deletePersistentVolumeClaim(persistentVolumeClaimName string) error {
-	request, err := http.NewRequest("DELETE", fmt.Sprintf("http://localhost:8080/persistentVolumeClaims/%s", persistentVolumeClaimName), nil)
+func deletePersistentVolumeClaim(namespaceName string, persistentVolumeClaimName string) error {
+	request, err := http.NewRequest("DELETE", namespaceURL(namespaceName, "persistent-volume-claims", persistentVolumeClaimName), nil)
 	if err != nil {
 		return err
 	}
@@ -117,6 +120,9 @@ func deletePersistentVolumeClaim(persistentVolumeClaimName string) error {
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNotFound {
+		return errResourceNotFound
+	}
 	if response.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("failed to delete persistentVolumeClaim with status: %s", response.Status)
 	}
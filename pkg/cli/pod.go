@@ -8,6 +8,8 @@ import (
 	"maden/pkg/shared"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/olekukonko/tablewriter"
@@ -19,7 +21,7 @@ var getPodsCmd = &cobra.Command{
 	Short: "Fetches current Maden pods",
 	Long: `Fetches and displays the current Maden pods and their details`,
 	Run: func(cmd *cobra.Command, args []string) {
-		response, err := http.Get("http://localhost:8080/pods")
+		response, err := http.Get(listURL("pods"))
 		if err != nil {
 			fmt.Println("Error fetching data: ", err)
 			return
@@ -43,22 +45,233 @@ var getPodsCmd = &cobra.Command{
 }
 
 func displayPods(pods []shared.Pod) {
+	wide := outputFormat == "wide"
+
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Status", "Node ID", "CPU", "Memory (MB)"})
+	header := []string{"Namespace", "ID", "Name", "Status", "Node ID", "CPU", "Memory (MB)", "Labels", "Message"}
+	if wide {
+		header = append(header, "Ready", "Restarts", "Started", "IP", "Reason")
+	}
+	table.SetHeader(header)
 	table.SetBorder(false)
 
 	for _, pod := range pods {
-		table.Append([]string{
+		row := []string{
+			pod.Namespace,
 			pod.ID,
 			pod.Name,
-			pod.Status.String(),
+			formatPodStatus(pod),
 			pod.NodeID,
 			fmt.Sprint(pod.Resources.CPU),
 			fmt.Sprint(pod.Resources.Memory),
-		})
+			formatLabels(pod.Labels),
+			getPodConditionMessage(pod),
+		}
+		if wide {
+			row = append(row, formatReadyContainers(pod), fmt.Sprint(getRestartCount(pod)), formatTime(pod.Status.StartTime), pod.Status.PodIP, pod.Status.Reason)
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+}
+
+// The phase of the pod, or its progress through the init containers while they run, e.g. "Init:1/2"
+func formatPodStatus(pod shared.Pod) string {
+	phase := pod.Status.Phase
+	if phase == shared.PodSucceeded || phase == shared.PodFailed && pod.Status.Reason != "InitContainerFailed" {
+		return phase.String()
+	}
+
+	for i, status := range pod.Status.InitContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode == 0 {
+			continue
+		}
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "PodInitializing" {
+			return "Init:" + waiting.Reason
+		}
+		if terminated := status.State.Terminated; terminated != nil {
+			return "Init:Error"
+		}
+		return fmt.Sprintf("Init:%d/%d", i, len(pod.InitContainers))
+	}
+	return phase.String()
+}
+
+// Ready containers out of all containers, e.g. "1/2"
+func formatReadyContainers(pod shared.Pod) string {
+	ready := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+	}
+	return fmt.Sprintf("%d/%d", ready, len(pod.Containers))
+}
+
+func getRestartCount(pod shared.Pod) int {
+	restarts := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// E.g. "http-get :8080/healthz delay=0s period=10s #failure=3"
+func formatProbe(probe *shared.Probe) string {
+	var action string
+	switch {
+	case probe.Exec != nil:
+		action = "exec " + strings.Join(probe.Exec.Command, " ")
+	case probe.HTTPGet != nil:
+		action = fmt.Sprintf("http-get %s:%d%s", probe.HTTPGet.Host, probe.HTTPGet.Port, probe.HTTPGet.Path)
+	case probe.TCPSocket != nil:
+		action = fmt.Sprintf("tcp-socket %s:%d", probe.TCPSocket.Host, probe.TCPSocket.Port)
+	}
+	return fmt.Sprintf("%s delay=%ds period=%ds #failure=%d", action, probe.InitialDelaySeconds, probe.PeriodSeconds, probe.FailureThreshold)
+}
+
+// Explains what is holding the pod back, e.g. why it could not be scheduled
+func getPodConditionMessage(pod shared.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if !condition.Status {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+var describePodCmd = &cobra.Command{
+	Use: "pod [podID]",
+	Short: "Shows the details of a Maden pod",
+	Long: `Shows the details of a Maden pod by ID, including its conditions and the state of each of its containers. For example:
+
+maden describe pod 1234`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		response, err := http.Get(namespacedURL("pods", args[0]))
+		if err != nil {
+			fmt.Println("Error fetching data: ", err)
+			return
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			fmt.Printf("Error fetching pod with status: %s\n", response.Status)
+			return
+		}
+
+		var pod shared.Pod
+		if err := json.NewDecoder(response.Body).Decode(&pod); err != nil {
+			fmt.Println("Error decoding JSON: ", err)
+			return
+		}
+
+		describePod(pod)
+	},
+}
+
+func describePod(pod shared.Pod) {
+	fmt.Printf("Name:        %s\n", pod.Name)
+	fmt.Printf("ID:          %s\n", pod.ID)
+	fmt.Printf("Namespace:   %s\n", pod.Namespace)
+	fmt.Printf("Node ID:     %s\n", pod.NodeID)
+	fmt.Printf("Labels:      %s\n", formatLabels(pod.Labels))
+	fmt.Printf("Start Time:  %s\n", formatTime(pod.Status.StartTime))
+	fmt.Printf("IP:          %s\n", pod.Status.PodIP)
+	fmt.Printf("Status:      %s\n", pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		fmt.Printf("Reason:      %s\n", pod.Status.Reason)
+		fmt.Printf("Message:     %s\n", pod.Status.Message)
+	}
+
+	if len(pod.InitContainers) > 0 {
+		fmt.Println("Init Containers:")
+		describeContainers(pod.InitContainers, pod.Status.InitContainerStatuses)
 	}
+	fmt.Println("Containers:")
+	describeContainers(pod.Containers, pod.Status.ContainerStatuses)
 
+	fmt.Println("Conditions:")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Type", "Status", "Reason", "Last Transition"})
+	table.SetBorder(false)
+	for _, condition := range pod.Status.Conditions {
+		table.Append([]string{condition.Type.String(), fmt.Sprint(condition.Status), condition.Reason, formatTime(&condition.LastTransitionTime)})
+	}
 	table.Render()
+
+	if len(pod.Status.Events) == 0 {
+		return
+	}
+	fmt.Println("Events:")
+	eventTable := tablewriter.NewWriter(os.Stdout)
+	eventTable.SetHeader([]string{"Type", "Reason", "Time", "Message"})
+	eventTable.SetBorder(false)
+	for _, event := range pod.Status.Events {
+		eventTable.Append([]string{event.Type.String(), event.Reason, formatTime(&event.Timestamp), event.Message})
+	}
+	eventTable.Render()
+}
+
+// Statuses are in the order of the containers
+func describeContainers(containers []shared.Container, statuses []shared.ContainerStatus) {
+	for i, container := range containers {
+		if container.Name != "" {
+			fmt.Printf("  %s:\n", container.Name)
+		} else {
+			fmt.Printf("  %s:\n", container.Image)
+		}
+		fmt.Printf("    Container ID:  %s\n", container.ID)
+		fmt.Printf("    Image:         %s\n", container.Image)
+		for _, probe := range []struct {
+			name  string
+			probe *shared.Probe
+		}{{"Liveness", container.LivenessProbe}, {"Readiness", container.ReadinessProbe}, {"Startup", container.StartupProbe}} {
+			if probe.probe != nil {
+				fmt.Printf("    %-15s%s\n", probe.name+":", formatProbe(probe.probe))
+			}
+		}
+		if i >= len(statuses) {
+			continue
+		}
+
+		status := statuses[i]
+		state := status.State
+		switch {
+		case state.Running != nil:
+			fmt.Printf("    State:         Running\n")
+			fmt.Printf("      Started:     %s\n", formatTime(&state.Running.StartedAt))
+		case state.Terminated != nil:
+			fmt.Printf("    State:         Terminated\n")
+			fmt.Printf("      Reason:      %s\n", state.Terminated.Reason)
+			fmt.Printf("      Exit Code:   %d\n", state.Terminated.ExitCode)
+			fmt.Printf("      Started:     %s\n", formatTime(&state.Terminated.StartedAt))
+			fmt.Printf("      Finished:    %s\n", formatTime(&state.Terminated.FinishedAt))
+		case state.Waiting != nil:
+			fmt.Printf("    State:         Waiting\n")
+			fmt.Printf("      Reason:      %s\n", state.Waiting.Reason)
+			if state.Waiting.Message != "" {
+				fmt.Printf("      Message:     %s\n", state.Waiting.Message)
+			}
+		}
+		if lastState := status.LastState.Terminated; lastState != nil {
+			fmt.Printf("    Last State:    Terminated\n")
+			fmt.Printf("      Reason:      %s\n", lastState.Reason)
+			fmt.Printf("      Exit Code:   %d\n", lastState.ExitCode)
+			fmt.Printf("      Finished:    %s\n", formatTime(&lastState.FinishedAt))
+		}
+		fmt.Printf("    Started:       %t\n", status.Started)
+		fmt.Printf("    Ready:         %t\n", status.Ready)
+		fmt.Printf("    Restart Count: %d\n", status.RestartCount)
+	}
 }
 
 var deletePodCmd = &cobra.Command{
@@ -72,18 +285,20 @@ This command will delete the pod with ID 1234 from the system`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		podID := args[0]
-		err := deletePod(podID)
+		namespaces, err := deleteInNamespaces(func(namespaceName string) error {
+			return deletePod(namespaceName, podID)
+		})
 		if err != nil {
 			fmt.Printf("Error deleting pod: %s\n", err)
 			return
 		}
-		fmt.Printf("Pod %s deleted successfully\n", podID)
+		fmt.Printf("Pod %s deleted successfully from %s\n", podID, strings.Join(namespaces, ", "))
 	},
 }
 
 
-func deletePod(podID string) error {
-	request, err := http.NewRequest("DELETE", fmt.Sprintf("http://localhost:8080/pods/%s", podID), nil)
+func deletePod(namespaceName string, podID string) error {
+	request, err := http.NewRequest("DELETE", namespaceURL(namespaceName, "pods", podID), nil)
 	if err != nil {
 		return err
 	}
@@ -95,6 +310,9 @@ func deletePod(podID string) error {
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNotFound {
+		return errResourceNotFound
+	}
 	if response.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("failed to delete pod with status: %s", response.Status)
 	}
@@ -115,7 +333,7 @@ var logsCmd = &cobra.Command{
         }
         follow, _ := cmd.Flags().GetBool("follow")
 
-        url := fmt.Sprintf("http://localhost:8080/pods/%s/logs?containerID=%s&follow=%t", podID, containerID, follow)
+        url := fmt.Sprintf("%s?containerID=%s&follow=%t", namespacedURL("pods", podID, "logs"), containerID, follow)
 
 		client := &http.Client{Timeout: 0}
         req, err := http.NewRequest("GET", url, nil)
@@ -162,7 +380,7 @@ This command opens an interactive session where commands can be typed and execut
 		}
 
 		// Construct the URL for the WebSocket connection
-		url := fmt.Sprintf("ws://localhost:8080/pods/%s/exec", podID)
+		url := fmt.Sprintf("ws://localhost:8080/namespaces/%s/pods/%s/exec", namespace, podID)
 		if containerID != "" {
 			url += fmt.Sprintf("?containerID=%s", containerID)
 		}
@@ -211,6 +429,7 @@ This command opens an interactive session where commands can be typed and execut
 
 func init() {
 	getCmd.AddCommand(getPodsCmd)
+	describeCmd.AddCommand(describePodCmd)
 	deleteCmd.AddCommand(deletePodCmd)
 	rootCmd.AddCommand(logsCmd)
 	rootCmd.AddCommand(execCmd)
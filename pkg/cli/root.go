@@ -1,8 +1,16 @@
 package cli
 
 import (
+	"maden/pkg/shared"
+
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -10,6 +18,21 @@ import (
 
 var cfgFile string
 
+const apiServerURL = "http://localhost:8080"
+
+// Namespace that namespaced commands act on, and whether get and delete commands act on all of them instead
+var namespace string
+var allNamespaces bool
+
+// Label selector narrowing down the resources listed by get commands, e.g. "app=web,tier!=db"
+var labelSelector string
+
+// Output format of get commands, "wide" adds more columns
+var outputFormat string
+
+// What happens to the dependents of resources removed by delete commands: background, foreground or orphan
+var cascade string
+
 var rootCmd = &cobra.Command{
 	Use:   "madencli",
 	Short: "Maden is a container orchestration tool",
@@ -23,10 +46,132 @@ var getCmd = &cobra.Command{
 	Long: `Get resources from the Maden API server`,
 }
 
+var describeCmd = &cobra.Command{
+	Use: "describe",
+	Short: "Show details of a resource",
+	Long: `Show the details of a resource from the Maden API server, including its status`,
+}
+
 var deleteCmd = &cobra.Command{
 	Use: "delete",
 	Short: "Delete resources",
-	Long: `Delete resources from the Maden API server`,
+	Long: `Delete resources from the Maden API server. Namespaced resources are deleted from the namespace selected
+with --namespace, or from every namespace holding one with --all-namespaces`,
+}
+
+// URL of a namespaced resource, e.g. namespacedURL("pods", podID), in the namespace selected with --namespace
+func namespacedURL(resource ...string) string {
+	return namespaceURL(namespace, resource...)
+}
+
+func namespaceURL(namespaceName string, resource ...string) string {
+	url := fmt.Sprintf("%s/namespaces/%s", apiServerURL, namespaceName)
+	for _, part := range resource {
+		url += "/" + part
+	}
+	return url
+}
+
+// URL listing a namespaced resource, across all namespaces with --all-namespaces
+func listURL(resource string) string {
+	if allNamespaces {
+		return withLabelSelector(fmt.Sprintf("%s/%s", apiServerURL, resource))
+	}
+	return withLabelSelector(namespacedURL(resource))
+}
+
+func withLabelSelector(listURL string) string {
+	if labelSelector == "" {
+		return listURL
+	}
+	return listURL + "?labelSelector=" + url.QueryEscape(labelSelector)
+}
+
+// Adds the propagation policy chosen with --cascade, e.g. "orphan" becomes ?propagationPolicy=Orphan
+func withPropagationPolicy(deleteURL string) (string, error) {
+	if cascade == "" {
+		return deleteURL, nil
+	}
+
+	policy, err := shared.ParseDeletionPropagation(strings.ToUpper(cascade[:1]) + strings.ToLower(cascade[1:]))
+	if err != nil {
+		return "", fmt.Errorf("invalid --cascade value %q, expected background, foreground or orphan", cascade)
+	}
+	return deleteURL + "?propagationPolicy=" + policy.String(), nil
+}
+
+// Returned by namespaced deletes when the namespace holds no such resource
+var errResourceNotFound = errors.New("resource not found")
+
+// Runs a namespaced delete in the namespace selected with --namespace or, with --all-namespaces, in every namespace
+// holding the resource. Returns the namespaces the resource was deleted from
+func deleteInNamespaces(deleteFunc func(namespaceName string) error) ([]string, error) {
+	if !allNamespaces {
+		return []string{namespace}, deleteFunc(namespace)
+	}
+
+	namespaceNames, err := fetchNamespaceNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var deletedFrom []string
+	for _, namespaceName := range namespaceNames {
+		err := deleteFunc(namespaceName)
+		if errors.Is(err, errResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return deletedFrom, err
+		}
+		deletedFrom = append(deletedFrom, namespaceName)
+	}
+
+	if len(deletedFrom) == 0 {
+		return nil, fmt.Errorf("%w in any namespace", errResourceNotFound)
+	}
+	return deletedFrom, nil
+}
+
+func fetchNamespaceNames() ([]string, error) {
+	response, err := http.Get(apiServerURL + "/namespaces")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list namespaces with status: %s", response.Status)
+	}
+
+	var namespaces []shared.Namespace
+	if err := json.NewDecoder(response.Body).Decode(&namespaces); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		names = append(names, namespace.Name)
+	}
+	return names, nil
+}
+
+// Where a namespaced delete command acts, as shown in its confirmation prompt
+func deleteScope() string {
+	if allNamespaces {
+		return "in all namespaces"
+	}
+	return "in namespace " + namespace
+}
+
+// Labels as shown in tables, e.g. "app=web,tier=frontend"
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
 }
 
 func Execute() {
@@ -40,9 +185,16 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.AddCommand(getCmd)
+	rootCmd.AddCommand(describeCmd)
 	rootCmd.AddCommand(deleteCmd)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.madencli.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", shared.DefaultNamespace, "Namespace of the resources")
+	getCmd.PersistentFlags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the resources of all namespaces")
+	deleteCmd.PersistentFlags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Delete the named resource from every namespace holding one")
+	getCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format, wide shows additional columns")
+	getCmd.PersistentFlags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter on, e.g. app=web,tier!=db")
+	deleteCmd.PersistentFlags().StringVar(&cascade, "cascade", "background", "What happens to the dependents, e.g. the pods of a deployment: background, foreground or orphan")
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
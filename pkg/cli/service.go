@@ -20,7 +20,7 @@ var getServicesCmd = &cobra.Command{
 	Short: "Fetches current Maden services",
 	Long: `Fetches the currently active Maden services, by calling the API server, and displays them.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		response, err := http.Get("http://localhost:8080/services")
+		response, err := http.Get(listURL("services"))
 		if err != nil {
 			fmt.Println("Error fetching data: ", err)
 			return
@@ -45,11 +45,12 @@ var getServicesCmd = &cobra.Command{
 
 func displayServices(services []shared.Service) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Port", "Target Port"})
+	table.SetHeader([]string{"Namespace", "ID", "Name", "Port", "Target Port"})
 	table.SetBorder(false)
 
 	for _, service := range services {
 		table.Append([]string{
+			service.Namespace,
 			service.ID,
 			service.Name,
 			fmt.Sprint(service.Ports[0].Port),
@@ -78,18 +79,20 @@ This command will delete the service with ID 1234 from the system`,
 			return
 		}
 		
-		err := deleteService(serviceID)
+		namespaces, err := deleteInNamespaces(func(namespaceName string) error {
+			return deleteService(namespaceName, serviceID)
+		})
 		if err != nil {
 			fmt.Printf("Error deleting service: %s\n", err)
 			return
 		}
-		fmt.Printf("Service %s deleted successfully\n", serviceID)
+		fmt.Printf("Service %s deleted successfully from %s\n", serviceID, strings.Join(namespaces, ", "))
 	},
 }
 
 func addServiceConfirmationPrompt(serviceID string) bool {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("Warning: This will delete service %s. Continue? (y/n): ", serviceID)
+	fmt.Printf("Warning: This will delete service %s %s. Continue? (y/n): ", serviceID, deleteScope())
 	
 	response, err := reader.ReadString('\n')
 	response = strings.TrimSpace(response)
@@ -105,8 +108,8 @@ func addServiceConfirmationPrompt(serviceID string) bool {
 	return true
 }
 
-func deleteService(serviceID string) error {
-	request, err := http.NewRequest("DELETE", fmt.Sprintf("http://localhost:8080/services/%s", serviceID), nil)
+func deleteService(namespaceName string, serviceID string) error {
+	request, err := http.NewRequest("DELETE", namespaceURL(namespaceName, "services", serviceID), nil)
 	if err != nil {
 		return err
 	}
@@ -118,6 +121,9 @@ func deleteService(serviceID string) error {
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNotFound {
+		return errResourceNotFound
+	}
 	if response.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("failed to delete service with status: %s", response.Status)
 	}
@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"context"
 	"maden/pkg/etcd"
 	"maden/pkg/shared"
 
 	"fmt"
+	"reflect"
+	"slices"
 )
 
 type DefaultDeploymentController struct {
@@ -16,13 +19,14 @@ func NewDefaultDeploymentController(repo etcd.DeploymentRepository) DeploymentCo
 }
 
 
-func (c *DefaultDeploymentController) HandleIncomingDeployment(deploymentSpec shared.DeploymentSpec) error {
-	existingDeployment, err := c.Repo.GetDeploymentByName(deploymentSpec.Name)
+func (c *DefaultDeploymentController) HandleIncomingDeployment(ctx context.Context, deploymentSpec shared.DeploymentSpec) error {
+	deploymentSpec.Namespace = shared.NamespaceOrDefault(deploymentSpec.Namespace)
+	existingDeployment, err := c.Repo.GetDeploymentByName(ctx, deploymentSpec.Namespace, deploymentSpec.Name)
 	if err != nil {
 		if _, ok := err.(*shared.ErrNotFound); ok {
 			fmt.Println("Creating deployment")
 			deployment := transformToDeployment(deploymentSpec)
-			return c.Repo.CreateDeployment(&deployment)
+			return c.Repo.CreateDeployment(ctx, &deployment)
 		} else {
 			return err
 		}
@@ -31,7 +35,7 @@ func (c *DefaultDeploymentController) HandleIncomingDeployment(deploymentSpec sh
 	if needsDeploymentUpdate(deploymentSpec, existingDeployment) {
 		fmt.Println("Updating deployment")
 		existingDeployment := updateExistingDeployment(deploymentSpec, existingDeployment)
-		return c.Repo.UpdateDeployment(&existingDeployment)
+		return c.Repo.UpdateDeployment(ctx, &existingDeployment)
 	}
 
 	fmt.Println("No update required for deployment: ", deploymentSpec.Name)
@@ -42,10 +46,12 @@ func transformToDeployment(spec shared.DeploymentSpec) shared.Deployment {
 	id := shared.GenerateRandomString(10)
 	deployment := shared.Deployment{
 		ID: id,
-		Name: spec.Name,
+		ObjectMeta: shared.ObjectMeta{Name: spec.Name, Namespace: spec.Namespace, Labels: spec.Labels, Annotations: spec.Annotations},
 		Replicas: spec.Replicas,
 		Selector: spec.Selector,
 		Template: spec.Template,
+		Strategy: spec.Strategy,
+		RevisionHistoryLimit: spec.RevisionHistoryLimit,
 	}
 	return deployment
 }
@@ -53,13 +59,19 @@ func transformToDeployment(spec shared.DeploymentSpec) shared.Deployment {
 func needsDeploymentUpdate(spec shared.DeploymentSpec, existing *shared.Deployment) bool {
 	return spec.Replicas != existing.Replicas || 
 	!areSelectorsEqual(spec.Selector, existing.Selector) || 
-	!arePodTemplatesEqual(spec.Template, existing.Template)
+	!arePodTemplatesEqual(spec.Template, existing.Template) ||
+	!areStrategiesEqual(spec.Strategy, existing.Strategy) ||
+	spec.RevisionHistoryLimit != existing.RevisionHistoryLimit ||
+	shared.IsMetadataUpdated(existing.ObjectMeta, spec.ObjectMeta)
 }
 
 func updateExistingDeployment(spec shared.DeploymentSpec, existing *shared.Deployment) shared.Deployment {
 	(*existing).Replicas = spec.Replicas
 	(*existing).Selector = spec.Selector
 	(*existing).Template = spec.Template
+	(*existing).Strategy = spec.Strategy
+	(*existing).RevisionHistoryLimit = spec.RevisionHistoryLimit
+	shared.UpdateMetadata(&existing.ObjectMeta, spec.ObjectMeta)
 	return *existing
 }
 
@@ -68,19 +80,31 @@ func areSelectorsEqual(a, b shared.LabelSelector) bool {
     return areMapsEqual(a.MatchLabels, b.MatchLabels)
 }
 
-func arePodTemplatesEqual(a, b shared.PodTemplate) bool {
-    if !areMapsEqual(a.Metadata.Labels, b.Metadata.Labels) {
+func areStrategiesEqual(a, b shared.DeploymentStrategy) bool {
+    if a.Type != b.Type {
         return false
     }
-    return arePodSpecsEqual(a.Spec, b.Spec)
+    if a.RollingUpdate == nil || b.RollingUpdate == nil {
+        return a.RollingUpdate == b.RollingUpdate
+    }
+    return *a.RollingUpdate == *b.RollingUpdate
+}
+
+// Every field of a template ends up in its hash, so scheduling rules and resources are compared as well
+func arePodTemplatesEqual(a, b shared.PodTemplate) bool {
+    return shared.ComputeTemplateHash(a) == shared.ComputeTemplateHash(b)
 }
 
 func arePodSpecsEqual(a, b shared.PodSpec) bool {
-    if len(a.Containers) != len(b.Containers) {
+    return areContainerListsEqual(a.InitContainers, b.InitContainers) && areContainerListsEqual(a.Containers, b.Containers)
+}
+
+func areContainerListsEqual(a, b []shared.Container) bool {
+    if len(a) != len(b) {
         return false
     }
-    for i := range a.Containers {
-        if !areContainersEqual(a.Containers[i], b.Containers[i]) {
+    for i := range a {
+        if !areContainersEqual(a[i], b[i]) {
             return false
         }
     }
@@ -88,16 +112,16 @@ func arePodSpecsEqual(a, b shared.PodSpec) bool {
 }
 
 func areContainersEqual(a, b shared.Container) bool {
-    if a.Image != b.Image {
+    if a.Name != b.Name || a.Image != b.Image || a.ImagePullPolicy != b.ImagePullPolicy || a.WorkingDir != b.WorkingDir {
         return false
     }
-    if len(a.Ports) != len(b.Ports) {
+    if !slices.Equal(a.Command, b.Command) || !slices.Equal(a.Args, b.Args) || !slices.Equal(a.Env, b.Env) {
         return false
     }
-    for i := range a.Ports {
-        if a.Ports[i].ContainerPort != b.Ports[i].ContainerPort {
-            return false
-        }
+    if !slices.Equal(a.Ports, b.Ports) || a.Resources != b.Resources {
+        return false
     }
-    return true
+    return reflect.DeepEqual(a.LivenessProbe, b.LivenessProbe) &&
+        reflect.DeepEqual(a.ReadinessProbe, b.ReadinessProbe) &&
+        reflect.DeepEqual(a.StartupProbe, b.StartupProbe)
 }
\ No newline at end of file
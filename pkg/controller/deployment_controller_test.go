@@ -4,6 +4,7 @@ import (
 	"maden/pkg/shared"
 	"maden/pkg/mocks"
 	
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -18,17 +19,17 @@ func TestHandleIncomingDeploymentCreateNew(t *testing.T) {
 	mockRepo := mocks.NewMockDeploymentRepository(ctrl)
 	controller := NewDefaultDeploymentController(mockRepo)
 
-	deploymentSpec := shared.DeploymentSpec{Name: "test-deployment", Replicas: 3}
+	deploymentSpec := shared.DeploymentSpec{ObjectMeta: shared.ObjectMeta{Name: "test-deployment"}, Replicas: 3}
 	expectedDeployment := transformToDeployment(deploymentSpec)
 
-	mockRepo.EXPECT().GetDeploymentByName("test-deployment").Return(nil, &shared.ErrNotFound{})
-	mockRepo.EXPECT().CreateDeployment(gomock.Any()).Do(func(deployment *shared.Deployment) {
+	mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(nil, &shared.ErrNotFound{})
+	mockRepo.EXPECT().CreateDeployment(gomock.Any(), gomock.Any()).Do(func(_ context.Context, deployment *shared.Deployment) {
 		assert.Equal(t, expectedDeployment.Name, deployment.Name)
 		assert.Equal(t, expectedDeployment.Replicas, deployment.Replicas)
 	}).Return(nil)
 
 	// Act
-	err := controller.HandleIncomingDeployment(deploymentSpec)
+	err := controller.HandleIncomingDeployment(context.Background(), deploymentSpec)
 
 	// Assert
 	assert.NoError(t, err)
@@ -44,7 +45,7 @@ func TestHandleIncomingDeploymentUpdateExisting(t *testing.T) {
 
     existingDeployment := shared.Deployment{
         ID: "123",
-        Name: "test-deployment",
+        ObjectMeta: shared.ObjectMeta{Name: "test-deployment"},
         Replicas: 2,
         Selector: shared.LabelSelector{MatchLabels: map[string]string{"app": "old"}},
         Template: shared.PodTemplate{
@@ -55,7 +56,7 @@ func TestHandleIncomingDeploymentUpdateExisting(t *testing.T) {
         },
     }
     deploymentSpec := shared.DeploymentSpec{
-        Name: "test-deployment",
+        ObjectMeta: shared.ObjectMeta{Name: "test-deployment"},
         Replicas: 3, // Different number of replicas to trigger an update
         Selector: shared.LabelSelector{MatchLabels: map[string]string{"app": "new"}},
         Template: shared.PodTemplate{
@@ -66,14 +67,14 @@ func TestHandleIncomingDeploymentUpdateExisting(t *testing.T) {
         },
     }
 
-    mockRepo.EXPECT().GetDeploymentByName("test-deployment").Return(&existingDeployment, nil)
-    mockRepo.EXPECT().UpdateDeployment(gomock.Any()).Do(func(deployment *shared.Deployment) {
+    mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(&existingDeployment, nil)
+    mockRepo.EXPECT().UpdateDeployment(gomock.Any(), gomock.Any()).Do(func(_ context.Context, deployment *shared.Deployment) {
         assert.Equal(t, 3, deployment.Replicas)
         assert.Equal(t, "new-image", deployment.Template.Spec.Containers[0].Image)
     }).Return(nil)
 
 	// Act
-    err := controller.HandleIncomingDeployment(deploymentSpec)
+    err := controller.HandleIncomingDeployment(context.Background(), deploymentSpec)
 
     // Assert
     assert.NoError(t, err)
@@ -88,12 +89,12 @@ func TestHandleIncomingDeploymentNoOperationNeeded(t *testing.T) {
 	mockRepo := mocks.NewMockDeploymentRepository(ctrl)
 	controller := NewDefaultDeploymentController(mockRepo)
 
-	existingDeployment := shared.Deployment{ID: "123", Name: "test-deployment", Replicas: 3}
+	existingDeployment := shared.Deployment{ID: "123", ObjectMeta: shared.ObjectMeta{Name: "test-deployment"}, Replicas: 3}
 
-	mockRepo.EXPECT().GetDeploymentByName("test-deployment").Return(&existingDeployment, nil)
+	mockRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(&existingDeployment, nil)
 
 	// Act
-	err := controller.HandleIncomingDeployment(shared.DeploymentSpec{Name: "test-deployment", Replicas: 3})
+	err := controller.HandleIncomingDeployment(context.Background(), shared.DeploymentSpec{ObjectMeta: shared.ObjectMeta{Name: "test-deployment"}, Replicas: 3})
 
 	// Assert
 	assert.NoError(t, err)
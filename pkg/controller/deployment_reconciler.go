@@ -0,0 +1,320 @@
+package controller
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/orchestrator"
+	"maden/pkg/shared"
+
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDeploymentResyncInterval = 30 * time.Second
+	deploymentRequeueDelay          = 5 * time.Second
+	rolloutPollInterval             = 2 * time.Second
+	defaultRevisionHistoryLimit     = 10
+)
+
+// Component responsible for converging the pods of every deployment towards its desired state.
+// Etcd events only enqueue the namespaces and names of deployments; the actual work is done by a single worker,
+// and a periodic resync catches up on anything the events missed.
+type DefaultDeploymentReconciler struct {
+	DeploymentRepo etcd.DeploymentRepository
+	PodRepo        etcd.PodRepository
+	RevisionRepo   etcd.DeploymentRevisionRepository
+	Orchestrator   orchestrator.PodOrchestrator
+	RolloutEngine  DeploymentRolloutEngine
+	Queue          *WorkQueue
+	ResyncInterval time.Duration
+}
+
+func NewDefaultDeploymentReconciler(
+	deploymentRepo etcd.DeploymentRepository,
+	podRepo etcd.PodRepository,
+	revisionRepo etcd.DeploymentRevisionRepository,
+	orchestrator orchestrator.PodOrchestrator,
+	rolloutEngine DeploymentRolloutEngine,
+) DeploymentReconciler {
+	return &DefaultDeploymentReconciler{
+		DeploymentRepo: deploymentRepo,
+		PodRepo:        podRepo,
+		RevisionRepo:   revisionRepo,
+		Orchestrator:   orchestrator,
+		RolloutEngine:  rolloutEngine,
+		Queue:          NewWorkQueue(),
+		ResyncInterval: DefaultDeploymentResyncInterval,
+	}
+}
+
+func (r *DefaultDeploymentReconciler) Enqueue(namespace string, deploymentName string) {
+	r.Queue.Add(etcd.ObjectKey(namespace, deploymentName))
+}
+
+func (r *DefaultDeploymentReconciler) Run(ctx context.Context) {
+	shared.Log.Infof("Starting deployment reconciler...")
+
+	go r.runWorker(ctx)
+
+	r.resync(ctx)
+	ticker := time.NewTicker(r.ResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.Queue.ShutDown()
+			return
+		case <-ticker.C:
+			r.resync(ctx)
+		}
+	}
+}
+
+func (r *DefaultDeploymentReconciler) runWorker(ctx context.Context) {
+	for r.processNextItem(ctx) {
+	}
+}
+
+func (r *DefaultDeploymentReconciler) processNextItem(ctx context.Context) bool {
+	deploymentKey, shutdown := r.Queue.Get()
+	if shutdown {
+		return false
+	}
+	defer r.Queue.Done(deploymentKey)
+
+	namespace, deploymentName := splitObjectKey(deploymentKey)
+	if err := r.syncDeployment(ctx, namespace, deploymentName); err != nil {
+		shared.Log.Errorf("Failed to sync deployment %s: %v", deploymentKey, err)
+		r.Queue.AddAfter(deploymentKey, deploymentRequeueDelay)
+	}
+	return true
+}
+
+// Objects are queued under their namespace and name, as in etcd
+func splitObjectKey(key string) (string, string) {
+	namespace, name, found := strings.Cut(key, "/")
+	if !found {
+		return "", key
+	}
+	return namespace, name
+}
+
+func (r *DefaultDeploymentReconciler) resync(ctx context.Context) {
+	deployments, err := r.DeploymentRepo.ListDeployments(ctx, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to list deployments: %v", err)
+		return
+	}
+
+	for _, deployment := range deployments {
+		r.Enqueue(deployment.Namespace, deployment.Name)
+	}
+}
+
+func (r *DefaultDeploymentReconciler) syncDeployment(ctx context.Context, namespace string, deploymentName string) error {
+	deployment, err := r.DeploymentRepo.GetDeploymentByName(ctx, namespace, deploymentName)
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			// The pods are left to the garbage collector
+			return r.RevisionRepo.DeleteRevisions(ctx, namespace, deploymentName)
+		}
+		return err
+	}
+
+	// The garbage collector is deleting or releasing the pods, replacing them would work against it
+	if deployment.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if err := r.syncRevisions(ctx, deployment); err != nil {
+		return err
+	}
+
+	pods, err := r.PodRepo.GetPodsByDeploymentID(ctx, deployment.Namespace, deployment.ID)
+	if err != nil {
+		return err
+	}
+
+	currentPods, outdatedPods, failedPods := partitionPods(pods, deployment.Template)
+	for _, pod := range failedPods {
+		if err := r.Orchestrator.OrchestratePodDeletion(ctx, &pod); err != nil {
+			return err
+		}
+	}
+
+	if len(outdatedPods) == 0 {
+		return r.scale(ctx, deployment, currentPods)
+	}
+	if deployment.Paused {
+		return r.scalePaused(ctx, deployment, currentPods, outdatedPods)
+	}
+
+	isComplete, err := r.RolloutEngine.Rollout(ctx, deployment, currentPods, outdatedPods)
+	if err != nil {
+		return err
+	}
+	if !isComplete {
+		r.Queue.AddAfter(etcd.ObjectKey(namespace, deploymentName), rolloutPollInterval)
+	}
+	return nil
+}
+
+func (r *DefaultDeploymentReconciler) scale(ctx context.Context, deployment *shared.Deployment, pods []shared.Pod) error {
+	difference := deployment.Replicas - len(pods)
+	if difference > 0 {
+		return r.createPods(ctx, deployment, difference)
+	} else if difference < 0 {
+		return r.deletePods(ctx, pods, -difference)
+	}
+	return nil
+}
+
+// A paused rollout leaves the outdated pods in place, but the replica count is still honored
+func (r *DefaultDeploymentReconciler) scalePaused(ctx context.Context, deployment *shared.Deployment, currentPods []shared.Pod, outdatedPods []shared.Pod) error {
+	difference := deployment.Replicas - len(currentPods) - len(outdatedPods)
+	if difference > 0 {
+		return r.createPods(ctx, deployment, difference)
+	} else if difference < 0 {
+		excess := -difference
+		if err := r.deletePods(ctx, outdatedPods, excess); err != nil {
+			return err
+		}
+		if excess > len(outdatedPods) {
+			return r.deletePods(ctx, currentPods, excess-len(outdatedPods))
+		}
+	}
+	return nil
+}
+
+// Splits the pods of a deployment into those created from its current template,
+// those created from an outdated one, and those that failed or completed and have to be replaced
+func partitionPods(pods []shared.Pod, template shared.PodTemplate) ([]shared.Pod, []shared.Pod, []shared.Pod) {
+	currentPods := make([]shared.Pod, 0)
+	outdatedPods := make([]shared.Pod, 0)
+	failedPods := make([]shared.Pod, 0)
+	for _, pod := range pods {
+		if pod.Status.Phase == shared.PodFailed || pod.Status.Phase == shared.PodSucceeded {
+			failedPods = append(failedPods, pod)
+		} else if podMatchesTemplate(&pod, template) {
+			currentPods = append(currentPods, pod)
+		} else {
+			outdatedPods = append(outdatedPods, pod)
+		}
+	}
+	return currentPods, outdatedPods, failedPods
+}
+
+func podMatchesTemplate(pod *shared.Pod, template shared.PodTemplate) bool {
+	if pod.TemplateHash != "" {
+		return pod.TemplateHash == shared.ComputeTemplateHash(template)
+	}
+
+	// Pods created before template hashes were recorded
+	podSpec := shared.PodSpec{InitContainers: pod.InitContainers, Containers: pod.Containers}
+	return arePodSpecsEqual(podSpec, template.Spec)
+}
+
+// Makes sure the current template of the deployment is recorded as its latest revision.
+// Returning to an older template moves that revision to the top instead of duplicating it
+func (r *DefaultDeploymentReconciler) syncRevisions(ctx context.Context, deployment *shared.Deployment) error {
+	revisions, err := r.RevisionRepo.ListRevisions(ctx, deployment.Namespace, deployment.Name)
+	if err != nil {
+		return err
+	}
+
+	templateHash := shared.ComputeTemplateHash(deployment.Template)
+	latestRevision := 0
+	var matchingRevision *shared.DeploymentRevision
+	validRevisions := make([]shared.DeploymentRevision, 0)
+	for i, revision := range revisions {
+		if revision.DeploymentID != deployment.ID {
+			// Left over from a previous deployment with the same name
+			if err := r.RevisionRepo.DeleteRevision(ctx, deployment.Namespace, deployment.Name, revision.Revision); err != nil {
+				return err
+			}
+			continue
+		}
+
+		latestRevision = max(latestRevision, revision.Revision)
+		if revision.TemplateHash == templateHash {
+			matchingRevision = &revisions[i]
+		} else {
+			validRevisions = append(validRevisions, revision)
+		}
+	}
+
+	if matchingRevision != nil && matchingRevision.Revision == latestRevision {
+		return nil
+	}
+	if matchingRevision != nil {
+		if err := r.RevisionRepo.DeleteRevision(ctx, deployment.Namespace, deployment.Name, matchingRevision.Revision); err != nil {
+			return err
+		}
+	}
+
+	newRevision := &shared.DeploymentRevision{
+		ID:             deployment.Name + "-" + templateHash,
+		DeploymentID:   deployment.ID,
+		DeploymentName: deployment.Name,
+		Namespace:      deployment.Namespace,
+		Revision:       latestRevision + 1,
+		TemplateHash:   templateHash,
+		Template:       deployment.Template,
+		CreatedAt:      time.Now(),
+	}
+	if err := r.RevisionRepo.CreateRevision(ctx, newRevision); err != nil {
+		return err
+	}
+	shared.Log.Infof("Recorded revision %d of deployment %s", newRevision.Revision, deployment.Name)
+
+	return r.pruneRevisions(ctx, deployment, validRevisions)
+}
+
+// Drops the oldest revisions beyond the history limit; the current revision is never among them
+func (r *DefaultDeploymentReconciler) pruneRevisions(ctx context.Context, deployment *shared.Deployment, previousRevisions []shared.DeploymentRevision) error {
+	historyLimit := deployment.RevisionHistoryLimit
+	if historyLimit <= 0 {
+		historyLimit = defaultRevisionHistoryLimit
+	}
+
+	excess := len(previousRevisions) + 1 - historyLimit
+	for i := 0; i < excess; i++ {
+		if err := r.RevisionRepo.DeleteRevision(ctx, deployment.Namespace, deployment.Name, previousRevisions[i].Revision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *DefaultDeploymentReconciler) createPods(ctx context.Context, deployment *shared.Deployment, count int) error {
+	for i := 0; i < count; i++ {
+		pod := getPodFromTemplate(deployment)
+		if err := r.Orchestrator.OrchestratePodCreation(ctx, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *DefaultDeploymentReconciler) deletePods(ctx context.Context, pods []shared.Pod, count int) error {
+	sortPodsForDeletion(pods)
+
+	for _, pod := range pods[:min(count, len(pods))] {
+		if err := r.Orchestrator.OrchestratePodDeletion(ctx, &pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pods that are not running yet are the cheapest to give up, so they go first
+func sortPodsForDeletion(pods []shared.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].Status.Phase != shared.PodRunning && pods[j].Status.Phase == shared.PodRunning
+	})
+}
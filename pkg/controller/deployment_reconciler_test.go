@@ -0,0 +1,316 @@
+package controller
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDeployment(replicas int, image string) *shared.Deployment {
+	return &shared.Deployment{
+		ID:        "dep-1",
+		ObjectMeta: shared.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Replicas: replicas,
+		Template: shared.PodTemplate{
+			Spec: shared.PodSpec{
+				Containers: []shared.Container{{Image: image, Ports: []shared.Port{{ContainerPort: 80}}}},
+			},
+		},
+	}
+}
+
+func newTestPod(id string, phase shared.PodPhase, image string) shared.Pod {
+	return shared.Pod{
+		ID:           id,
+		ObjectMeta: shared.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		DeploymentID: "dep-1",
+		Status:       shared.PodStatus{Phase: phase},
+		Containers:   []shared.Container{{Image: image, Ports: []shared.Port{{ContainerPort: 80}}}},
+	}
+}
+
+func newTestRevision(deployment *shared.Deployment, revision int) shared.DeploymentRevision {
+	return shared.DeploymentRevision{
+		DeploymentID:   deployment.ID,
+		DeploymentName: deployment.Name,
+		Revision:       revision,
+		TemplateHash:   shared.ComputeTemplateHash(deployment.Template),
+		Template:       deployment.Template,
+	}
+}
+
+func TestDeploymentReconcilerCreatesMissingPods(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	deployment := newTestDeployment(3, "nginx:latest")
+	pods := []shared.Pod{newTestPod("pod-1", shared.PodRunning, "nginx:latest")}
+
+	mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(deployment, nil)
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return([]shared.DeploymentRevision{newTestRevision(deployment, 1)}, nil)
+	mockPodRepo.EXPECT().GetPodsByDeploymentID(gomock.Any(), "default", "dep-1").Return(pods, nil)
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		assert.Equal(t, "dep-1", pod.DeploymentID)
+		assert.Equal(t, "nginx:latest", pod.Containers[0].Image)
+		return nil
+	})
+
+	// Act
+	err := reconciler.syncDeployment(context.Background(), "default", "test-deployment")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDeploymentReconcilerDeletesExtraPods(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	deployment := newTestDeployment(1, "nginx:latest")
+	pods := []shared.Pod{
+		newTestPod("pod-1", shared.PodRunning, "nginx:latest"),
+		newTestPod("pod-2", shared.PodPending, "nginx:latest"),
+	}
+
+	mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(deployment, nil)
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return([]shared.DeploymentRevision{newTestRevision(deployment, 1)}, nil)
+	mockPodRepo.EXPECT().GetPodsByDeploymentID(gomock.Any(), "default", "dep-1").Return(pods, nil)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		assert.Equal(t, "pod-2", pod.ID) // Pods that are not running yet go first
+		return nil
+	})
+
+	// Act
+	err := reconciler.syncDeployment(context.Background(), "default", "test-deployment")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDeploymentReconcilerRollsOutTemplateChanges(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	deployment := newTestDeployment(2, "nginx:1.26")
+	pods := []shared.Pod{
+		newTestPod("pod-1", shared.PodRunning, "nginx:1.25"),
+		newTestPod("pod-2", shared.PodFailed, "nginx:1.26"),
+	}
+
+	mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(deployment, nil)
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return([]shared.DeploymentRevision{newTestRevision(deployment, 1)}, nil)
+	mockPodRepo.EXPECT().GetPodsByDeploymentID(gomock.Any(), "default", "dep-1").Return(pods, nil)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		assert.Equal(t, "pod-2", pod.ID)
+		return nil
+	})
+	mockRolloutEngine.EXPECT().Rollout(gomock.Any(), deployment, gomock.Len(0), gomock.Len(1)).Return(true, nil)
+
+	// Act
+	err := reconciler.syncDeployment(context.Background(), "default", "test-deployment")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDeploymentReconcilerPausedRollout(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	deployment := newTestDeployment(3, "nginx:1.26")
+	deployment.Paused = true
+	pods := []shared.Pod{
+		newTestPod("pod-1", shared.PodRunning, "nginx:1.25"),
+		newTestPod("pod-2", shared.PodRunning, "nginx:1.25"),
+	}
+
+	mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(deployment, nil)
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return([]shared.DeploymentRevision{newTestRevision(deployment, 1)}, nil)
+	mockPodRepo.EXPECT().GetPodsByDeploymentID(gomock.Any(), "default", "dep-1").Return(pods, nil)
+	mockRolloutEngine.EXPECT().Rollout(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(0)
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	// Act
+	err := reconciler.syncDeployment(context.Background(), "default", "test-deployment")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDeploymentReconcilerLeavesPodsOfDeletedDeployment(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(nil, &shared.ErrNotFound{})
+	mockRevisionRepo.EXPECT().DeleteRevisions(gomock.Any(), "default", "test-deployment").Return(nil)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(0)
+
+	// Act
+	err := reconciler.syncDeployment(context.Background(), "default", "test-deployment")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDeploymentReconcilerSkipsDeploymentBeingDeleted(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	deletionTimestamp := time.Now()
+	deployment := newTestDeployment(3, "nginx:latest")
+	deployment.DeletionTimestamp = &deletionTimestamp
+
+	mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(deployment, nil)
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(0)
+
+	// Act
+	err := reconciler.syncDeployment(context.Background(), "default", "test-deployment")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDeploymentReconcilerRecordsNewRevision(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	deployment := newTestDeployment(1, "nginx:1.27")
+	deployment.RevisionHistoryLimit = 2
+	revisions := []shared.DeploymentRevision{
+		newTestRevision(newTestDeployment(1, "nginx:1.25"), 1),
+		newTestRevision(newTestDeployment(1, "nginx:1.26"), 2),
+	}
+
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return(revisions, nil)
+	mockRevisionRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, revision *shared.DeploymentRevision) error {
+		assert.Equal(t, 3, revision.Revision)
+		assert.Equal(t, shared.ComputeTemplateHash(deployment.Template), revision.TemplateHash)
+		return nil
+	})
+	mockRevisionRepo.EXPECT().DeleteRevision(gomock.Any(), "default", "test-deployment", 1).Return(nil) // Beyond the history limit
+
+	// Act
+	err := reconciler.syncRevisions(context.Background(), deployment)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDeploymentReconcilerMovesReusedRevisionToTop(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockRolloutEngine := mocks.NewMockDeploymentRolloutEngine(ctrl)
+	reconciler := NewDefaultDeploymentReconciler(mockDeploymentRepo, mockPodRepo, mockRevisionRepo, mockOrch, mockRolloutEngine).(*DefaultDeploymentReconciler)
+
+	deployment := newTestDeployment(1, "nginx:1.25")
+	revisions := []shared.DeploymentRevision{
+		newTestRevision(deployment, 1),
+		newTestRevision(newTestDeployment(1, "nginx:1.26"), 2),
+	}
+
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return(revisions, nil)
+	mockRevisionRepo.EXPECT().DeleteRevision(gomock.Any(), "default", "test-deployment", 1).Return(nil)
+	mockRevisionRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, revision *shared.DeploymentRevision) error {
+		assert.Equal(t, 3, revision.Revision)
+		assert.Equal(t, "nginx:1.25", revision.Template.Spec.Containers[0].Image)
+		return nil
+	})
+
+	// Act
+	err := reconciler.syncRevisions(context.Background(), deployment)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestWorkQueueDeduplicatesKeys(t *testing.T) {
+	queue := NewWorkQueue()
+
+	queue.Add("a")
+	queue.Add("b")
+	queue.Add("a")
+	assert.Equal(t, 2, queue.Len())
+
+	key, shutdown := queue.Get()
+	assert.False(t, shutdown)
+	assert.Equal(t, "a", key)
+
+	// Re-adding a key that is being processed only queues it again once it is done
+	queue.Add("a")
+	assert.Equal(t, 1, queue.Len())
+	queue.Done("a")
+	assert.Equal(t, 2, queue.Len())
+
+	queue.ShutDown()
+	queue.Add("c")
+	assert.Equal(t, 2, queue.Len())
+}
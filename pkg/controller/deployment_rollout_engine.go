@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"maden/pkg/orchestrator"
+	"maden/pkg/shared"
+
+	"context"
+)
+
+const (
+	defaultMaxSurge       = 1
+	defaultMaxUnavailable = 0
+)
+
+// Component responsible for replacing the outdated pods of a deployment according to its strategy.
+// Each call performs a single step of the rollout; the reconciler keeps calling it until it reports completion.
+type DefaultDeploymentRolloutEngine struct {
+	Orchestrator orchestrator.PodOrchestrator
+}
+
+func NewDefaultDeploymentRolloutEngine(orchestrator orchestrator.PodOrchestrator) DeploymentRolloutEngine {
+	return &DefaultDeploymentRolloutEngine{Orchestrator: orchestrator}
+}
+
+func (e *DefaultDeploymentRolloutEngine) Rollout(ctx context.Context, deployment *shared.Deployment, currentPods []shared.Pod, outdatedPods []shared.Pod) (bool, error) {
+	switch deployment.Strategy.Type {
+	case shared.RecreateStrategy:
+		return e.recreate(ctx, deployment, currentPods, outdatedPods)
+	default:
+		return e.rollingUpdate(ctx, deployment, currentPods, outdatedPods)
+	}
+}
+
+// Recreate: all outdated pods go down before any new one is created
+func (e *DefaultDeploymentRolloutEngine) recreate(ctx context.Context, deployment *shared.Deployment, currentPods []shared.Pod, outdatedPods []shared.Pod) (bool, error) {
+	for _, pod := range outdatedPods {
+		if err := e.Orchestrator.OrchestratePodDeletion(ctx, &pod); err != nil {
+			return false, err
+		}
+	}
+
+	for i := len(currentPods); i < deployment.Replicas; i++ {
+		pod := getPodFromTemplate(deployment)
+		if err := e.Orchestrator.OrchestratePodCreation(ctx, pod); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Rolling update: new pods are surged in, and old ones are only removed
+// as long as enough pods stay available (running)
+func (e *DefaultDeploymentRolloutEngine) rollingUpdate(ctx context.Context, deployment *shared.Deployment, currentPods []shared.Pod, outdatedPods []shared.Pod) (bool, error) {
+	maxSurge, maxUnavailable := getRollingUpdateLimits(deployment.Strategy)
+	replicas := deployment.Replicas
+
+	// Scale up the new pods
+	totalPods := len(currentPods) + len(outdatedPods)
+	podsToCreate := min(replicas+maxSurge-totalPods, replicas-len(currentPods))
+	for i := 0; i < podsToCreate; i++ {
+		pod := getPodFromTemplate(deployment)
+		if err := e.Orchestrator.OrchestratePodCreation(ctx, pod); err != nil {
+			return false, err
+		}
+	}
+
+	// Scale down the old pods. Those that are not running do not count as available, so they can always go
+	availablePods := countRunningPods(currentPods) + countRunningPods(outdatedPods)
+	removablePods := availablePods - (replicas - maxUnavailable)
+
+	sortPodsForDeletion(outdatedPods)
+	remainingOutdatedPods := 0
+	for _, pod := range outdatedPods {
+		if pod.Status.Phase == shared.PodRunning {
+			if removablePods <= 0 {
+				remainingOutdatedPods++
+				continue
+			}
+			removablePods--
+		}
+
+		if err := e.Orchestrator.OrchestratePodDeletion(ctx, &pod); err != nil {
+			return false, err
+		}
+	}
+
+	isComplete := remainingOutdatedPods == 0 && len(currentPods) == replicas &&
+		countRunningPods(currentPods) == replicas
+	return isComplete, nil
+}
+
+func getRollingUpdateLimits(strategy shared.DeploymentStrategy) (int, int) {
+	if strategy.RollingUpdate == nil {
+		return defaultMaxSurge, defaultMaxUnavailable
+	}
+
+	maxSurge := max(strategy.RollingUpdate.MaxSurge, 0)
+	maxUnavailable := max(strategy.RollingUpdate.MaxUnavailable, 0)
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxSurge = defaultMaxSurge // Otherwise the rollout could never make progress
+	}
+	return maxSurge, maxUnavailable
+}
+
+func countRunningPods(pods []shared.Pod) int {
+	count := 0
+	for _, pod := range pods {
+		if pod.Status.Phase == shared.PodRunning {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolloutEngineRollingUpdateSurgesNewPods(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	engine := NewDefaultDeploymentRolloutEngine(mockOrch)
+
+	deployment := newTestDeployment(2, "nginx:1.26")
+	outdatedPods := []shared.Pod{
+		newTestPod("pod-1", shared.PodRunning, "nginx:1.25"),
+		newTestPod("pod-2", shared.PodRunning, "nginx:1.25"),
+	}
+
+	// Default strategy: maxSurge 1, maxUnavailable 0, so one new pod and no deletions yet
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(0)
+
+	// Act
+	isComplete, err := engine.Rollout(context.Background(), deployment, []shared.Pod{}, outdatedPods)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, isComplete)
+}
+
+func TestRolloutEngineRollingUpdateWaitsForRunningPods(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	engine := NewDefaultDeploymentRolloutEngine(mockOrch)
+
+	deployment := newTestDeployment(2, "nginx:1.26")
+	currentPods := []shared.Pod{newTestPod("pod-3", shared.PodContainerCreating, "nginx:1.26")}
+	outdatedPods := []shared.Pod{
+		newTestPod("pod-1", shared.PodRunning, "nginx:1.25"),
+		newTestPod("pod-2", shared.PodRunning, "nginx:1.25"),
+	}
+
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(0)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(0)
+
+	// Act
+	isComplete, err := engine.Rollout(context.Background(), deployment, currentPods, outdatedPods)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, isComplete)
+
+	// Once the new pod is running, one old pod can be replaced
+	currentPods[0].Status.Phase = shared.PodRunning
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(0)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	isComplete, err = engine.Rollout(context.Background(), deployment, currentPods, outdatedPods)
+
+	assert.NoError(t, err)
+	assert.False(t, isComplete)
+}
+
+func TestRolloutEngineRollingUpdateMaxUnavailable(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	engine := NewDefaultDeploymentRolloutEngine(mockOrch)
+
+	deployment := newTestDeployment(3, "nginx:1.26")
+	deployment.Strategy = shared.DeploymentStrategy{
+		Type:          shared.RollingUpdateStrategy,
+		RollingUpdate: &shared.RollingUpdateDeployment{MaxSurge: 0, MaxUnavailable: 2},
+	}
+	outdatedPods := []shared.Pod{
+		newTestPod("pod-1", shared.PodRunning, "nginx:1.25"),
+		newTestPod("pod-2", shared.PodRunning, "nginx:1.25"),
+		newTestPod("pod-3", shared.PodRunning, "nginx:1.25"),
+	}
+
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(0)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	// Act
+	isComplete, err := engine.Rollout(context.Background(), deployment, []shared.Pod{}, outdatedPods)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, isComplete)
+}
+
+func TestRolloutEngineRollingUpdateComplete(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	engine := NewDefaultDeploymentRolloutEngine(mockOrch)
+
+	deployment := newTestDeployment(1, "nginx:1.26")
+	currentPods := []shared.Pod{newTestPod("pod-2", shared.PodRunning, "nginx:1.26")}
+	outdatedPods := []shared.Pod{newTestPod("pod-1", shared.PodRunning, "nginx:1.25")}
+
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		assert.Equal(t, "pod-1", pod.ID)
+		return nil
+	})
+
+	// Act
+	isComplete, err := engine.Rollout(context.Background(), deployment, currentPods, outdatedPods)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, isComplete)
+}
+
+func TestRolloutEngineRecreate(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	engine := NewDefaultDeploymentRolloutEngine(mockOrch)
+
+	deployment := newTestDeployment(2, "nginx:1.26")
+	deployment.Strategy = shared.DeploymentStrategy{Type: shared.RecreateStrategy}
+	outdatedPods := []shared.Pod{
+		newTestPod("pod-1", shared.PodRunning, "nginx:1.25"),
+		newTestPod("pod-2", shared.PodRunning, "nginx:1.25"),
+	}
+
+	deletion := mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(2).Return(nil).After(deletion)
+
+	// Act
+	isComplete, err := engine.Rollout(context.Background(), deployment, []shared.Pod{}, outdatedPods)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, isComplete)
+}
@@ -1,27 +1,41 @@
 package controller
 
 import (
+	"context"
 	"maden/pkg/etcd"
 	"maden/pkg/orchestrator"
 	"maden/pkg/shared"
 
 	"encoding/json"
+	"fmt"
 
 	"github.com/google/uuid"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 )
 
-// Component responsible for updating a deployment based on a received configuration file
+// Component responsible for reacting to deployment changes in etcd, by handing them over to the reconciler
 type DefaultDeploymentUpdaterController struct {
-	Repo         etcd.PodRepository
-	Orchestrator orchestrator.PodOrchestrator
+	Repo           etcd.PodRepository
+	DeploymentRepo etcd.DeploymentRepository
+	RevisionRepo   etcd.DeploymentRevisionRepository
+	Orchestrator   orchestrator.PodOrchestrator
+	Reconciler     DeploymentReconciler
 }
 
 func NewDefaultDeploymentUpdaterController(
 	repo etcd.PodRepository,
+	deploymentRepo etcd.DeploymentRepository,
+	revisionRepo etcd.DeploymentRevisionRepository,
 	orchestrator orchestrator.PodOrchestrator,
+	reconciler DeploymentReconciler,
 ) DeploymentUpdaterController {
-	return &DefaultDeploymentUpdaterController{Repo: repo, Orchestrator: orchestrator}
+	return &DefaultDeploymentUpdaterController{
+		Repo:           repo,
+		DeploymentRepo: deploymentRepo,
+		RevisionRepo:   revisionRepo,
+		Orchestrator:   orchestrator,
+		Reconciler:     reconciler,
+	}
 }
 
 // Create
@@ -34,34 +48,33 @@ func (c *DefaultDeploymentUpdaterController) HandleDeploymentCreate(kv *mvccpb.K
 		return
 	}
 
-	c.createAndSchedulePodsFromDeployment(&deployment, -1)
-}
-
-func (c *DefaultDeploymentUpdaterController) createAndSchedulePodsFromDeployment(deployment *shared.Deployment, limit int) {
-	maxPods := getMaxPods(deployment.Replicas, limit)
-
-	for i := 0; i < maxPods; i++ {
-		pod := getPodFromTemplate(deployment.Template, deployment.Name, deployment.ID)
-		if err := c.Orchestrator.OrchestratePodCreation(pod); err != nil {
-			shared.Log.Errorf("Failed to create pod: %v", err)
-			return
-		}
-	}
+	c.Reconciler.Enqueue(deployment.Namespace, deployment.Name)
 }
 
-func getPodFromTemplate(template shared.PodTemplate, podName string, deploymentID string) *shared.Pod {
-	podID := podName + "-" + uuid.New().String()
+func getPodFromTemplate(deployment *shared.Deployment) *shared.Pod {
+	template := deployment.Template
+	podID := deployment.Name + "-" + uuid.New().String()
 	pod := &shared.Pod{
 		ID:            podID,
-		Name:          podName,
-		DeploymentID:  deploymentID,
-		Status:        shared.PodPending,
+		ObjectMeta: shared.ObjectMeta{
+			Name:            deployment.Name,
+			Namespace:       deployment.Namespace,
+			Labels:          template.Metadata.Labels,
+			Annotations:     template.Metadata.Annotations,
+			OwnerReferences: []shared.OwnerReference{shared.NewOwnerReference(shared.DeploymentResource, deployment.ObjectMeta, true)},
+		},
+		DeploymentID:  deployment.ID,
+		TemplateHash:  shared.ComputeTemplateHash(template),
+		Status:        shared.PodStatus{Phase: shared.PodPending},
 		NodeID:        "",
+		InitContainers: template.Spec.InitContainers,
 		Containers:    template.Spec.Containers,
-		Resources:     template.Spec.Resources,
+		Resources:     shared.PodResourceRequests(template.Spec),
 		Affinity:      template.Spec.Affinity,
 		AntiAffinity:  template.Spec.AntiAffinity,
 		Tolerations:   template.Spec.Tolerations,
+		PodAffinity:     template.Spec.PodAffinity,
+		PodAntiAffinity: template.Spec.PodAntiAffinity,
 		RestartPolicy: template.Spec.RestartPolicy,
 	}
 	return pod
@@ -71,38 +84,13 @@ func getPodFromTemplate(template shared.PodTemplate, podName string, deploymentI
 func (c *DefaultDeploymentUpdaterController) HandleDeploymentUpdate(oldKv *mvccpb.KeyValue, newKv *mvccpb.KeyValue) {
 	shared.Log.Infof("Deployment updated: %s, %v", string(oldKv.Value), string(newKv.Value))
 
-	var oldDeployment shared.Deployment
-	if err := json.Unmarshal(oldKv.Value, &oldDeployment); err != nil {
-		shared.Log.Errorf("Failed to unmarshal old deployment: %v", err)
-		return
-	}
-
 	var newDeployment shared.Deployment
 	if err := json.Unmarshal(newKv.Value, &newDeployment); err != nil {
 		shared.Log.Errorf("Failed to unmarshal new deployment: %v", err)
 		return
 	}
 
-	if oldDeployment.Replicas != newDeployment.Replicas {
-		c.handleDeploymentReplicasUpdate(&oldDeployment, &newDeployment)
-	}
-	if !arePodTemplatesEqual(oldDeployment.Template, newDeployment.Template) {
-		c.handleDeploymentTemplateUpdate(oldDeployment.ID, &newDeployment)
-	}
-}
-
-func (c *DefaultDeploymentUpdaterController) handleDeploymentReplicasUpdate(oldDeployment *shared.Deployment, newDeployment *shared.Deployment) {
-	difference := newDeployment.Replicas - oldDeployment.Replicas
-	if difference > 0 {
-		c.createAndSchedulePodsFromDeployment(newDeployment, difference)
-	} else if difference < 0 {
-		c.deletePodsByDeploymentID(newDeployment.ID, -difference)
-	}
-}
-
-func (c *DefaultDeploymentUpdaterController) handleDeploymentTemplateUpdate(oldDeploymentID string, newDeployment *shared.Deployment) {
-	c.deletePodsByDeploymentID(oldDeploymentID, -1)
-	c.createAndSchedulePodsFromDeployment(newDeployment, -1)
+	c.Reconciler.Enqueue(newDeployment.Namespace, newDeployment.Name)
 }
 
 // Delete
@@ -115,54 +103,62 @@ func (c *DefaultDeploymentUpdaterController) HandleDeploymentDelete(kv *mvccpb.K
 		return
 	}
 
-	c.deletePodsByDeploymentID(deployment.ID, -1)
+	c.Reconciler.Enqueue(deployment.Namespace, deployment.Name)
 }
 
-func (c *DefaultDeploymentUpdaterController) deletePodsByDeploymentID(deploymentID string, limit int) {
-	pods, err := c.Repo.GetPodsByDeploymentID(deploymentID)
+func (c *DefaultDeploymentUpdaterController) HandleDeploymentRolloutRestart(ctx context.Context, deployment *shared.Deployment) error {
+	pods, err := c.Repo.GetPodsByDeploymentID(ctx, deployment.Namespace, deployment.ID)
 	if err != nil {
-		shared.Log.Errorf("Failed to get pods by deployment ID: %v", err)
-		return
+		return err
 	}
 
-	maxPods := getMaxPods(len(pods), limit)
-	for _, pod := range pods[:maxPods] {
-		err := c.Orchestrator.OrchestratePodDeletion(&pod)
+	for _, pod := range pods {
+		err := c.Orchestrator.OrchestratePodDeletion(ctx, &pod)
 		if err != nil {
-			shared.Log.Errorf("Failed to delete pod %s: %v", pod.ID, err)
-			return
+			return err
 		}
-	}
-}
 
-func getMaxPods(podsCount int, limit int) int {
-	var maxPods int
-	if limit == -1 {
-		maxPods = podsCount
-	} else {
-		maxPods = min(podsCount, limit)
+		newPod := getPodFromTemplate(deployment)
+		err = c.Orchestrator.OrchestratePodCreation(ctx, newPod)
+		if err != nil {
+			return err
+		}
 	}
-	return maxPods
+
+	return nil
 }
 
-func (c *DefaultDeploymentUpdaterController) HandleDeploymentRolloutRestart(deployment *shared.Deployment) error {
-	pods, err := c.Repo.GetPodsByDeploymentID(deployment.ID)
+// Puts the template of a previous revision back on the deployment; the reconciler then rolls it out
+// and moves that revision to the top of the history
+func (c *DefaultDeploymentUpdaterController) HandleDeploymentRollback(ctx context.Context, deployment *shared.Deployment, toRevision int) error {
+	revisions, err := c.RevisionRepo.ListRevisions(ctx, deployment.Namespace, deployment.Name)
 	if err != nil {
 		return err
 	}
 
-	for _, pod := range pods {
-		err := c.Orchestrator.OrchestratePodDeletion(&pod)
-		if err != nil {
-			return err
-		}
+	targetRevision := findRollbackRevision(revisions, shared.ComputeTemplateHash(deployment.Template), toRevision)
+	if targetRevision == nil {
+		return &shared.ErrNotFound{Name: fmt.Sprintf("%s (revision %d)", deployment.Name, toRevision), ResourceType: shared.DeploymentRevisionResource}
+	}
+	if targetRevision.TemplateHash == shared.ComputeTemplateHash(deployment.Template) {
+		shared.Log.Infof("Deployment %s is already at revision %d", deployment.Name, targetRevision.Revision)
+		return nil
+	}
 
-		newPod := getPodFromTemplate(deployment.Template, deployment.Name, deployment.ID)
-		err = c.Orchestrator.OrchestratePodCreation(newPod)
-		if err != nil {
-			return err
+	shared.Log.Infof("Rolling back deployment %s to revision %d", deployment.Name, targetRevision.Revision)
+	deployment.Template = targetRevision.Template
+	return c.DeploymentRepo.UpdateDeployment(ctx, deployment)
+}
+
+// With toRevision 0, the latest revision that differs from the current template is picked
+func findRollbackRevision(revisions []shared.DeploymentRevision, currentTemplateHash string, toRevision int) *shared.DeploymentRevision {
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if toRevision == 0 && revisions[i].TemplateHash != currentTemplateHash {
+			return &revisions[i]
+		}
+		if toRevision != 0 && revisions[i].Revision == toRevision {
+			return &revisions[i]
 		}
 	}
-
 	return nil
 }
@@ -1,10 +1,10 @@
 package controller
 
 import (
-	"encoding/json"
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -19,10 +19,14 @@ func TestHandleDeploymentCreate(t *testing.T) {
 
 	mockRepo := mocks.NewMockPodRepository(ctrl)
 	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
-	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockOrch)
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockReconciler := mocks.NewMockDeploymentReconciler(ctrl)
+	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockDeploymentRepo, mockRevisionRepo, mockOrch, mockReconciler)
 
 	deploymentJSON := `{
         "ID":"dep-1",
+        "Namespace":"default",
         "Name":"test-deployment",
         "Replicas":3,
         "Template":{
@@ -43,17 +47,10 @@ func TestHandleDeploymentCreate(t *testing.T) {
     }`
 
 	kv := &mvccpb.KeyValue{Value: []byte(deploymentJSON)}
-	var deployment shared.Deployment
-	json.Unmarshal(kv.Value, &deployment)
 
 	// Expectations
-	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any()).Times(deployment.Replicas).DoAndReturn(func(pod *shared.Pod) error {
-		assert.Equal(t, "dep-1", pod.DeploymentID)
-		assert.Equal(t, "test-deployment", pod.Name)
-		assert.Equal(t, "nginx:latest", pod.Containers[0].Image)
-		assert.Equal(t, 80, pod.Containers[0].Ports[0].ContainerPort)
-		return nil
-	})
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(0)
+	mockReconciler.EXPECT().Enqueue("default", "test-deployment").Times(1)
 
 	controller.HandleDeploymentCreate(kv)
 }
@@ -65,10 +62,14 @@ func TestHandleDeploymentUpdate(t *testing.T) {
 
 	mockRepo := mocks.NewMockPodRepository(ctrl)
 	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
-	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockOrch)
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockReconciler := mocks.NewMockDeploymentReconciler(ctrl)
+	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockDeploymentRepo, mockRevisionRepo, mockOrch, mockReconciler)
 
 	oldDeploymentJSON := `{
         "ID":"dep-1",
+        "Namespace":"default",
         "Name":"test-deployment",
         "Replicas":2,
         "Template":{
@@ -89,6 +90,7 @@ func TestHandleDeploymentUpdate(t *testing.T) {
     }`
 	newDeploymentJSON := `{
         "ID":"dep-1",
+        "Namespace":"default",
         "Name":"test-deployment",
         "Replicas":4,
         "Template":{
@@ -111,18 +113,124 @@ func TestHandleDeploymentUpdate(t *testing.T) {
 	oldKv := &mvccpb.KeyValue{Value: []byte(oldDeploymentJSON)}
 	newKv := &mvccpb.KeyValue{Value: []byte(newDeploymentJSON)}
 
-	var oldDeployment, newDeployment shared.Deployment
-	json.Unmarshal(oldKv.Value, &oldDeployment)
-	json.Unmarshal(newKv.Value, &newDeployment)
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(0)
+	mockReconciler.EXPECT().Enqueue("default", "test-deployment").Times(1)
+
+	controller.HandleDeploymentUpdate(oldKv, newKv)
+}
+
+func TestHandleDeploymentDelete(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	difference := newDeployment.Replicas - oldDeployment.Replicas
-	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any()).Times(difference).DoAndReturn(func(pod *shared.Pod) error {
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockReconciler := mocks.NewMockDeploymentReconciler(ctrl)
+	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockDeploymentRepo, mockRevisionRepo, mockOrch, mockReconciler)
+
+	kv := &mvccpb.KeyValue{Value: []byte(`{"id":"dep-1","name":"test-deployment","namespace":"default","replicas":2}`)}
+
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(0)
+	mockReconciler.EXPECT().Enqueue("default", "test-deployment").Times(1)
+
+	controller.HandleDeploymentDelete(kv)
+}
+
+func TestHandleDeploymentRolloutRestart(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockReconciler := mocks.NewMockDeploymentReconciler(ctrl)
+	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockDeploymentRepo, mockRevisionRepo, mockOrch, mockReconciler)
+
+	deployment := &shared.Deployment{
+		ID:         "dep-1",
+		ObjectMeta: shared.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Replicas:   2,
+		Template: shared.PodTemplate{
+			Spec: shared.PodSpec{Containers: []shared.Container{{Image: "nginx:latest"}}},
+		},
+	}
+	pods := []shared.Pod{{ID: "pod-1", DeploymentID: "dep-1"}, {ID: "pod-2", DeploymentID: "dep-1"}}
+
+	mockRepo.EXPECT().GetPodsByDeploymentID(gomock.Any(), "default", "dep-1").Return(pods, nil)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockOrch.EXPECT().OrchestratePodCreation(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
 		assert.Equal(t, "dep-1", pod.DeploymentID)
-		assert.Equal(t, "test-deployment", pod.Name)
 		assert.Equal(t, "nginx:latest", pod.Containers[0].Image)
-		assert.Equal(t, 80, pod.Containers[0].Ports[0].ContainerPort)
 		return nil
 	})
 
-	controller.HandleDeploymentUpdate(oldKv, newKv)
+	// Act
+	err := controller.HandleDeploymentRolloutRestart(context.Background(), deployment)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestHandleDeploymentRollback(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockReconciler := mocks.NewMockDeploymentReconciler(ctrl)
+	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockDeploymentRepo, mockRevisionRepo, mockOrch, mockReconciler)
+
+	deployment := newTestDeployment(2, "nginx:1.26")
+	revisions := []shared.DeploymentRevision{
+		newTestRevision(newTestDeployment(2, "nginx:1.24"), 1),
+		newTestRevision(newTestDeployment(2, "nginx:1.25"), 2),
+		newTestRevision(deployment, 3),
+	}
+
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return(revisions, nil).Times(2)
+	mockDeploymentRepo.EXPECT().UpdateDeployment(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	// Act
+	errPrevious := controller.HandleDeploymentRollback(context.Background(), deployment, 0)
+	imagePrevious := deployment.Template.Spec.Containers[0].Image
+	errExplicit := controller.HandleDeploymentRollback(context.Background(), deployment, 1)
+	imageExplicit := deployment.Template.Spec.Containers[0].Image
+
+	// Assert
+	assert.NoError(t, errPrevious)
+	assert.Equal(t, "nginx:1.25", imagePrevious)
+	assert.NoError(t, errExplicit)
+	assert.Equal(t, "nginx:1.24", imageExplicit)
+}
+
+func TestHandleDeploymentRollbackUnknownRevision(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockDeploymentRepo := mocks.NewMockDeploymentRepository(ctrl)
+	mockRevisionRepo := mocks.NewMockDeploymentRevisionRepository(ctrl)
+	mockReconciler := mocks.NewMockDeploymentReconciler(ctrl)
+	controller := NewDefaultDeploymentUpdaterController(mockRepo, mockDeploymentRepo, mockRevisionRepo, mockOrch, mockReconciler)
+
+	deployment := newTestDeployment(2, "nginx:1.26")
+	mockRevisionRepo.EXPECT().ListRevisions(gomock.Any(), "default", "test-deployment").Return([]shared.DeploymentRevision{newTestRevision(deployment, 1)}, nil)
+	mockDeploymentRepo.EXPECT().UpdateDeployment(gomock.Any(), gomock.Any()).Times(0)
+
+	// Act
+	err := controller.HandleDeploymentRollback(context.Background(), deployment, 5)
+
+	// Assert
+	var errNotFound *shared.ErrNotFound
+	assert.ErrorAs(t, err, &errNotFound)
 }
@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/shared"
+
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultEndpointsResyncInterval = 30 * time.Second
+	endpointsRequeueDelay          = 5 * time.Second
+	endpointsRewatchDelay          = 5 * time.Second
+)
+
+// Component responsible for the endpoints of every service: the running pods its selector matches, with their IP.
+// Service and pod events only enqueue the namespaces and names of the services they concern; a single worker
+// writes the endpoints, and a periodic resync catches up on anything the events missed.
+type DefaultEndpointsController struct {
+	ServiceRepo    etcd.ServiceRepository
+	PodRepo        etcd.PodRepository
+	EndpointsRepo  etcd.EndpointsRepository
+	Queue          *WorkQueue
+	ResyncInterval time.Duration
+}
+
+func NewDefaultEndpointsController(
+	serviceRepo etcd.ServiceRepository,
+	podRepo etcd.PodRepository,
+	endpointsRepo etcd.EndpointsRepository,
+) EndpointsController {
+	return &DefaultEndpointsController{
+		ServiceRepo:    serviceRepo,
+		PodRepo:        podRepo,
+		EndpointsRepo:  endpointsRepo,
+		Queue:          NewWorkQueue(),
+		ResyncInterval: DefaultEndpointsResyncInterval,
+	}
+}
+
+func (c *DefaultEndpointsController) Run(ctx context.Context) {
+	shared.Log.Infof("Starting endpoints controller...")
+
+	go c.runWorker(ctx)
+	go c.watchServices(ctx)
+	go c.watchPods(ctx)
+
+	c.resync(ctx)
+	ticker := time.NewTicker(c.ResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.Queue.ShutDown()
+			return
+		case <-ticker.C:
+			c.resync(ctx)
+		}
+	}
+}
+
+func (c *DefaultEndpointsController) watchServices(ctx context.Context) {
+	for {
+		for event := range c.ServiceRepo.WatchServices(ctx) {
+			if event.Type == shared.WatchEventError {
+				shared.Log.Errorf("Endpoints controller: %v", event.Err)
+				continue
+			}
+			c.Queue.Add(etcd.ObjectKey(event.Object.Namespace, event.Object.Name))
+		}
+		if !c.rewatch(ctx) {
+			return
+		}
+	}
+}
+
+func (c *DefaultEndpointsController) watchPods(ctx context.Context) {
+	for {
+		for event := range c.PodRepo.WatchPods(ctx) {
+			if event.Type == shared.WatchEventError {
+				shared.Log.Errorf("Endpoints controller: %v", event.Err)
+				continue
+			}
+			if !podEndpointChanged(event) {
+				continue
+			}
+			c.enqueueServicesOf(ctx, event.Object)
+			if event.PrevObject != nil && !maps.Equal(event.PrevObject.Labels, event.Object.Labels) {
+				c.enqueueServicesOf(ctx, event.PrevObject)
+			}
+		}
+		if !c.rewatch(ctx) {
+			return
+		}
+	}
+}
+
+// Waits before a watch that ended is opened anew and catches up on the changes it missed, false once ctx is done
+func (c *DefaultEndpointsController) rewatch(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(endpointsRewatchDelay):
+	}
+	c.resync(ctx)
+	return true
+}
+
+// Pods update their status all the time; only some of the changes move endpoints
+func podEndpointChanged(event shared.WatchEvent[shared.Pod]) bool {
+	if event.Type != shared.WatchEventUpdate || event.PrevObject == nil {
+		return true
+	}
+
+	previous, pod := event.PrevObject, event.Object
+	return previous.Status.Phase != pod.Status.Phase ||
+		previous.Status.PodIP != pod.Status.PodIP ||
+		previous.NodeID != pod.NodeID ||
+		shared.IsPodReady(*previous) != shared.IsPodReady(*pod) ||
+		!maps.Equal(previous.Labels, pod.Labels)
+}
+
+func (c *DefaultEndpointsController) enqueueServicesOf(ctx context.Context, pod *shared.Pod) {
+	services, err := c.ServiceRepo.ListServices(ctx, pod.Namespace)
+	if err != nil {
+		shared.Log.Errorf("Failed to list services of namespace %s: %v", pod.Namespace, err)
+		return
+	}
+
+	for _, service := range services {
+		if selectsPod(service, *pod) {
+			c.Queue.Add(etcd.ObjectKey(service.Namespace, service.Name))
+		}
+	}
+}
+
+// Services without a selector select no pods
+func selectsPod(service shared.Service, pod shared.Pod) bool {
+	if len(service.Selector) == 0 || pod.Namespace != service.Namespace {
+		return false
+	}
+	return shared.MatchesLabelSelector(shared.LabelSelector{MatchLabels: service.Selector}, pod.Labels)
+}
+
+func (c *DefaultEndpointsController) runWorker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *DefaultEndpointsController) processNextItem(ctx context.Context) bool {
+	serviceKey, shutdown := c.Queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.Queue.Done(serviceKey)
+
+	namespace, serviceName := splitObjectKey(serviceKey)
+	if err := c.syncEndpoints(ctx, namespace, serviceName); err != nil {
+		shared.Log.Errorf("Failed to sync endpoints of service %s: %v", serviceKey, err)
+		c.Queue.AddAfter(serviceKey, endpointsRequeueDelay)
+	}
+	return true
+}
+
+// Endpoints left behind by deleted services are only found by listing them
+func (c *DefaultEndpointsController) resync(ctx context.Context) {
+	services, err := c.ServiceRepo.ListServices(ctx, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to list services: %v", err)
+		return
+	}
+	for _, service := range services {
+		c.Queue.Add(etcd.ObjectKey(service.Namespace, service.Name))
+	}
+
+	endpointsList, err := c.EndpointsRepo.ListEndpoints(ctx, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to list endpoints: %v", err)
+		return
+	}
+	for _, endpoints := range endpointsList {
+		c.Queue.Add(etcd.ObjectKey(endpoints.Namespace, endpoints.Name))
+	}
+}
+
+func (c *DefaultEndpointsController) syncEndpoints(ctx context.Context, namespace string, serviceName string) error {
+	var errNotFound *shared.ErrNotFound
+	service, err := c.ServiceRepo.GetServiceByName(ctx, namespace, serviceName)
+	if err != nil {
+		if !errors.As(err, &errNotFound) {
+			return err
+		}
+		if err := c.EndpointsRepo.DeleteEndpoints(ctx, namespace, serviceName); err != nil && !errors.As(err, &errNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	pods, err := c.PodRepo.ListPods(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	desired := endpointsForService(*service, pods)
+
+	existing, err := c.EndpointsRepo.GetEndpoints(ctx, namespace, serviceName)
+	if err != nil {
+		if !errors.As(err, &errNotFound) {
+			return err
+		}
+		shared.Log.Infof("Creating endpoints of service %s/%s with %d ready pods", namespace, serviceName, len(desired.Addresses))
+		return c.EndpointsRepo.CreateEndpoints(ctx, &desired)
+	}
+
+	if areEndpointsEqual(*existing, desired) {
+		return nil
+	}
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Addresses = desired.Addresses
+	existing.NotReadyAddresses = desired.NotReadyAddresses
+	existing.Ports = desired.Ports
+	return c.EndpointsRepo.UpdateEndpoints(ctx, existing)
+}
+
+// The running pods the service selects, ready or not, and the ports they receive the traffic of the service on
+func endpointsForService(service shared.Service, pods []shared.Pod) shared.Endpoints {
+	endpoints := shared.Endpoints{
+		ObjectMeta: shared.ObjectMeta{
+			Name:            service.Name,
+			Namespace:       service.Namespace,
+			Labels:          service.Labels,
+			OwnerReferences: []shared.OwnerReference{shared.NewOwnerReference(shared.ServiceResource, service.ObjectMeta, true)},
+		},
+		Addresses: []shared.EndpointAddress{},
+		Ports:     []shared.EndpointPort{},
+	}
+
+	for _, port := range service.Ports {
+		targetPort := port.TargetPort
+		if targetPort == 0 {
+			targetPort = port.Port
+		}
+		protocol := strings.ToLower(port.Protocol)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		endpoints.Ports = append(endpoints.Ports, shared.EndpointPort{ServicePort: port.Port, Port: targetPort, Protocol: protocol})
+	}
+
+	for _, pod := range pods {
+		if !selectsPod(service, pod) || pod.Status.Phase != shared.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+
+		address := shared.EndpointAddress{IP: pod.Status.PodIP, PodID: pod.ID, NodeID: pod.NodeID}
+		if shared.IsPodReady(pod) {
+			endpoints.Addresses = append(endpoints.Addresses, address)
+		} else {
+			endpoints.NotReadyAddresses = append(endpoints.NotReadyAddresses, address)
+		}
+	}
+
+	// Sorted so that the same pods always make the same endpoints
+	sortAddresses(endpoints.Addresses)
+	sortAddresses(endpoints.NotReadyAddresses)
+	return endpoints
+}
+
+func sortAddresses(addresses []shared.EndpointAddress) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].IP < addresses[j].IP
+	})
+}
+
+func areEndpointsEqual(a, b shared.Endpoints) bool {
+	return maps.Equal(a.Labels, b.Labels) &&
+		reflect.DeepEqual(a.OwnerReferences, b.OwnerReferences) &&
+		reflect.DeepEqual(a.Addresses, b.Addresses) &&
+		reflect.DeepEqual(a.NotReadyAddresses, b.NotReadyAddresses) &&
+		reflect.DeepEqual(a.Ports, b.Ports)
+}
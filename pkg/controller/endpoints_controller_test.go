@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestService() *shared.Service {
+	return &shared.Service{
+		ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default", UID: "service-uid"},
+		Selector:   map[string]string{"app": "web"},
+		Ports:      []shared.ServicePort{{Port: 80, TargetPort: 8080}},
+	}
+}
+
+func newEndpointTestPod(id string, labels map[string]string, ip string, ready bool) shared.Pod {
+	return shared.Pod{
+		ID:         id,
+		ObjectMeta: shared.ObjectMeta{Name: id, Namespace: "default", Labels: labels},
+		NodeID:     "node-1",
+		Status: shared.PodStatus{
+			Phase:      shared.PodRunning,
+			PodIP:      ip,
+			Conditions: []shared.PodCondition{{Type: shared.PodReadyCondition, Status: ready}},
+		},
+	}
+}
+
+func TestEndpointsForService(t *testing.T) {
+	service := newTestService()
+	pending := newEndpointTestPod("pending", map[string]string{"app": "web"}, "", false)
+	pending.Status.Phase = shared.PodPending
+	pods := []shared.Pod{
+		newEndpointTestPod("web-2", map[string]string{"app": "web"}, "172.17.0.3", true),
+		newEndpointTestPod("web-1", map[string]string{"app": "web", "tier": "frontend"}, "172.17.0.2", true),
+		newEndpointTestPod("web-3", map[string]string{"app": "web"}, "172.17.0.4", false),
+		newEndpointTestPod("db", map[string]string{"app": "db"}, "172.17.0.5", true),
+		pending,
+	}
+
+	endpoints := endpointsForService(*service, pods)
+
+	assert.Equal(t, "web", endpoints.Name)
+	assert.Equal(t, "service-uid", endpoints.OwnerReferences[0].UID)
+	assert.Equal(t, []shared.EndpointAddress{
+		{IP: "172.17.0.2", PodID: "web-1", NodeID: "node-1"},
+		{IP: "172.17.0.3", PodID: "web-2", NodeID: "node-1"},
+	}, endpoints.Addresses)
+	assert.Equal(t, []shared.EndpointAddress{{IP: "172.17.0.4", PodID: "web-3", NodeID: "node-1"}}, endpoints.NotReadyAddresses)
+	assert.Equal(t, []shared.EndpointPort{{ServicePort: 80, Port: 8080, Protocol: "tcp"}}, endpoints.Ports)
+}
+
+func TestEndpointsControllerCreatesEndpoints(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	controller := NewDefaultEndpointsController(mockServiceRepo, mockPodRepo, mockEndpointsRepo).(*DefaultEndpointsController)
+
+	pods := []shared.Pod{newEndpointTestPod("web-1", map[string]string{"app": "web"}, "172.17.0.2", true)}
+	mockServiceRepo.EXPECT().GetServiceByName(gomock.Any(), "default", "web").Return(newTestService(), nil)
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "default").Return(pods, nil)
+	mockEndpointsRepo.EXPECT().GetEndpoints(gomock.Any(), "default", "web").Return(nil, &shared.ErrNotFound{ID: "web", ResourceType: shared.EndpointsResource})
+
+	var created *shared.Endpoints
+	mockEndpointsRepo.EXPECT().CreateEndpoints(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, endpoints *shared.Endpoints) error {
+			created = endpoints
+			return nil
+		})
+
+	// Act
+	err := controller.syncEndpoints(context.Background(), "default", "web")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "web", created.Name)
+	assert.Equal(t, []shared.EndpointAddress{{IP: "172.17.0.2", PodID: "web-1", NodeID: "node-1"}}, created.Addresses)
+}
+
+func TestEndpointsControllerUpdatesChangedEndpointsOnly(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	controller := NewDefaultEndpointsController(mockServiceRepo, mockPodRepo, mockEndpointsRepo).(*DefaultEndpointsController)
+
+	service := newTestService()
+	before := []shared.Pod{newEndpointTestPod("web-1", map[string]string{"app": "web"}, "172.17.0.2", true)}
+	after := append(before, newEndpointTestPod("web-2", map[string]string{"app": "web"}, "172.17.0.3", true))
+	existing := endpointsForService(*service, before)
+	existing.ResourceVersion = 7
+
+	mockServiceRepo.EXPECT().GetServiceByName(gomock.Any(), "default", "web").Times(2).Return(service, nil)
+	gomock.InOrder(
+		mockPodRepo.EXPECT().ListPods(gomock.Any(), "default").Return(before, nil),
+		mockPodRepo.EXPECT().ListPods(gomock.Any(), "default").Return(after, nil),
+	)
+	mockEndpointsRepo.EXPECT().GetEndpoints(gomock.Any(), "default", "web").Times(2).Return(&existing, nil)
+	mockEndpointsRepo.EXPECT().UpdateEndpoints(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	// Act
+	errUnchanged := controller.syncEndpoints(context.Background(), "default", "web")
+	errChanged := controller.syncEndpoints(context.Background(), "default", "web")
+
+	// Assert
+	assert.NoError(t, errUnchanged)
+	assert.NoError(t, errChanged)
+	assert.Len(t, existing.Addresses, 2)
+	assert.Equal(t, int64(7), existing.ResourceVersion)
+}
+
+func TestEndpointsControllerDeletesEndpointsOfDeletedService(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	controller := NewDefaultEndpointsController(mockServiceRepo, mocks.NewMockPodRepository(ctrl), mockEndpointsRepo).(*DefaultEndpointsController)
+
+	mockServiceRepo.EXPECT().GetServiceByName(gomock.Any(), "default", "web").Return(nil, &shared.ErrNotFound{ID: "web", ResourceType: shared.ServiceResource})
+	mockEndpointsRepo.EXPECT().DeleteEndpoints(gomock.Any(), "default", "web").Return(nil)
+
+	// Act
+	err := controller.syncEndpoints(context.Background(), "default", "web")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestPodEndpointChanged(t *testing.T) {
+	pod := newEndpointTestPod("web-1", map[string]string{"app": "web"}, "172.17.0.2", true)
+	restarted := pod
+	restarted.Status.ContainerStatuses = []shared.ContainerStatus{{RestartCount: 1}}
+	relabeled := pod
+	relabeled.Labels = map[string]string{"app": "api"}
+	failed := pod
+	failed.Status.Phase = shared.PodFailed
+
+	assert.True(t, podEndpointChanged(shared.WatchEvent[shared.Pod]{Type: shared.WatchEventDelete, Object: &pod}))
+	assert.False(t, podEndpointChanged(shared.WatchEvent[shared.Pod]{Type: shared.WatchEventUpdate, Object: &restarted, PrevObject: &pod}))
+	assert.True(t, podEndpointChanged(shared.WatchEvent[shared.Pod]{Type: shared.WatchEventUpdate, Object: &relabeled, PrevObject: &pod}))
+	assert.True(t, podEndpointChanged(shared.WatchEvent[shared.Pod]{Type: shared.WatchEventUpdate, Object: &failed, PrevObject: &pod}))
+}
+
+func TestEndpointsControllerStopsWatchingServicesAfterFailedWatch(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	controller := NewDefaultEndpointsController(mockServiceRepo, nil, nil).(*DefaultEndpointsController)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	events := make(chan shared.WatchEvent[shared.Service], 1)
+	events <- shared.WatchEvent[shared.Service]{Type: shared.WatchEventError, Err: errors.New("compacted")}
+	close(events)
+	mockServiceRepo.EXPECT().WatchServices(ctx).Return(events)
+
+	// Act
+	controller.watchServices(ctx)
+
+	// Assert, the failure enqueued nothing and no new watch was opened once ctx was done
+	assert.Equal(t, 0, controller.Queue.Len())
+}
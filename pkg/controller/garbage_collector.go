@@ -0,0 +1,306 @@
+package controller
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/orchestrator"
+	"maden/pkg/shared"
+
+	"context"
+	"slices"
+	"time"
+)
+
+const DefaultGarbageCollectionInterval = 30 * time.Second
+
+// Component responsible for the dependents of deleted objects, as recorded by their owner references:
+// pods owned by deployments and volume claims bound to persistent volumes.
+// Deletions go through it to apply their propagation policy, and a periodic sweep deletes the dependents
+// whose owners are all gone, for instance because the delete event of the owner was missed.
+type DefaultGarbageCollector struct {
+	DeploymentRepo          etcd.DeploymentRepository
+	PodRepo                 etcd.PodRepository
+	VolumeRepo              etcd.PersistentVolumeRepository
+	VolumeClaimRepo         etcd.PersistentVolumeClaimRepository
+	PodOrchestrator         orchestrator.PodOrchestrator
+	VolumeClaimOrchestrator orchestrator.PersistentVolumeClaimOrchestrator
+	Interval                time.Duration
+
+	trigger chan struct{} // Asks for a sweep ahead of the interval
+}
+
+func NewDefaultGarbageCollector(
+	deploymentRepo etcd.DeploymentRepository,
+	podRepo etcd.PodRepository,
+	volumeRepo etcd.PersistentVolumeRepository,
+	volumeClaimRepo etcd.PersistentVolumeClaimRepository,
+	podOrchestrator orchestrator.PodOrchestrator,
+	volumeClaimOrchestrator orchestrator.PersistentVolumeClaimOrchestrator,
+) GarbageCollector {
+	return &DefaultGarbageCollector{
+		DeploymentRepo:          deploymentRepo,
+		PodRepo:                 podRepo,
+		VolumeRepo:              volumeRepo,
+		VolumeClaimRepo:         volumeClaimRepo,
+		PodOrchestrator:         podOrchestrator,
+		VolumeClaimOrchestrator: volumeClaimOrchestrator,
+		Interval:                DefaultGarbageCollectionInterval,
+		trigger:                 make(chan struct{}, 1),
+	}
+}
+
+func (gc *DefaultGarbageCollector) Run(ctx context.Context) {
+	shared.Log.Infof("Starting garbage collector...")
+
+	gc.sweep(ctx)
+	ticker := time.NewTicker(gc.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			gc.sweep(ctx)
+		case <-gc.trigger:
+			gc.sweep(ctx)
+		}
+	}
+}
+
+// Sweeps that are already asked for cover the dependents of this owner as well
+func (gc *DefaultGarbageCollector) triggerSweep() {
+	select {
+	case gc.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (gc *DefaultGarbageCollector) sweep(ctx context.Context) {
+	if err := gc.finishDeploymentDeletions(ctx); err != nil {
+		shared.Log.Errorf("Failed to finish deployment deletions: %v", err)
+	}
+	if err := gc.collectGarbage(ctx); err != nil {
+		shared.Log.Errorf("Failed to collect garbage: %v", err)
+	}
+}
+
+// Deployments
+// Foreground and Orphan deletions first mark the deployment with a finalizer, so that a deletion that fails
+// half-way is finished by the next sweep
+func (gc *DefaultGarbageCollector) DeleteDeployment(ctx context.Context, namespace string, deploymentName string, policy shared.DeletionPropagation) error {
+	if policy == shared.DeletePropagationBackground {
+		return gc.deleteInBackground(gc.DeploymentRepo.DeleteDeployment(ctx, namespace, deploymentName))
+	}
+
+	var deployment *shared.Deployment
+	err := shared.RetryOnConflict(func() error {
+		var err error
+		deployment, err = gc.DeploymentRepo.GetDeploymentByName(ctx, namespace, deploymentName)
+		if err != nil {
+			return err
+		}
+		if deployment.DeletionTimestamp != nil {
+			return nil
+		}
+
+		now := time.Now()
+		deployment.DeletionTimestamp = &now
+		deployment.Finalizers = append(deployment.Finalizers, finalizerForPolicy(policy))
+		return gc.DeploymentRepo.UpdateDeployment(ctx, deployment)
+	})
+	if err != nil {
+		return err
+	}
+
+	return gc.finishDeploymentDeletion(ctx, deployment)
+}
+
+func finalizerForPolicy(policy shared.DeletionPropagation) string {
+	if policy == shared.DeletePropagationOrphan {
+		return shared.OrphanFinalizer
+	}
+	return shared.ForegroundDeletionFinalizer
+}
+
+func (gc *DefaultGarbageCollector) finishDeploymentDeletions(ctx context.Context) error {
+	deployments, err := gc.DeploymentRepo.ListDeployments(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for _, deployment := range deployments {
+		if deployment.DeletionTimestamp == nil {
+			continue
+		}
+		if err := gc.finishDeploymentDeletion(ctx, &deployment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gc *DefaultGarbageCollector) finishDeploymentDeletion(ctx context.Context, deployment *shared.Deployment) error {
+	pods, err := gc.PodRepo.ListPods(ctx, deployment.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		if !pod.IsOwnedBy(deployment.UID) {
+			continue
+		}
+
+		if slices.Contains(deployment.Finalizers, shared.OrphanFinalizer) {
+			err = gc.orphanPod(ctx, &pod, deployment.UID)
+		} else {
+			err = gc.PodOrchestrator.OrchestratePodDeletion(ctx, &pod)
+		}
+		if err := ignoreNotFound(err); err != nil {
+			return err
+		}
+	}
+
+	shared.Log.Infof("Deleting deployment %s after its pods", deployment.Name)
+	return ignoreNotFound(gc.DeploymentRepo.DeleteDeployment(ctx, deployment.Namespace, deployment.Name))
+}
+
+// The pod keeps running on its own, no longer counted towards the replicas of the deployment
+func (gc *DefaultGarbageCollector) orphanPod(ctx context.Context, pod *shared.Pod, ownerUID string) error {
+	return shared.RetryOnConflict(func() error {
+		latestPod, err := gc.PodRepo.GetPodByID(ctx, pod.Namespace, pod.ID)
+		if err != nil {
+			return err
+		}
+		if !latestPod.RemoveOwnerReference(ownerUID) {
+			return nil
+		}
+
+		latestPod.DeploymentID = ""
+		return gc.PodRepo.UpdatePod(ctx, latestPod)
+	})
+}
+
+// Persistent volumes
+func (gc *DefaultGarbageCollector) DeletePersistentVolume(ctx context.Context, volumeID string, policy shared.DeletionPropagation) error {
+	if policy == shared.DeletePropagationBackground {
+		return gc.deleteInBackground(gc.VolumeRepo.DeletePersistentVolume(ctx, volumeID))
+	}
+
+	volume, err := gc.VolumeRepo.GetPersistentVolumeByID(ctx, volumeID)
+	if err != nil {
+		return err
+	}
+
+	claims, err := gc.VolumeClaimRepo.ListPersistentVolumeClaims(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for _, claim := range claims {
+		if !claim.IsOwnedBy(volume.UID) {
+			continue
+		}
+
+		if policy == shared.DeletePropagationOrphan {
+			err = gc.orphanVolumeClaim(ctx, &claim, volume.UID)
+		} else {
+			err = gc.VolumeClaimOrchestrator.OrchestratePersistentVolumeClaimDeletion(ctx, claim.Namespace, claim.ID)
+		}
+		if err := ignoreNotFound(err); err != nil {
+			return err
+		}
+	}
+
+	return gc.VolumeRepo.DeletePersistentVolume(ctx, volumeID)
+}
+
+// The owner is gone once deleteErr is nil, its dependents follow with the next sweep
+func (gc *DefaultGarbageCollector) deleteInBackground(deleteErr error) error {
+	if deleteErr != nil {
+		return deleteErr
+	}
+	gc.triggerSweep()
+	return nil
+}
+
+func (gc *DefaultGarbageCollector) orphanVolumeClaim(ctx context.Context, claim *shared.PersistentVolumeClaim, ownerUID string) error {
+	return shared.RetryOnConflict(func() error {
+		latestClaim, err := gc.VolumeClaimRepo.GetPersistentVolumeClaimByID(ctx, claim.Namespace, claim.ID)
+		if err != nil {
+			return err
+		}
+		if !latestClaim.RemoveOwnerReference(ownerUID) {
+			return nil
+		}
+
+		return gc.VolumeClaimRepo.UpdatePersistentVolumeClaim(ctx, latestClaim)
+	})
+}
+
+// Garbage
+// Dependents are listed before their owners: an owner always exists before its dependents,
+// so an owner missing from the later listing is really gone
+func (gc *DefaultGarbageCollector) collectGarbage(ctx context.Context) error {
+	pods, err := gc.PodRepo.ListPods(ctx, "")
+	if err != nil {
+		return err
+	}
+	claims, err := gc.VolumeClaimRepo.ListPersistentVolumeClaims(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	deployments, err := gc.DeploymentRepo.ListDeployments(ctx, "")
+	if err != nil {
+		return err
+	}
+	volumes, err := gc.VolumeRepo.ListPersistentVolumes(ctx)
+	if err != nil {
+		return err
+	}
+
+	ownerUIDs := make(map[string]bool)
+	for _, deployment := range deployments {
+		ownerUIDs[deployment.UID] = true
+	}
+	for _, volume := range volumes {
+		ownerUIDs[volume.UID] = true
+	}
+
+	for _, pod := range pods {
+		if !isGarbage(pod.ObjectMeta, ownerUIDs) {
+			continue
+		}
+
+		shared.Log.Infof("Deleting pod %s whose owners are gone", pod.ID)
+		if err := ignoreNotFound(gc.PodOrchestrator.OrchestratePodDeletion(ctx, &pod)); err != nil {
+			return err
+		}
+	}
+
+	for _, claim := range claims {
+		if !isGarbage(claim.ObjectMeta, ownerUIDs) {
+			continue
+		}
+
+		shared.Log.Infof("Deleting persistent volume claim %s whose volume is gone", claim.ID)
+		if err := ignoreNotFound(gc.VolumeClaimOrchestrator.OrchestratePersistentVolumeClaimDeletion(ctx, claim.Namespace, claim.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Objects without owner references are never garbage, the others are as soon as all their owners are gone
+func isGarbage(meta shared.ObjectMeta, ownerUIDs map[string]bool) bool {
+	if len(meta.OwnerReferences) == 0 {
+		return false
+	}
+
+	for _, ref := range meta.OwnerReferences {
+		if ownerUIDs[ref.UID] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGarbageCollector(ctrl *gomock.Controller) *DefaultGarbageCollector {
+	return NewDefaultGarbageCollector(
+		mocks.NewMockDeploymentRepository(ctrl),
+		mocks.NewMockPodRepository(ctrl),
+		mocks.NewMockPersistentVolumeRepository(ctrl),
+		mocks.NewMockPersistentVolumeClaimRepository(ctrl),
+		mocks.NewMockPodOrchestrator(ctrl),
+		mocks.NewMockPersistentVolumeClaimOrchestrator(ctrl),
+	).(*DefaultGarbageCollector)
+}
+
+func newOwnedTestPod(id string, ownerUID string) shared.Pod {
+	pod := newTestPod(id, shared.PodRunning, "nginx:latest")
+	pod.OwnerReferences = []shared.OwnerReference{{Kind: "Deployment", Name: "test-deployment", UID: ownerUID, Controller: true}}
+	return pod
+}
+
+func TestGarbageCollectorDeletesPodsWithoutOwners(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gc := newTestGarbageCollector(ctrl)
+	mockDeploymentRepo := gc.DeploymentRepo.(*mocks.MockDeploymentRepository)
+	mockPodRepo := gc.PodRepo.(*mocks.MockPodRepository)
+	mockVolumeRepo := gc.VolumeRepo.(*mocks.MockPersistentVolumeRepository)
+	mockVolumeClaimRepo := gc.VolumeClaimRepo.(*mocks.MockPersistentVolumeClaimRepository)
+	mockPodOrch := gc.PodOrchestrator.(*mocks.MockPodOrchestrator)
+
+	pods := []shared.Pod{
+		newOwnedTestPod("orphaned-pod", "uid-gone"),
+		newOwnedTestPod("owned-pod", "uid-1"),
+		{ID: "standalone-pod", Status: shared.PodStatus{Phase: shared.PodRunning}},
+	}
+	deployment := newTestDeployment(1, "nginx:latest")
+	deployment.UID = "uid-1"
+
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "").Return(pods, nil)
+	mockVolumeClaimRepo.EXPECT().ListPersistentVolumeClaims(gomock.Any(), "").Return(nil, nil)
+	mockDeploymentRepo.EXPECT().ListDeployments(gomock.Any(), "").Return([]shared.Deployment{*deployment}, nil)
+	mockVolumeRepo.EXPECT().ListPersistentVolumes(gomock.Any()).Return(nil, nil)
+	mockPodOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		assert.Equal(t, "orphaned-pod", pod.ID)
+		return nil
+	})
+
+	// Act
+	err := gc.collectGarbage(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestGarbageCollectorDeletesClaimsOfDeletedVolumes(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gc := newTestGarbageCollector(ctrl)
+	mockDeploymentRepo := gc.DeploymentRepo.(*mocks.MockDeploymentRepository)
+	mockPodRepo := gc.PodRepo.(*mocks.MockPodRepository)
+	mockVolumeRepo := gc.VolumeRepo.(*mocks.MockPersistentVolumeRepository)
+	mockVolumeClaimRepo := gc.VolumeClaimRepo.(*mocks.MockPersistentVolumeClaimRepository)
+	mockVolumeClaimOrch := gc.VolumeClaimOrchestrator.(*mocks.MockPersistentVolumeClaimOrchestrator)
+
+	claims := []shared.PersistentVolumeClaim{
+		{ID: "claim-1", ObjectMeta: shared.ObjectMeta{Namespace: "default", OwnerReferences: []shared.OwnerReference{{Kind: "PersistentVolume", UID: "pv-gone"}}}},
+		{ID: "claim-2", ObjectMeta: shared.ObjectMeta{Namespace: "default", OwnerReferences: []shared.OwnerReference{{Kind: "PersistentVolume", UID: "pv-1"}}}},
+	}
+
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "").Return(nil, nil)
+	mockVolumeClaimRepo.EXPECT().ListPersistentVolumeClaims(gomock.Any(), "").Return(claims, nil)
+	mockDeploymentRepo.EXPECT().ListDeployments(gomock.Any(), "").Return(nil, nil)
+	mockVolumeRepo.EXPECT().ListPersistentVolumes(gomock.Any()).Return([]shared.PersistentVolume{{ID: "pv-1", ObjectMeta: shared.ObjectMeta{UID: "pv-1"}}}, nil)
+	mockVolumeClaimOrch.EXPECT().OrchestratePersistentVolumeClaimDeletion(gomock.Any(), "default", "claim-1").Return(nil)
+
+	// Act
+	err := gc.collectGarbage(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestGarbageCollectorDeleteDeploymentForeground(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gc := newTestGarbageCollector(ctrl)
+	mockDeploymentRepo := gc.DeploymentRepo.(*mocks.MockDeploymentRepository)
+	mockPodRepo := gc.PodRepo.(*mocks.MockPodRepository)
+	mockPodOrch := gc.PodOrchestrator.(*mocks.MockPodOrchestrator)
+
+	deployment := newTestDeployment(2, "nginx:latest")
+	deployment.UID = "uid-1"
+
+	gomock.InOrder(
+		mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(deployment, nil),
+		mockDeploymentRepo.EXPECT().UpdateDeployment(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, deployment *shared.Deployment) error {
+			assert.NotNil(t, deployment.DeletionTimestamp)
+			assert.Equal(t, []string{shared.ForegroundDeletionFinalizer}, deployment.Finalizers)
+			return nil
+		}),
+		mockPodRepo.EXPECT().ListPods(gomock.Any(), "default").Return([]shared.Pod{newOwnedTestPod("pod-1", "uid-1"), newOwnedTestPod("pod-2", "uid-other")}, nil),
+		mockPodOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+			assert.Equal(t, "pod-1", pod.ID)
+			return nil
+		}),
+		mockDeploymentRepo.EXPECT().DeleteDeployment(gomock.Any(), "default", "test-deployment").Return(nil),
+	)
+
+	// Act
+	err := gc.DeleteDeployment(context.Background(), "default", "test-deployment", shared.DeletePropagationForeground)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestGarbageCollectorDeleteDeploymentOrphan(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gc := newTestGarbageCollector(ctrl)
+	mockDeploymentRepo := gc.DeploymentRepo.(*mocks.MockDeploymentRepository)
+	mockPodRepo := gc.PodRepo.(*mocks.MockPodRepository)
+	mockPodOrch := gc.PodOrchestrator.(*mocks.MockPodOrchestrator)
+
+	deployment := newTestDeployment(1, "nginx:latest")
+	deployment.UID = "uid-1"
+	pod := newOwnedTestPod("pod-1", "uid-1")
+
+	mockDeploymentRepo.EXPECT().GetDeploymentByName(gomock.Any(), "default", "test-deployment").Return(deployment, nil)
+	mockDeploymentRepo.EXPECT().UpdateDeployment(gomock.Any(), gomock.Any()).Return(nil)
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "default").Return([]shared.Pod{pod}, nil)
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(&pod, nil)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		assert.Empty(t, pod.OwnerReferences)
+		assert.Empty(t, pod.DeploymentID)
+		return nil
+	})
+	mockPodOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(0)
+	mockDeploymentRepo.EXPECT().DeleteDeployment(gomock.Any(), "default", "test-deployment").Return(nil)
+
+	// Act
+	err := gc.DeleteDeployment(context.Background(), "default", "test-deployment", shared.DeletePropagationOrphan)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestGarbageCollectorDeleteDeploymentBackground(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gc := newTestGarbageCollector(ctrl)
+	mockDeploymentRepo := gc.DeploymentRepo.(*mocks.MockDeploymentRepository)
+
+	mockDeploymentRepo.EXPECT().DeleteDeployment(gomock.Any(), "default", "test-deployment").Return(nil)
+
+	// Act
+	err := gc.DeleteDeployment(context.Background(), "default", "test-deployment", shared.DeletePropagationBackground)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, gc.trigger, 1) // The pods follow with the next sweep
+}
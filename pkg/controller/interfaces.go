@@ -3,22 +3,56 @@ package controller
 import (
 	"maden/pkg/shared"
 
+	"context"
+
 	"go.etcd.io/etcd/api/v3/mvccpb"
 )
 
+type NamespaceController interface {
+	HandleIncomingNamespace(ctx context.Context, namespaceSpec shared.NamespaceSpec) error
+	EnsureDefaultNamespace(ctx context.Context) error
+	ValidateNamespace(ctx context.Context, namespaceName string) error
+	HandleNamespaceDeletion(ctx context.Context, namespaceName string) error
+}
+
 type DeploymentController interface {
-	HandleIncomingDeployment(deploymentSpec shared.DeploymentSpec) error
+	HandleIncomingDeployment(ctx context.Context, deploymentSpec shared.DeploymentSpec) error
 }
 
 type DeploymentUpdaterController interface {
 	HandleDeploymentCreate(kv *mvccpb.KeyValue)
 	HandleDeploymentUpdate(oldKv *mvccpb.KeyValue, newKv *mvccpb.KeyValue)
 	HandleDeploymentDelete(kv *mvccpb.KeyValue)
-	HandleDeploymentRolloutRestart(deployment *shared.Deployment) error
+	HandleDeploymentRolloutRestart(ctx context.Context, deployment *shared.Deployment) error
+	HandleDeploymentRollback(ctx context.Context, deployment *shared.Deployment, toRevision int) error
+}
+
+type DeploymentReconciler interface {
+	Run(ctx context.Context)
+	Enqueue(namespace string, deploymentName string)
+}
+
+type DeploymentRolloutEngine interface {
+	Rollout(ctx context.Context, deployment *shared.Deployment, currentPods []shared.Pod, outdatedPods []shared.Pod) (bool, error)
+}
+
+type GarbageCollector interface {
+	Run(ctx context.Context)
+	DeleteDeployment(ctx context.Context, namespace string, deploymentName string, policy shared.DeletionPropagation) error
+	DeletePersistentVolume(ctx context.Context, volumeID string, policy shared.DeletionPropagation) error
+}
+
+type NodeUpdaterController interface {
+	HandleNodeCreate(kv *mvccpb.KeyValue)
+	HandleNodeUpdate(oldKv *mvccpb.KeyValue, newKv *mvccpb.KeyValue)
+}
+
+type NodeLifecycleController interface {
+	Run(ctx context.Context)
 }
 
 type ServiceController interface {
-	HandleIncomingService(serviceSpec shared.ServiceSpec) error
+	HandleIncomingService(ctx context.Context, serviceSpec shared.ServiceSpec) error
 }
 
 type ServiceUpdaterController interface {
@@ -27,14 +61,14 @@ type ServiceUpdaterController interface {
 	HandleServiceDelete(prevKv *mvccpb.KeyValue)
 }
 
-type PodUpdaterController interface {
-	HandlePodUpdate(oldKv *mvccpb.KeyValue, newKv *mvccpb.KeyValue)
+type EndpointsController interface {
+	Run(ctx context.Context)
 }
 
 type PersistentVolumeController interface {
-	HandleIncomingPersistentVolume(volumeSpec shared.PersistentVolumeSpec) error
+	HandleIncomingPersistentVolume(ctx context.Context, volumeSpec shared.PersistentVolumeSpec) error
 }
 
 type PersistentVolumeClaimController interface {
-	HandleIncomingPersistentVolumeClaim(volumeClaimSpec shared.PersistentVolumeClaimSpec) error
+	HandleIncomingPersistentVolumeClaim(ctx context.Context, volumeClaimSpec shared.PersistentVolumeClaimSpec) error
 }
\ No newline at end of file
@@ -12,16 +12,21 @@ type EtcdChangeListener struct {
 	client *clientv3.Client
 	DeploymentController DeploymentUpdaterController
 	ServiceController ServiceUpdaterController
-	PodController PodUpdaterController
+	NodeController NodeUpdaterController
 }
 
 func NewEtcdChangeListener(
 	client *clientv3.Client,
 	deploymentController DeploymentUpdaterController,
 	serviceController ServiceUpdaterController,
-	podController PodUpdaterController,
+	nodeController NodeUpdaterController,
 ) *EtcdChangeListener {
-	return &EtcdChangeListener{client: client, DeploymentController: deploymentController, ServiceController: serviceController, PodController: podController}
+	return &EtcdChangeListener{
+		client: client,
+		DeploymentController: deploymentController,
+		ServiceController: serviceController,
+		NodeController: nodeController,
+	}
 }
 
 func (l *EtcdChangeListener) WatchDeployments() {	
@@ -66,18 +71,20 @@ func (l *EtcdChangeListener) WatchServices() {
 	}
 }
 
-func (l *EtcdChangeListener) WatchPodStatusChanges() {
+func (l *EtcdChangeListener) WatchNodes() {
 	ctx := context.Background()
-	rch := l.client.Watch(ctx, "pods/", clientv3.WithPrefix(), clientv3.WithPrevKV())
-	shared.Log.Infof("Watching pods...")
+	rch := l.client.Watch(ctx, "nodes/", clientv3.WithPrefix(), clientv3.WithPrevKV())
+	shared.Log.Infof("Watching nodes...")
 
 	for wresp := range rch {
 		for _, ev := range wresp.Events {
 			if ev.Type != clientv3.EventTypePut {
 				continue
 			}
-			if !ev.IsCreate() {
-				l.PodController.HandlePodUpdate(ev.PrevKv, ev.Kv)
+			if ev.IsCreate() {
+				l.NodeController.HandleNodeCreate(ev.Kv)
+			} else {
+				l.NodeController.HandleNodeUpdate(ev.PrevKv, ev.Kv)
 			}
 		}
 	}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/orchestrator"
+	"maden/pkg/shared"
+
+	"context"
+	"errors"
+)
+
+// Component responsible for the lifecycle of namespaces. Deleting a namespace deletes everything in it:
+// the namespace is marked as terminating first, so that nothing new is created in it while its objects go away
+type DefaultNamespaceController struct {
+	Repo                    etcd.NamespaceRepository
+	DeploymentRepo          etcd.DeploymentRepository
+	RevisionRepo            etcd.DeploymentRevisionRepository
+	PodRepo                 etcd.PodRepository
+	ServiceRepo             etcd.ServiceRepository
+	VolumeClaimRepo         etcd.PersistentVolumeClaimRepository
+	PodOrchestrator         orchestrator.PodOrchestrator
+	SvcOrchestrator         orchestrator.ServiceOrchestrator
+	VolumeClaimOrchestrator orchestrator.PersistentVolumeClaimOrchestrator
+}
+
+func NewDefaultNamespaceController(
+	repo etcd.NamespaceRepository,
+	deploymentRepo etcd.DeploymentRepository,
+	revisionRepo etcd.DeploymentRevisionRepository,
+	podRepo etcd.PodRepository,
+	serviceRepo etcd.ServiceRepository,
+	volumeClaimRepo etcd.PersistentVolumeClaimRepository,
+	podOrchestrator orchestrator.PodOrchestrator,
+	svcOrchestrator orchestrator.ServiceOrchestrator,
+	volumeClaimOrchestrator orchestrator.PersistentVolumeClaimOrchestrator,
+) NamespaceController {
+	return &DefaultNamespaceController{
+		Repo:                    repo,
+		DeploymentRepo:          deploymentRepo,
+		RevisionRepo:            revisionRepo,
+		PodRepo:                 podRepo,
+		ServiceRepo:             serviceRepo,
+		VolumeClaimRepo:         volumeClaimRepo,
+		PodOrchestrator:         podOrchestrator,
+		SvcOrchestrator:         svcOrchestrator,
+		VolumeClaimOrchestrator: volumeClaimOrchestrator,
+	}
+}
+
+func (c *DefaultNamespaceController) HandleIncomingNamespace(ctx context.Context, namespaceSpec shared.NamespaceSpec) error {
+	err := c.ValidateNamespace(ctx, namespaceSpec.Name)
+	var errNotFound *shared.ErrNotFound
+	if !errors.As(err, &errNotFound) {
+		return err // Nothing to update on an existing namespace
+	}
+
+	shared.Log.Infof("Creating namespace %s", namespaceSpec.Name)
+	namespace := &shared.Namespace{
+		ID:         shared.GenerateRandomString(10),
+		ObjectMeta: shared.ObjectMeta{Name: namespaceSpec.Name, Labels: namespaceSpec.Labels, Annotations: namespaceSpec.Annotations},
+		Phase:      shared.NamespaceActive,
+	}
+	return c.Repo.CreateNamespace(ctx, namespace)
+}
+
+// Objects submitted without a namespace go to the default one, so it has to exist from the start
+func (c *DefaultNamespaceController) EnsureDefaultNamespace(ctx context.Context) error {
+	err := c.HandleIncomingNamespace(ctx, shared.NamespaceSpec{ObjectMeta: shared.ObjectMeta{Name: shared.DefaultNamespace}})
+	var errDuplicate *shared.ErrDuplicateResource
+	if errors.As(err, &errDuplicate) {
+		return nil // Created concurrently
+	}
+	return err
+}
+
+// Fails with ErrNotFound if the namespace does not exist and with ErrNamespaceTerminating if it is being deleted
+func (c *DefaultNamespaceController) ValidateNamespace(ctx context.Context, namespaceName string) error {
+	namespace, err := c.Repo.GetNamespaceByName(ctx, namespaceName)
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			return &shared.ErrNotFound{Name: namespaceName, ResourceType: shared.NamespaceResource}
+		}
+		return err
+	}
+
+	if namespace.Phase == shared.NamespaceTerminating {
+		return &shared.ErrNamespaceTerminating{Namespace: namespaceName}
+	}
+	return nil
+}
+
+// A deletion that fails half-way leaves the namespace terminating, deleting it again picks up where it stopped
+func (c *DefaultNamespaceController) HandleNamespaceDeletion(ctx context.Context, namespaceName string) error {
+	err := shared.RetryOnConflict(func() error {
+		namespace, err := c.Repo.GetNamespaceByName(ctx, namespaceName)
+		if err != nil {
+			return err
+		}
+		if namespace.Phase == shared.NamespaceTerminating {
+			return nil
+		}
+
+		namespace.Phase = shared.NamespaceTerminating
+		return c.Repo.UpdateNamespace(ctx, namespace)
+	})
+	if err != nil {
+		return err
+	}
+
+	shared.Log.Infof("Deleting namespace %s and its contents", namespaceName)
+
+	// Deployments go first, so that their pods are not replaced while being deleted
+	if err := c.deleteDeployments(ctx, namespaceName); err != nil {
+		return err
+	}
+	if err := c.deletePods(ctx, namespaceName); err != nil {
+		return err
+	}
+	if err := c.deleteServices(ctx, namespaceName); err != nil {
+		return err
+	}
+	if err := c.deleteVolumeClaims(ctx, namespaceName); err != nil {
+		return err
+	}
+
+	return ignoreNotFound(c.Repo.DeleteNamespace(ctx, namespaceName))
+}
+
+func (c *DefaultNamespaceController) deleteDeployments(ctx context.Context, namespaceName string) error {
+	deployments, err := c.DeploymentRepo.ListDeployments(ctx, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	for _, deployment := range deployments {
+		if err := ignoreNotFound(c.DeploymentRepo.DeleteDeployment(ctx, namespaceName, deployment.Name)); err != nil {
+			return err
+		}
+		if err := c.RevisionRepo.DeleteRevisions(ctx, namespaceName, deployment.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DefaultNamespaceController) deletePods(ctx context.Context, namespaceName string) error {
+	pods, err := c.PodRepo.ListPods(ctx, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		if err := ignoreNotFound(c.PodOrchestrator.OrchestratePodDeletion(ctx, &pod)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DefaultNamespaceController) deleteServices(ctx context.Context, namespaceName string) error {
+	services, err := c.ServiceRepo.ListServices(ctx, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		if err := ignoreNotFound(c.SvcOrchestrator.OrchestrateServiceDeletion(ctx, namespaceName, service.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DefaultNamespaceController) deleteVolumeClaims(ctx context.Context, namespaceName string) error {
+	volumeClaims, err := c.VolumeClaimRepo.ListPersistentVolumeClaims(ctx, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	for _, volumeClaim := range volumeClaims {
+		if err := ignoreNotFound(c.VolumeClaimOrchestrator.OrchestratePersistentVolumeClaimDeletion(ctx, namespaceName, volumeClaim.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Objects deleted concurrently are gone either way
+func ignoreNotFound(err error) error {
+	var errNotFound *shared.ErrNotFound
+	if errors.As(err, &errNotFound) {
+		return nil
+	}
+	return err
+}
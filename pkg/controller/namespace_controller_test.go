@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNamespaceController(ctrl *gomock.Controller) (*DefaultNamespaceController, *mocks.MockNamespaceRepository) {
+	mockRepo := mocks.NewMockNamespaceRepository(ctrl)
+	controller := &DefaultNamespaceController{
+		Repo:                    mockRepo,
+		DeploymentRepo:          mocks.NewMockDeploymentRepository(ctrl),
+		RevisionRepo:            mocks.NewMockDeploymentRevisionRepository(ctrl),
+		PodRepo:                 mocks.NewMockPodRepository(ctrl),
+		ServiceRepo:             mocks.NewMockServiceRepository(ctrl),
+		VolumeClaimRepo:         mocks.NewMockPersistentVolumeClaimRepository(ctrl),
+		PodOrchestrator:         mocks.NewMockPodOrchestrator(ctrl),
+		SvcOrchestrator:         mocks.NewMockServiceOrchestrator(ctrl),
+		VolumeClaimOrchestrator: mocks.NewMockPersistentVolumeClaimOrchestrator(ctrl),
+	}
+	return controller, mockRepo
+}
+
+func TestValidateNamespace(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	controller, mockRepo := newTestNamespaceController(ctrl)
+
+	mockRepo.EXPECT().GetNamespaceByName(gomock.Any(), "team-a").Return(&shared.Namespace{ObjectMeta: shared.ObjectMeta{Name: "team-a"}}, nil)
+	mockRepo.EXPECT().GetNamespaceByName(gomock.Any(), "old").Return(&shared.Namespace{ObjectMeta: shared.ObjectMeta{Name: "old"}, Phase: shared.NamespaceTerminating}, nil)
+	mockRepo.EXPECT().GetNamespaceByName(gomock.Any(), "missing").Return(nil, &shared.ErrNotFound{ID: "missing"})
+
+	// Act
+	errActive := controller.ValidateNamespace(context.Background(), "team-a")
+	errTerminating := controller.ValidateNamespace(context.Background(), "old")
+	errMissing := controller.ValidateNamespace(context.Background(), "missing")
+
+	// Assert
+	assert.NoError(t, errActive)
+	var errNamespaceTerminating *shared.ErrNamespaceTerminating
+	assert.ErrorAs(t, errTerminating, &errNamespaceTerminating)
+	var errNotFound *shared.ErrNotFound
+	assert.ErrorAs(t, errMissing, &errNotFound)
+	assert.Equal(t, shared.NamespaceResource, errNotFound.ResourceType)
+}
+
+func TestEnsureDefaultNamespaceCreatesItOnce(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	controller, mockRepo := newTestNamespaceController(ctrl)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetNamespaceByName(gomock.Any(), shared.DefaultNamespace).Return(nil, &shared.ErrNotFound{}),
+		mockRepo.EXPECT().GetNamespaceByName(gomock.Any(), shared.DefaultNamespace).Return(&shared.Namespace{ObjectMeta: shared.ObjectMeta{Name: shared.DefaultNamespace}}, nil),
+	)
+	mockRepo.EXPECT().CreateNamespace(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, namespace *shared.Namespace) error {
+		assert.Equal(t, shared.DefaultNamespace, namespace.Name)
+		assert.Equal(t, shared.NamespaceActive, namespace.Phase)
+		return nil
+	})
+
+	// Act
+	errFirst := controller.EnsureDefaultNamespace(context.Background())
+	errSecond := controller.EnsureDefaultNamespace(context.Background())
+
+	// Assert
+	assert.NoError(t, errFirst)
+	assert.NoError(t, errSecond)
+}
+
+func TestHandleNamespaceDeletionDeletesContents(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	controller, mockRepo := newTestNamespaceController(ctrl)
+	mockDeploymentRepo := controller.DeploymentRepo.(*mocks.MockDeploymentRepository)
+	mockRevisionRepo := controller.RevisionRepo.(*mocks.MockDeploymentRevisionRepository)
+	mockPodRepo := controller.PodRepo.(*mocks.MockPodRepository)
+	mockServiceRepo := controller.ServiceRepo.(*mocks.MockServiceRepository)
+	mockVolumeClaimRepo := controller.VolumeClaimRepo.(*mocks.MockPersistentVolumeClaimRepository)
+	mockPodOrch := controller.PodOrchestrator.(*mocks.MockPodOrchestrator)
+	mockSvcOrch := controller.SvcOrchestrator.(*mocks.MockServiceOrchestrator)
+	mockVolumeClaimOrch := controller.VolumeClaimOrchestrator.(*mocks.MockPersistentVolumeClaimOrchestrator)
+
+	mockRepo.EXPECT().GetNamespaceByName(gomock.Any(), "team-a").Return(&shared.Namespace{ObjectMeta: shared.ObjectMeta{Name: "team-a"}}, nil)
+	mockRepo.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, namespace *shared.Namespace) error {
+		assert.Equal(t, shared.NamespaceTerminating, namespace.Phase)
+		return nil
+	})
+	gomock.InOrder(
+		mockDeploymentRepo.EXPECT().ListDeployments(gomock.Any(), "team-a").Return([]shared.Deployment{{ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "team-a"}}}, nil),
+		mockDeploymentRepo.EXPECT().DeleteDeployment(gomock.Any(), "team-a", "web").Return(nil),
+		mockPodRepo.EXPECT().ListPods(gomock.Any(), "team-a").Return([]shared.Pod{{ID: "pod-1", ObjectMeta: shared.ObjectMeta{Namespace: "team-a"}}}, nil),
+		mockPodOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Return(&shared.ErrNotFound{}), // Deleted concurrently
+		mockServiceRepo.EXPECT().ListServices(gomock.Any(), "team-a").Return([]shared.Service{{ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "team-a"}}}, nil),
+		mockSvcOrch.EXPECT().OrchestrateServiceDeletion(gomock.Any(), "team-a", "web").Return(nil),
+		mockVolumeClaimRepo.EXPECT().ListPersistentVolumeClaims(gomock.Any(), "team-a").Return([]shared.PersistentVolumeClaim{{ID: "claim-1", ObjectMeta: shared.ObjectMeta{Namespace: "team-a"}}}, nil),
+		mockVolumeClaimOrch.EXPECT().OrchestratePersistentVolumeClaimDeletion(gomock.Any(), "team-a", "claim-1").Return(nil),
+		mockRepo.EXPECT().DeleteNamespace(gomock.Any(), "team-a").Return(nil),
+	)
+	mockRevisionRepo.EXPECT().DeleteRevisions(gomock.Any(), "team-a", "web").Return(nil)
+
+	// Act
+	err := controller.HandleNamespaceDeletion(context.Background(), "team-a")
+
+	// Assert
+	assert.NoError(t, err)
+}
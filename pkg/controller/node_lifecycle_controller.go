@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/orchestrator"
+	"maden/pkg/shared"
+
+	"context"
+	"os"
+	"time"
+)
+
+const (
+	DefaultNodeMonitorInterval     = 5 * time.Second
+	DefaultNodeNotReadyGracePeriod = 40 * time.Second
+	DefaultNodeOfflineGracePeriod  = 5 * time.Minute
+)
+
+// Grace periods are measured from the last heartbeat of the node agent
+type NodeLifecycleConfig struct {
+	MonitorInterval     time.Duration
+	NotReadyGracePeriod time.Duration
+	OfflineGracePeriod  time.Duration
+}
+
+// Reads the grace periods from NODE_NOT_READY_GRACE_PERIOD and NODE_OFFLINE_GRACE_PERIOD (e.g. "90s"), if set
+func NewNodeLifecycleConfig() NodeLifecycleConfig {
+	return NodeLifecycleConfig{
+		MonitorInterval:     DefaultNodeMonitorInterval,
+		NotReadyGracePeriod: getDurationFromEnv("NODE_NOT_READY_GRACE_PERIOD", DefaultNodeNotReadyGracePeriod),
+		OfflineGracePeriod:  getDurationFromEnv("NODE_OFFLINE_GRACE_PERIOD", DefaultNodeOfflineGracePeriod),
+	}
+}
+
+func getDurationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		shared.Log.Errorf("Invalid duration %q for %s, using %v", value, key, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// Component responsible for tracking the heartbeats of node agents. Nodes whose agent stops heartbeating
+// go from Ready to NotReady (no new pods are scheduled onto them) and then to Offline, at which point
+// their pods are evicted so that the deployment reconciler recreates them on other nodes.
+type DefaultNodeLifecycleController struct {
+	NodeRepo     etcd.NodeRepository
+	LeaseRepo    etcd.NodeLeaseRepository
+	PodRepo      etcd.PodRepository
+	Orchestrator orchestrator.PodOrchestrator
+	Reconciler   DeploymentReconciler
+	Config       NodeLifecycleConfig
+
+	firstSeen map[string]time.Time // Nodes without a lease yet get a grace period from when they were first seen
+	now       func() time.Time
+}
+
+func NewDefaultNodeLifecycleController(
+	nodeRepo etcd.NodeRepository,
+	leaseRepo etcd.NodeLeaseRepository,
+	podRepo etcd.PodRepository,
+	orchestrator orchestrator.PodOrchestrator,
+	reconciler DeploymentReconciler,
+	config NodeLifecycleConfig,
+) NodeLifecycleController {
+	return &DefaultNodeLifecycleController{
+		NodeRepo:     nodeRepo,
+		LeaseRepo:    leaseRepo,
+		PodRepo:      podRepo,
+		Orchestrator: orchestrator,
+		Reconciler:   reconciler,
+		Config:       config,
+		firstSeen:    make(map[string]time.Time),
+		now:          time.Now,
+	}
+}
+
+func (c *DefaultNodeLifecycleController) Run(ctx context.Context) {
+	shared.Log.Infof("Starting node lifecycle controller...")
+
+	ticker := time.NewTicker(c.Config.MonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.monitorNodes(ctx); err != nil {
+				shared.Log.Errorf("Failed to monitor nodes: %v", err)
+			}
+		}
+	}
+}
+
+func (c *DefaultNodeLifecycleController) monitorNodes(ctx context.Context) error {
+	nodes, err := c.NodeRepo.ListNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	leases, err := c.LeaseRepo.ListNodeLeases(ctx)
+	if err != nil {
+		return err
+	}
+
+	renewTimes := make(map[string]time.Time)
+	for _, lease := range leases {
+		renewTimes[lease.NodeID] = lease.RenewTime
+	}
+
+	for _, node := range nodes {
+		// Nodes registered by hand have no agent to heartbeat for them
+		if !node.AgentManaged {
+			continue
+		}
+
+		if err := c.updateNodeStatus(ctx, node, renewTimes); err != nil {
+			shared.Log.Errorf("Failed to update status of node %s: %v", node.ID, err)
+		}
+	}
+	return nil
+}
+
+func (c *DefaultNodeLifecycleController) updateNodeStatus(ctx context.Context, node shared.Node, renewTimes map[string]time.Time) error {
+	lastHeartbeat, ok := renewTimes[node.ID]
+	if !ok {
+		if _, seen := c.firstSeen[node.ID]; !seen {
+			c.firstSeen[node.ID] = c.now()
+		}
+		lastHeartbeat = c.firstSeen[node.ID]
+	} else {
+		delete(c.firstSeen, node.ID)
+	}
+
+	status := c.getStatusForHeartbeat(lastHeartbeat)
+	if status == shared.NodeOffline {
+		// Evicting again on every pass also catches pods that were scheduled before the node went Offline
+		if err := c.evictPods(ctx, &node); err != nil {
+			return err
+		}
+	}
+	if status == node.Status {
+		return nil
+	}
+
+	shared.Log.Infof("Node %s changed status from %s to %s", node.ID, node.Status, status)
+	// Evictions and the scheduler update the node as well, so the status is written onto its latest state
+	return shared.RetryOnConflict(func() error {
+		latestNode, err := c.NodeRepo.GetNodeByID(ctx, node.ID)
+		if err != nil {
+			return err
+		}
+		latestNode.Status = status
+		return c.NodeRepo.UpdateNode(ctx, latestNode)
+	})
+}
+
+func (c *DefaultNodeLifecycleController) getStatusForHeartbeat(lastHeartbeat time.Time) shared.NodeStatus {
+	elapsed := c.now().Sub(lastHeartbeat)
+	switch {
+	case elapsed >= c.Config.OfflineGracePeriod:
+		return shared.NodeOffline
+	case elapsed >= c.Config.NotReadyGracePeriod:
+		return shared.NodeNotReady
+	default:
+		return shared.NodeReady
+	}
+}
+
+// Deletes the pods of the node, which releases the resources they held
+func (c *DefaultNodeLifecycleController) evictPods(ctx context.Context, node *shared.Node) error {
+	pods, err := c.PodRepo.ListPods(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		if pod.NodeID != node.ID {
+			continue
+		}
+
+		shared.Log.Infof("Evicting pod %s from offline node %s", pod.ID, node.ID)
+		if err := c.Orchestrator.OrchestratePodDeletion(ctx, &pod); err != nil {
+			return err
+		}
+
+		// The deployment managing the pod replaces it on another node
+		if owner, ok := pod.ControllerReference(); ok && owner.Kind == shared.DeploymentResource.String() {
+			c.Reconciler.Enqueue(pod.Namespace, owner.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNodeLifecycleConfig = NodeLifecycleConfig{
+	MonitorInterval:     time.Second,
+	NotReadyGracePeriod: 40 * time.Second,
+	OfflineGracePeriod:  5 * time.Minute,
+}
+
+func newTestNodeLifecycleController(ctrl *gomock.Controller, now time.Time) (*DefaultNodeLifecycleController, *mocks.MockNodeRepository, *mocks.MockNodeLeaseRepository, *mocks.MockPodRepository, *mocks.MockPodOrchestrator, *mocks.MockDeploymentReconciler) {
+	mockNodeRepo := mocks.NewMockNodeRepository(ctrl)
+	mockLeaseRepo := mocks.NewMockNodeLeaseRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockOrch := mocks.NewMockPodOrchestrator(ctrl)
+	mockReconciler := mocks.NewMockDeploymentReconciler(ctrl)
+
+	controller := NewDefaultNodeLifecycleController(mockNodeRepo, mockLeaseRepo, mockPodRepo, mockOrch, mockReconciler, testNodeLifecycleConfig).(*DefaultNodeLifecycleController)
+	controller.now = func() time.Time { return now }
+	return controller, mockNodeRepo, mockLeaseRepo, mockPodRepo, mockOrch, mockReconciler
+}
+
+func TestNodeLifecycleControllerTransitions(t *testing.T) {
+	tests := []struct {
+		name           string
+		heartbeatAge   time.Duration
+		currentStatus  shared.NodeStatus
+		expectedStatus shared.NodeStatus
+	}{
+		{"recent heartbeat marks node Ready", 10 * time.Second, shared.NodeNotReady, shared.NodeReady},
+		{"missed heartbeats mark node NotReady", time.Minute, shared.NodeReady, shared.NodeNotReady},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			now := time.Now()
+			controller, mockNodeRepo, mockLeaseRepo, _, _, _ := newTestNodeLifecycleController(ctrl, now)
+
+			nodes := []shared.Node{{ID: "node-1", Status: test.currentStatus, AgentManaged: true}}
+			leases := []shared.NodeLease{{NodeID: "node-1", RenewTime: now.Add(-test.heartbeatAge)}}
+
+			mockNodeRepo.EXPECT().ListNodes(gomock.Any()).Return(nodes, nil)
+			mockLeaseRepo.EXPECT().ListNodeLeases(gomock.Any()).Return(leases, nil)
+			mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node-1").Return(&nodes[0], nil)
+			mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+				assert.Equal(t, test.expectedStatus, node.Status)
+				return nil
+			})
+
+			// Act
+			err := controller.monitorNodes(context.Background())
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNodeLifecycleControllerEvictsPodsFromOfflineNode(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	controller, mockNodeRepo, mockLeaseRepo, mockPodRepo, mockOrch, mockReconciler := newTestNodeLifecycleController(ctrl, now)
+
+	nodes := []shared.Node{
+		{ID: "node-1", Status: shared.NodeNotReady, AgentManaged: true, Used: shared.Resources{CPU: 3, Memory: 768}},
+		{ID: "manual-node", Status: shared.NodeReady}, // No agent, left alone
+	}
+	leases := []shared.NodeLease{{NodeID: "node-1", RenewTime: now.Add(-10 * time.Minute)}}
+	owners := []shared.OwnerReference{{Kind: shared.DeploymentResource.String(), Name: "web", UID: "dep-uid", Controller: true}}
+	pods := []shared.Pod{
+		{ID: "pod-1", ObjectMeta: shared.ObjectMeta{Name: "web-1", Namespace: "default", OwnerReferences: owners}, DeploymentID: "dep-1", NodeID: "node-1", Resources: shared.Resources{CPU: 2, Memory: 512}},
+		{ID: "pod-2", ObjectMeta: shared.ObjectMeta{Name: "web-2", Namespace: "default", OwnerReferences: owners}, DeploymentID: "dep-1", NodeID: "manual-node"},
+		{ID: "pod-3", ObjectMeta: shared.ObjectMeta{Name: "standalone", Namespace: "default"}, NodeID: "node-1"}, // Nothing replaces it
+	}
+
+	mockNodeRepo.EXPECT().ListNodes(gomock.Any()).Return(nodes, nil)
+	mockLeaseRepo.EXPECT().ListNodeLeases(gomock.Any()).Return(leases, nil)
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "").Return(pods, nil)
+	mockOrch.EXPECT().OrchestratePodDeletion(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		assert.Equal(t, "node-1", pod.NodeID)
+		return nil
+	})
+	mockReconciler.EXPECT().Enqueue("default", "web").Times(1) // The deployment, not the pod
+	// The deletion released the resources of the pod in the meantime, which the status update must keep
+	releasedNode := shared.Node{ID: "node-1", Status: shared.NodeNotReady, AgentManaged: true, Used: shared.Resources{CPU: 1, Memory: 256}}
+	mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node-1").Return(&releasedNode, nil)
+	mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+		assert.Equal(t, shared.NodeOffline, node.Status)
+		assert.Equal(t, shared.Resources{CPU: 1, Memory: 256}, node.Used)
+		return nil
+	})
+
+	// Act
+	err := controller.monitorNodes(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestNodeLifecycleControllerGracePeriodForNodesWithoutLease(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	controller, mockNodeRepo, mockLeaseRepo, _, _, _ := newTestNodeLifecycleController(ctrl, now)
+
+	nodes := []shared.Node{{ID: "node-1", Status: shared.NodeReady, AgentManaged: true}}
+	mockNodeRepo.EXPECT().ListNodes(gomock.Any()).Return(nodes, nil).Times(2)
+	mockLeaseRepo.EXPECT().ListNodeLeases(gomock.Any()).Return([]shared.NodeLease{}, nil).Times(2)
+	mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node-1").Return(&nodes[0], nil)
+	mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+		assert.Equal(t, shared.NodeNotReady, node.Status)
+		return nil
+	})
+
+	// Act
+	errFirst := controller.monitorNodes(context.Background()) // Still within the grace period
+	controller.now = func() time.Time { return now.Add(time.Minute) }
+	errSecond := controller.monitorNodes(context.Background())
+
+	// Assert
+	assert.NoError(t, errFirst)
+	assert.NoError(t, errSecond)
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"maden/pkg/scheduler"
+	"maden/pkg/shared"
+
+	"encoding/json"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// Component responsible for waking up the scheduling queue whenever the cluster gains capacity
+type DefaultNodeUpdaterController struct {
+	SchedulingQueue scheduler.SchedulingQueue
+}
+
+func NewDefaultNodeUpdaterController(schedulingQueue scheduler.SchedulingQueue) NodeUpdaterController {
+	return &DefaultNodeUpdaterController{SchedulingQueue: schedulingQueue}
+}
+
+func (c *DefaultNodeUpdaterController) HandleNodeCreate(kv *mvccpb.KeyValue) {
+	var node shared.Node
+	if err := json.Unmarshal(kv.Value, &node); err != nil {
+		shared.Log.Errorf("Failed to unmarshal node: %v", err)
+		return
+	}
+
+	shared.Log.Infof("Node %s added, retrying pending pods", node.ID)
+	c.SchedulingQueue.MoveAllToActive()
+}
+
+func (c *DefaultNodeUpdaterController) HandleNodeUpdate(oldKv *mvccpb.KeyValue, newKv *mvccpb.KeyValue) {
+	var oldNode shared.Node
+	if err := json.Unmarshal(oldKv.Value, &oldNode); err != nil {
+		shared.Log.Errorf("Failed to unmarshal old node: %v", err)
+		return
+	}
+
+	var newNode shared.Node
+	if err := json.Unmarshal(newKv.Value, &newNode); err != nil {
+		shared.Log.Errorf("Failed to unmarshal new node: %v", err)
+		return
+	}
+
+	if !hasGainedCapacity(oldNode, newNode) {
+		return
+	}
+	c.SchedulingQueue.MoveAllToActive()
+}
+
+// A node gains capacity when it becomes ready, grows, or frees up resources (e.g. a pod was deleted)
+func hasGainedCapacity(oldNode shared.Node, newNode shared.Node) bool {
+	if newNode.Status != shared.NodeReady {
+		return false
+	}
+	if oldNode.Status != shared.NodeReady {
+		return true
+	}
+
+	oldAvailable := shared.Resources{
+		CPU:    oldNode.Capacity.CPU - oldNode.Used.CPU,
+		Memory: oldNode.Capacity.Memory - oldNode.Used.Memory,
+	}
+	newAvailable := shared.Resources{
+		CPU:    newNode.Capacity.CPU - newNode.Used.CPU,
+		Memory: newNode.Capacity.Memory - newNode.Used.Memory,
+	}
+	return newAvailable.CPU > oldAvailable.CPU || newAvailable.Memory > oldAvailable.Memory
+}
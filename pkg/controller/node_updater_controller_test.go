@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/golang/mock/gomock"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestHandleNodeCreateWakesSchedulingQueue(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueue := mocks.NewMockSchedulingQueue(ctrl)
+	controller := NewDefaultNodeUpdaterController(mockQueue)
+
+	mockQueue.EXPECT().MoveAllToActive().Times(1)
+
+	// Act
+	controller.HandleNodeCreate(&mvccpb.KeyValue{Value: []byte(`{"id":"node-1","status":"Ready"}`)})
+}
+
+func TestHasGainedCapacity(t *testing.T) {
+	capacity := shared.Resources{CPU: 4, Memory: 4096}
+	tests := []struct {
+		name     string
+		oldNode  shared.Node
+		newNode  shared.Node
+		expected bool
+	}{
+		{
+			name:     "resources released",
+			oldNode:  shared.Node{Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 4, Memory: 1024}},
+			newNode:  shared.Node{Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 2, Memory: 1024}},
+			expected: true,
+		},
+		{
+			name:     "resources taken",
+			oldNode:  shared.Node{Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 2, Memory: 1024}},
+			newNode:  shared.Node{Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 3, Memory: 2048}},
+			expected: false,
+		},
+		{
+			name:     "node became ready",
+			oldNode:  shared.Node{Status: shared.NodeNotReady, Capacity: capacity},
+			newNode:  shared.Node{Status: shared.NodeReady, Capacity: capacity},
+			expected: true,
+		},
+		{
+			name:     "node went offline",
+			oldNode:  shared.Node{Status: shared.NodeNotReady, Capacity: capacity, Used: capacity},
+			newNode:  shared.Node{Status: shared.NodeOffline, Capacity: capacity},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, hasGainedCapacity(test.oldNode, test.newNode))
+		})
+	}
+}
@@ -3,6 +3,8 @@ package controller
 import (
 	"maden/pkg/orchestrator"
 	"maden/pkg/shared"
+
+	"context"
 )
 
 type DefaultPersistentVolumeClaimController struct {
@@ -13,6 +15,6 @@ func NewDefaultPersistentVolumeClaimController(orchestrator orchestrator.Persist
 	return &DefaultPersistentVolumeClaimController{Orchestrator: orchestrator}
 }
 
-func (po *DefaultPersistentVolumeClaimController) HandleIncomingPersistentVolumeClaim(volumeClaimSpec shared.PersistentVolumeClaimSpec) error {
-	return po.Orchestrator.OrchestratePersistentVolumeClaimCreation(&volumeClaimSpec)
+func (po *DefaultPersistentVolumeClaimController) HandleIncomingPersistentVolumeClaim(ctx context.Context, volumeClaimSpec shared.PersistentVolumeClaimSpec) error {
+	return po.Orchestrator.OrchestratePersistentVolumeClaimCreation(ctx, &volumeClaimSpec)
 }
@@ -3,6 +3,8 @@ package controller
 import (
 	"maden/pkg/orchestrator"
 	"maden/pkg/shared"
+
+	"context"
 )
 
 type DefaultPersistentVolumeController struct {
@@ -13,6 +15,6 @@ func NewDefaultPersistentVolumeController(orchestrator orchestrator.PersistentVo
 	return &DefaultPersistentVolumeController{Orchestrator: orchestrator}
 }
 
-func (po *DefaultPersistentVolumeController) HandleIncomingPersistentVolume(volumeSpec shared.PersistentVolumeSpec) error {
-	return po.Orchestrator.OrchestratePersistentVolumeCreation(&volumeSpec)
+func (po *DefaultPersistentVolumeController) HandleIncomingPersistentVolume(ctx context.Context, volumeSpec shared.PersistentVolumeSpec) error {
+	return po.Orchestrator.OrchestratePersistentVolumeCreation(ctx, &volumeSpec)
 }
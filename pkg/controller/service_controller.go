@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"maden/pkg/etcd"
 	"maden/pkg/shared"
 	"maden/pkg/orchestrator"
@@ -20,14 +21,15 @@ func NewDefaultServiceController(
 	return &DefaultServiceController{Repo: repo, SvcOrchestrator: svcOrchestrator}
 }
 
-func (c *DefaultServiceController) HandleIncomingService(serviceSpec shared.ServiceSpec) error {
-	existingService, err := c.Repo.GetServiceByName(serviceSpec.Name)
+func (c *DefaultServiceController) HandleIncomingService(ctx context.Context, serviceSpec shared.ServiceSpec) error {
+	serviceSpec.Namespace = shared.NamespaceOrDefault(serviceSpec.Namespace)
+	existingService, err := c.Repo.GetServiceByName(ctx, serviceSpec.Namespace, serviceSpec.Name)
 	if err != nil {
-		return c.SvcOrchestrator.OrchestrateServiceCreation(serviceSpec)
+		return c.SvcOrchestrator.OrchestrateServiceCreation(ctx, serviceSpec)
 	}
 
 	if existingService != nil && needsServiceUpdate(serviceSpec, existingService) {
-		c.SvcOrchestrator.OrchestrateServiceUpdate(*existingService, serviceSpec)
+		return c.SvcOrchestrator.OrchestrateServiceUpdate(ctx, *existingService, serviceSpec)
 	}
 
 	fmt.Println("No update required for service: ", serviceSpec.Name)
@@ -35,9 +37,12 @@ func (c *DefaultServiceController) HandleIncomingService(serviceSpec shared.Serv
 }
 
 
+// Specs without an IP keep the one the service has
 func needsServiceUpdate(spec shared.ServiceSpec, existing *shared.Service) bool {
 	return !areMapsEqual(spec.Selector, existing.Selector) || 
-	!arePortsEqual(spec.Ports, existing.Ports)
+	!arePortsEqual(spec.Ports, existing.Ports) ||
+	(spec.IP != "" && spec.IP != existing.IP) ||
+	shared.IsMetadataUpdated(existing.ObjectMeta, spec.ObjectMeta)
 }
 
 
@@ -59,7 +64,7 @@ func arePortsEqual(a, b []shared.ServicePort) bool {
         return false
     }
     for i := range a {
-        if a[i].Port != b[i].Port || a[i].TargetPort != b[i].TargetPort {
+        if a[i] != b[i] {
             return false
         }
     }
@@ -4,6 +4,8 @@ import (
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -21,44 +23,61 @@ func TestHandleIncomingService(t *testing.T) {
 	serviceController := NewDefaultServiceController(mockRepo, mockOrchestrator)
 	
 	serviceSpec := shared.ServiceSpec{
-		Name: "test-service",
+		ObjectMeta: shared.ObjectMeta{Name: "test-service", Namespace: "default"},
 		Selector: map[string]string{"app": "myapp"},
 		Ports: []shared.ServicePort{{Port: 80, TargetPort: 8080}},
 	}
 
 	t.Run("Service Creation", func(t *testing.T) {
 		notFoundErr := &shared.ErrNotFound{Name: serviceSpec.Name, ResourceType: shared.ServiceResource}
-        mockRepo.EXPECT().GetServiceByName(gomock.Eq(serviceSpec.Name)).Return(nil, notFoundErr)
-        mockOrchestrator.EXPECT().OrchestrateServiceCreation(serviceSpec).Return(nil)
+        mockRepo.EXPECT().GetServiceByName(gomock.Any(), "default", gomock.Eq(serviceSpec.Name)).Return(nil, notFoundErr)
+        mockOrchestrator.EXPECT().OrchestrateServiceCreation(gomock.Any(), serviceSpec).Return(nil)
 
 // Act
-		err := serviceController.HandleIncomingService(serviceSpec)
+		err := serviceController.HandleIncomingService(context.Background(), serviceSpec)
 		assert.NoError(t, err)
 	})
 
 	t.Run("Service Update", func(t *testing.T) {
 		existingService := &shared.Service{
-			Name: serviceSpec.Name,
+			ObjectMeta: shared.ObjectMeta{Name: serviceSpec.Name},
 			Selector: map[string]string{"app": "old"},
 			Ports: []shared.ServicePort{{Port: 80, TargetPort: 8081}},
 		}
 	
-// Assert	mockRepo.EXPECT().GetServiceByName(serviceSpec.Name).Return(existingService, nil)
-		mockOrchestrator.EXPECT().OrchestrateServiceUpdate(*existingService, serviceSpec).Return(nil)
+		mockRepo.EXPECT().GetServiceByName(gomock.Any(), "default", serviceSpec.Name).Return(existingService, nil)
+		mockOrchestrator.EXPECT().OrchestrateServiceUpdate(gomock.Any(), *existingService, serviceSpec).Return(nil)
 
-		err := serviceController.HandleIncomingService(serviceSpec)
+		err := serviceController.HandleIncomingService(context.Background(), serviceSpec)
 		assert.NoError(t, err)
 	})
 
+	t.Run("IP Change Rejected", func(t *testing.T) {
+		existingService := &shared.Service{
+			ObjectMeta: shared.ObjectMeta{Name: serviceSpec.Name},
+			Selector: serviceSpec.Selector,
+			Ports: serviceSpec.Ports,
+			IP: "10.96.0.10",
+		}
+		changedSpec := serviceSpec
+		changedSpec.IP = "10.96.0.20"
+		ipChangeErr := errors.New("the IP of service test-service cannot be changed from 10.96.0.10 to 10.96.0.20")
+		mockRepo.EXPECT().GetServiceByName(gomock.Any(), "default", serviceSpec.Name).Return(existingService, nil)
+		mockOrchestrator.EXPECT().OrchestrateServiceUpdate(gomock.Any(), *existingService, changedSpec).Return(ipChangeErr)
+
+		err := serviceController.HandleIncomingService(context.Background(), changedSpec)
+		assert.ErrorIs(t, err, ipChangeErr)
+	})
+
 	t.Run("No Update Required", func(t *testing.T) {
 		existingService := &shared.Service{
-			Name: serviceSpec.Name,
+			ObjectMeta: shared.ObjectMeta{Name: serviceSpec.Name},
 			Selector: serviceSpec.Selector,
 			Ports: serviceSpec.Ports,
 		}
-		mockRepo.EXPECT().GetServiceByName(serviceSpec.Name).Return(existingService, nil)
+		mockRepo.EXPECT().GetServiceByName(gomock.Any(), "default", serviceSpec.Name).Return(existingService, nil)
 
-		err := serviceController.HandleIncomingService(serviceSpec)
+		err := serviceController.HandleIncomingService(context.Background(), serviceSpec)
 		assert.NoError(t, err)
 	})
 }
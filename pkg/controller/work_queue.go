@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduplicating queue of resource keys, processed by the reconcilers.
+// A key is never handed out to two workers at once: if it is added while being processed,
+// it is queued again once the current worker calls Done.
+type WorkQueue struct {
+	mutex        sync.Mutex
+	cond         *sync.Cond
+	queue        []string
+	dirty        map[string]bool
+	processing   map[string]bool
+	shuttingDown bool
+}
+
+func NewWorkQueue() *WorkQueue {
+	q := &WorkQueue{
+		queue:      make([]string, 0),
+		dirty:      make(map[string]bool),
+		processing: make(map[string]bool),
+	}
+	q.cond = sync.NewCond(&q.mutex)
+	return q
+}
+
+func (q *WorkQueue) Add(key string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.shuttingDown || q.dirty[key] {
+		return
+	}
+	q.dirty[key] = true
+	if q.processing[key] {
+		return
+	}
+
+	q.queue = append(q.queue, key)
+	q.cond.Signal()
+}
+
+func (q *WorkQueue) AddAfter(key string, delay time.Duration) {
+	if delay <= 0 {
+		q.Add(key)
+		return
+	}
+	time.AfterFunc(delay, func() {
+		q.Add(key)
+	})
+}
+
+// Blocks until a key is available. The second return value is true once the queue is shut down
+func (q *WorkQueue) Get() (string, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for len(q.queue) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.queue) == 0 {
+		return "", true
+	}
+
+	key := q.queue[0]
+	q.queue = q.queue[1:]
+	q.processing[key] = true
+	delete(q.dirty, key)
+
+	return key, false
+}
+
+func (q *WorkQueue) Done(key string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delete(q.processing, key)
+	if q.dirty[key] {
+		q.queue = append(q.queue, key)
+		q.cond.Signal()
+	}
+}
+
+func (q *WorkQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.queue)
+}
+
+func (q *WorkQueue) ShutDown() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
@@ -2,119 +2,43 @@ package etcd
 
 import (
 	"maden/pkg/shared"
-	
-	"context"
-	"encoding/json"
-	"time"
 
-	clientv3 "go.etcd.io/etcd/client/v3"
+	"context"
 )
 
 var deploymentsKey = "deployments/"
 
 type EtcdDeploymentRepository struct {
-	client EtcdClient
-	transactioner Transactioner
+	store *Store[shared.Deployment]
 }
 
 func NewEtcdDeploymentRepository(
 	client EtcdClient,
-	transactioner Transactioner,	
+	transactioner Transactioner,
 ) DeploymentRepository {
-	return &EtcdDeploymentRepository{client: client, transactioner: transactioner}
+	store := NewStore(client, transactioner, deploymentsKey, shared.DeploymentResource,
+		func(deployment *shared.Deployment) string { return ObjectKey(deployment.Namespace, deployment.Name) },
+		func(deployment *shared.Deployment) *int64 { return &deployment.ResourceVersion },
+	)
+	return &EtcdDeploymentRepository{store: store}
 }
 
-
-func (repo *EtcdDeploymentRepository) ListDeployments() ([]shared.Deployment, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	resp, err := repo.client.Get(ctx, deploymentsKey, clientv3.WithPrefix())
-	if err != nil {
-		return nil, err
-	}
-
-	deployments := make([]shared.Deployment, 0)
-	for _, kv := range resp.Kvs {
-		var deployment shared.Deployment
-		if err := json.Unmarshal(kv.Value, &deployment); err != nil {
-			return nil, err
-		}
-		deployments = append(deployments, deployment)
-	}
-	return deployments, nil
+func (repo *EtcdDeploymentRepository) ListDeployments(ctx context.Context, namespace string) ([]shared.Deployment, error) {
+	return repo.store.ListWithPrefix(ctx, namespacePrefix(namespace))
 }
 
-func (repo *EtcdDeploymentRepository) GetDeploymentByName(name string) (*shared.Deployment, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-    defer cancel()
-
-	key := deploymentsKey + name
-	resp, err := repo.client.Get(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if len(resp.Kvs) == 0 {
-		return nil, &shared.ErrNotFound{Name: name, ResourceType: shared.DeploymentResource}
-	}
-
-	var deployment shared.Deployment
-	if err := json.Unmarshal(resp.Kvs[0].Value, &deployment); err != nil {
-		return nil, err
-	}
-
-	return &deployment, nil
-} 
-
-func (repo *EtcdDeploymentRepository) CreateDeployment(deployment *shared.Deployment) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-    defer cancel()
-
-    deploymentData, err := json.Marshal(deployment)
-    if err != nil {
-        return err
-    }
-
-	key := deploymentsKey + deployment.Name
-
-	return repo.transactioner.PerformTransaction(ctx, key, string(deploymentData), shared.DeploymentResource)
+func (repo *EtcdDeploymentRepository) GetDeploymentByName(ctx context.Context, namespace string, name string) (*shared.Deployment, error) {
+	return repo.store.Get(ctx, ObjectKey(namespace, name))
 }
 
-func (repo *EtcdDeploymentRepository) UpdateDeployment(deployment *shared.Deployment) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-    defer cancel()
-
-    deploymentData, err := json.Marshal(deployment)
-    if err != nil {
-        return err
-    }
-
-    key := deploymentsKey + deployment.Name
-
-    resp, err := repo.client.Put(ctx, key, string(deploymentData), clientv3.WithPrevKV())
-    if err != nil {
-        return err
-    }
-	
-	if resp.PrevKv == nil {
-		return &shared.ErrNotFound{ID: deployment.Name, ResourceType: shared.DeploymentResource}
-	}
-    return nil
+func (repo *EtcdDeploymentRepository) CreateDeployment(ctx context.Context, deployment *shared.Deployment) error {
+	return repo.store.Create(ctx, deployment)
 }
 
-func (repo *EtcdDeploymentRepository) DeleteDeployment(deploymentName string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	key := deploymentsKey + deploymentName
-
-	resp, err := repo.client.Delete(ctx, key)
-	if err != nil {
-		return err
-	}
+func (repo *EtcdDeploymentRepository) UpdateDeployment(ctx context.Context, deployment *shared.Deployment) error {
+	return repo.store.Update(ctx, deployment)
+}
 
-	if resp.Deleted == 0 {
-		return &shared.ErrNotFound{ID: deploymentName, ResourceType: shared.DeploymentResource}
-	}
-	return nil
-}
\ No newline at end of file
+func (repo *EtcdDeploymentRepository) DeleteDeployment(ctx context.Context, namespace string, deploymentName string) error {
+	return repo.store.Delete(ctx, ObjectKey(namespace, deploymentName))
+}
@@ -1,11 +1,13 @@
 package etcd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -23,18 +25,18 @@ func TestEtcdDeploymentRepositoryListDeployments(t *testing.T) {
     repo := NewEtcdDeploymentRepository(mockClient, mockTransactioner)
 
 	mockClient.EXPECT().
-		Get(gomock.Any(), deploymentsKey, gomock.Any()).
+		Get(gomock.Any(), deploymentsKey+"default/", gomock.Any()).
 		Return(&clientv3.GetResponse{
 			Kvs: []*mvccpb.KeyValue{
 				{
-					Key: []byte(deploymentsKey + "1"),
+					Key: []byte(deploymentsKey + "default/1"),
 					Value: []byte(`{"id": "1", "name": "test-deployment"}`),
 				},
 			},
 		}, nil).Times(1)
 
 	// Act
-	deployments, err := repo.ListDeployments()
+	deployments, err := repo.ListDeployments(context.Background(), "default")
 
 	// Assert
 	assert.NoError(t, err)
@@ -54,7 +56,7 @@ func TestEtcdDeploymentRepositoryCreateDeployment(t *testing.T) {
 
     deployment := &shared.Deployment{
         ID:   "1",
-        Name: "test-deployment",
+        ObjectMeta: shared.ObjectMeta{Name: "test-deployment", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     deploymentData, _ := json.Marshal(deployment)
@@ -62,9 +64,9 @@ func TestEtcdDeploymentRepositoryCreateDeployment(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(deploymentData), shared.DeploymentResource).
-        Return(nil).Times(1)
+        Return(int64(1), nil).Times(1)
 
-    err := repo.CreateDeployment(deployment)
+    err := repo.CreateDeployment(context.Background(), deployment)
     assert.NoError(t, err)
 }
 
@@ -78,7 +80,7 @@ func TestEtcdDeploymentRepositoryCreateDeploymentError(t *testing.T) {
 
     deployment := &shared.Deployment{
         ID:   "1",
-        Name: "test-deployment",
+        ObjectMeta: shared.ObjectMeta{Name: "test-deployment", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     deploymentData, _ := json.Marshal(deployment)
@@ -86,9 +88,9 @@ func TestEtcdDeploymentRepositoryCreateDeploymentError(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(deploymentData), shared.DeploymentResource).
-        Return(errors.New("transaction failed")).Times(1)
+        Return(int64(0), errors.New("transaction failed")).Times(1)
 
-    err := repo.CreateDeployment(deployment)
+    err := repo.CreateDeployment(context.Background(), deployment)
     assert.Error(t, err)
     assert.Equal(t, "transaction failed", err.Error())
 }
@@ -102,14 +104,14 @@ func TestEtcdDeploymentRepositoryUpdateDeployment(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdDeploymentRepository(mockClient, mockTransactioner)
 
-    deployment := &shared.Deployment{ID: "1", Name: "updated-deployment"}
+    deployment := &shared.Deployment{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-deployment"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), deploymentsKey + deployment.Name, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: &mvccpb.KeyValue{}}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), deploymentsKey + deployment.Name, gomock.Any(), int64(0), shared.DeploymentResource).
+        Return(int64(5), nil).Times(1)
 
     // Act
-    err := repo.UpdateDeployment(deployment)
+    err := repo.UpdateDeployment(context.Background(), deployment)
 
     // Assert
     assert.NoError(t, err)
@@ -123,13 +125,13 @@ func TestEtcdDeploymentRepositoryUpdateDeploymentErrorOnPut(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdDeploymentRepository(mockClient, mockTransactioner)
 
-    deployment := &shared.Deployment{ID: "1", Name: "updated-deployment"}
+    deployment := &shared.Deployment{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-deployment"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), deploymentsKey + deployment.Name, gomock.Any(), gomock.Any()).
-        Return(nil, errors.New("etcd put error")).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), deploymentsKey + deployment.Name, gomock.Any(), int64(0), shared.DeploymentResource).
+        Return(int64(0), errors.New("etcd put error")).Times(1)
 
-    err := repo.UpdateDeployment(deployment)
+    err := repo.UpdateDeployment(context.Background(), deployment)
     assert.Error(t, err)
     assert.Equal(t, "etcd put error", err.Error())
 }
@@ -142,13 +144,13 @@ func TestEtcdDeploymentRepositoryUpdateDeploymentNoPreviousKeyValue(t *testing.T
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdDeploymentRepository(mockClient, mockTransactioner)
 
-    deployment := &shared.Deployment{ID: "1", Name: "updated-deployment"}
+    deployment := &shared.Deployment{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-deployment"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), deploymentsKey+deployment.Name, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: nil}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), deploymentsKey+deployment.Name, gomock.Any(), int64(0), shared.DeploymentResource).
+        Return(int64(0), &shared.ErrNotFound{ID: deploymentsKey+deployment.Name, ResourceType: shared.DeploymentResource}).Times(1)
 
-    err := repo.UpdateDeployment(deployment)
+    err := repo.UpdateDeployment(context.Background(), deployment)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
@@ -165,11 +167,11 @@ func TestEtcdDeploymentRepositoryDeleteDeployment(t *testing.T) {
     deploymentID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), deploymentsKey+deploymentID).
+        Delete(gomock.Any(), deploymentsKey+"default/"+deploymentID).
         Return(&clientv3.DeleteResponse{Deleted: 1}, nil).Times(1)
 
     // Act
-    err := repo.DeleteDeployment(deploymentID)
+    err := repo.DeleteDeployment(context.Background(), "default", deploymentID)
 
     // Assert
     assert.NoError(t, err)
@@ -186,10 +188,10 @@ func TestEtcdDeploymentRepositoryDeleteDeploymentErrorOnDelete(t *testing.T) {
     deploymentID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), deploymentsKey+deploymentID).
+        Delete(gomock.Any(), deploymentsKey+"default/"+deploymentID).
         Return(nil, errors.New("etcd delete error")).Times(1)
 
-    err := repo.DeleteDeployment(deploymentID)
+    err := repo.DeleteDeployment(context.Background(), "default", deploymentID)
     assert.Error(t, err)
     assert.Equal(t, "etcd delete error", err.Error())
 }
@@ -205,10 +207,10 @@ func TestEtcdDeploymentRepositoryDeleteDeploymentNotFound(t *testing.T) {
     deploymentID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), deploymentsKey+deploymentID).
+        Delete(gomock.Any(), deploymentsKey+"default/"+deploymentID).
         Return(&clientv3.DeleteResponse{Deleted: 0}, nil).Times(1)
 
-    err := repo.DeleteDeployment(deploymentID)
+    err := repo.DeleteDeployment(context.Background(), "default", deploymentID)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
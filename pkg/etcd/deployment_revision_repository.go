@@ -0,0 +1,66 @@
+package etcd
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+	"sort"
+	"strconv"
+)
+
+var revisionsKey = "revisions/"
+
+type EtcdDeploymentRevisionRepository struct {
+	store *Store[shared.DeploymentRevision]
+}
+
+func NewEtcdDeploymentRevisionRepository(
+	client EtcdClient,
+	transactioner Transactioner,
+) DeploymentRevisionRepository {
+	store := NewStore(client, transactioner, revisionsKey, shared.DeploymentRevisionResource,
+		func(revision *shared.DeploymentRevision) string {
+			return getDeploymentRevisionKey(revision.Namespace, revision.DeploymentName, revision.Revision)
+		},
+		func(revision *shared.DeploymentRevision) *int64 { return &revision.ResourceVersion },
+	)
+	return &EtcdDeploymentRevisionRepository{store: store}
+}
+
+// Returns the revisions of a deployment, ordered from oldest to newest
+func (repo *EtcdDeploymentRevisionRepository) ListRevisions(ctx context.Context, namespace string, deploymentName string) ([]shared.DeploymentRevision, error) {
+	revisions, err := repo.store.ListWithPrefix(ctx, getDeploymentRevisionsKey(namespace, deploymentName))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+func (repo *EtcdDeploymentRevisionRepository) GetRevision(ctx context.Context, namespace string, deploymentName string, revisionNumber int) (*shared.DeploymentRevision, error) {
+	return repo.store.Get(ctx, getDeploymentRevisionKey(namespace, deploymentName, revisionNumber))
+}
+
+func (repo *EtcdDeploymentRevisionRepository) CreateRevision(ctx context.Context, revision *shared.DeploymentRevision) error {
+	return repo.store.Create(ctx, revision)
+}
+
+func (repo *EtcdDeploymentRevisionRepository) DeleteRevision(ctx context.Context, namespace string, deploymentName string, revisionNumber int) error {
+	return repo.store.Delete(ctx, getDeploymentRevisionKey(namespace, deploymentName, revisionNumber))
+}
+
+func (repo *EtcdDeploymentRevisionRepository) DeleteRevisions(ctx context.Context, namespace string, deploymentName string) error {
+	return repo.store.DeleteWithPrefix(ctx, getDeploymentRevisionsKey(namespace, deploymentName))
+}
+
+// Keys below revisionsKey
+func getDeploymentRevisionsKey(namespace string, deploymentName string) string {
+	return ObjectKey(namespace, deploymentName) + "/"
+}
+
+func getDeploymentRevisionKey(namespace string, deploymentName string, revisionNumber int) string {
+	return ObjectKey(namespace, deploymentName, strconv.Itoa(revisionNumber))
+}
@@ -0,0 +1,93 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestEtcdDeploymentRevisionRepositoryListRevisions(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	mockTransactioner := mocks.NewMockTransactioner(ctrl)
+	repo := NewEtcdDeploymentRevisionRepository(mockClient, mockTransactioner)
+
+	// Etcd orders keys lexically, so revision 10 comes before revision 2
+	mockClient.EXPECT().
+		Get(gomock.Any(), revisionsKey+"default/test-deployment/", gomock.Any()).
+		Return(&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{
+				{
+					Key:   []byte(revisionsKey + "default/test-deployment/10"),
+					Value: []byte(`{"deploymentName": "test-deployment", "revision": 10}`),
+				},
+				{
+					Key:   []byte(revisionsKey + "default/test-deployment/2"),
+					Value: []byte(`{"deploymentName": "test-deployment", "revision": 2}`),
+				},
+			},
+		}, nil).Times(1)
+
+	// Act
+	revisions, err := repo.ListRevisions(context.Background(), "default", "test-deployment")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, 10, revisions[1].Revision)
+}
+
+func TestEtcdDeploymentRevisionRepositoryCreateRevision(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	mockTransactioner := mocks.NewMockTransactioner(ctrl)
+	repo := NewEtcdDeploymentRevisionRepository(mockClient, mockTransactioner)
+
+	revision := &shared.DeploymentRevision{DeploymentName: "test-deployment", Revision: 3, TemplateHash: "abcd1234"}
+	revisionData, _ := json.Marshal(revision)
+
+	mockTransactioner.EXPECT().
+		PerformTransaction(gomock.Any(), revisionsKey+"test-deployment/3", string(revisionData), shared.DeploymentRevisionResource).
+		Return(int64(1), nil).Times(1)
+
+	// Act
+	err := repo.CreateRevision(context.Background(), revision)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestEtcdDeploymentRevisionRepositoryDeleteRevisionNotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	mockTransactioner := mocks.NewMockTransactioner(ctrl)
+	repo := NewEtcdDeploymentRevisionRepository(mockClient, mockTransactioner)
+
+	mockClient.EXPECT().
+		Delete(gomock.Any(), revisionsKey+"default/test-deployment/1").
+		Return(&clientv3.DeleteResponse{Deleted: 0}, nil).Times(1)
+
+	// Act
+	err := repo.DeleteRevision(context.Background(), "default", "test-deployment", 1)
+
+	// Assert
+	var errNotFound *shared.ErrNotFound
+	assert.ErrorAs(t, err, &errNotFound)
+}
@@ -2,8 +2,14 @@ package etcd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"maden/pkg/shared"
+	"net"
+	"strings"
 	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 var dnsKey = "dns/"
@@ -16,37 +22,127 @@ func NewEtcdDNSRepository(client EtcdClient) DNSRepository {
 	return &EtcdDNSRepository{client: client}
 }
 
-func (repo *EtcdDNSRepository) RegisterService(serviceName string, serviceIP string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (repo *EtcdDNSRepository) RegisterService(ctx context.Context, service shared.Service) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	key := dnsKey + serviceName
-	_, err := repo.client.Put(ctx, key, serviceIP)
+	record := shared.DNSRecord{Namespace: service.Namespace, Name: service.Name, IP: service.IP, Ports: service.Ports}
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	key := dnsKey + ObjectKey(service.Namespace, service.Name)
+	_, err = repo.client.Put(ctx, key, string(value))
 	return err
 }
 
-func (repo *EtcdDNSRepository) DeregisterService(serviceName string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (repo *EtcdDNSRepository) DeregisterService(ctx context.Context, namespace string, serviceName string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	key := dnsKey + serviceName
+	key := dnsKey + ObjectKey(namespace, serviceName)
 	_, err := repo.client.Delete(ctx, key)
 	return err
 }
 
-func (repo *EtcdDNSRepository) ResolveService(serviceName string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// Every record along with the etcd revision they were read at, to watch the changes made after it
+func (repo *EtcdDNSRepository) ListRecords(ctx context.Context) ([]shared.DNSRecord, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	key := dnsKey + serviceName
-	resp, err := repo.client.Get(ctx, key)
+	resp, err := repo.client.Get(ctx, dnsKey, clientv3.WithPrefix())
 	if err != nil {
-		return "", err
+		return nil, 0, err
 	}
 
-	if len(resp.Kvs) == 0 {
-		return "", &shared.ErrNotFound{ID: serviceName, ResourceType: shared.DNSResource}
+	records := make([]shared.DNSRecord, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		record, err := decodeDNSRecord(string(kv.Key), kv.Value)
+		if err != nil {
+			shared.Log.Errorf("Skipping DNS record %s: %v", kv.Key, err)
+			continue
+		}
+		records = append(records, *record)
+	}
+	return records, resp.Header.Revision, nil
+}
+
+// Changes made after revision. The channel is closed when ctx is done or when the watch fails,
+// e.g. because the revision was compacted, in which case the records have to be listed anew
+func (repo *EtcdDNSRepository) WatchRecords(ctx context.Context, revision int64) <-chan shared.WatchEvent[shared.DNSRecord] {
+	events := make(chan shared.WatchEvent[shared.DNSRecord])
+
+	go func() {
+		defer close(events)
+
+		for wresp := range repo.client.Watch(ctx, dnsKey, clientv3.WithPrefix(), clientv3.WithRev(revision+1)) {
+			if err := wresp.Err(); err != nil {
+				shared.Log.Errorf("Failed to watch DNS records: %v", err)
+				return
+			}
+
+			for _, ev := range wresp.Events {
+				event, err := decodeDNSEvent(ev)
+				if err != nil {
+					shared.Log.Errorf("Skipping DNS record %s: %v", ev.Kv.Key, err)
+					continue
+				}
+
+				select {
+				case events <- *event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// Deleted records are known by their key alone
+func decodeDNSEvent(ev *clientv3.Event) (*shared.WatchEvent[shared.DNSRecord], error) {
+	if ev.Type == clientv3.EventTypeDelete {
+		namespace, name, err := splitDNSKey(string(ev.Kv.Key))
+		if err != nil {
+			return nil, err
+		}
+		record := &shared.DNSRecord{Namespace: namespace, Name: name}
+		return &shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventDelete, Object: record}, nil
 	}
 
-	return string(resp.Kvs[0].Value), nil
-}
\ No newline at end of file
+	record, err := decodeDNSRecord(string(ev.Kv.Key), ev.Kv.Value)
+	if err != nil {
+		return nil, err
+	}
+	if ev.IsCreate() {
+		return &shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventCreate, Object: record}, nil
+	}
+	return &shared.WatchEvent[shared.DNSRecord]{Type: shared.WatchEventUpdate, Object: record}, nil
+}
+
+// Records written before they carried the ports of the service hold nothing but its IP
+func decodeDNSRecord(key string, value []byte) (*shared.DNSRecord, error) {
+	namespace, name, err := splitDNSKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	record := shared.DNSRecord{}
+	if ip := net.ParseIP(string(value)); ip != nil {
+		record.IP = ip.String()
+	} else if err := json.Unmarshal(value, &record); err != nil {
+		return nil, err
+	}
+	record.Namespace, record.Name = namespace, name
+	return &record, nil
+}
+
+func splitDNSKey(key string) (string, string, error) {
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(key, dnsKey), "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", fmt.Errorf("key %s does not name a service", key)
+	}
+	return namespace, name, nil
+}
@@ -0,0 +1,71 @@
+package etcd
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestEtcdDNSRepositoryRegisterService(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	repo := NewEtcdDNSRepository(mockClient)
+	service := shared.Service{
+		ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default"},
+		IP:         "10.96.0.10",
+		Ports:      []shared.ServicePort{{Name: "http", Port: 80}},
+	}
+
+	mockClient.EXPECT().
+		Put(gomock.Any(), dnsKey+"default/web", `{"namespace":"default","name":"web","ip":"10.96.0.10","ports":[{"name":"http","port":80,"targetPort":0}]}`).
+		Return(&clientv3.PutResponse{}, nil).Times(1)
+
+	// Act
+	err := repo.RegisterService(context.Background(), service)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestEtcdDNSRepositoryListRecords(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	repo := NewEtcdDNSRepository(mockClient)
+
+	// Records written before they were JSON hold the bare IP
+	mockClient.EXPECT().
+		Get(gomock.Any(), dnsKey, gomock.Any()).
+		Return(&clientv3.GetResponse{
+			Header: &etcdserverpb.ResponseHeader{Revision: 12},
+			Kvs: []*mvccpb.KeyValue{
+				{Key: []byte(dnsKey + "default/web"), Value: []byte(`{"ip": "10.96.0.10", "ports": [{"name": "http", "port": 80}]}`)},
+				{Key: []byte(dnsKey + "default/legacy"), Value: []byte("192.168.1.100")},
+				{Key: []byte(dnsKey + "default/broken"), Value: []byte("not a record")},
+			},
+		}, nil).Times(1)
+
+	// Act
+	records, revision, err := repo.ListRecords(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), revision)
+	assert.Equal(t, []shared.DNSRecord{
+		{Namespace: "default", Name: "web", IP: "10.96.0.10", Ports: []shared.ServicePort{{Name: "http", Port: 80}}},
+		{Namespace: "default", Name: "legacy", IP: "192.168.1.100"},
+	}, records)
+}
@@ -0,0 +1,48 @@
+package etcd
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+)
+
+var endpointsKey = "endpoints/"
+
+type EtcdEndpointsRepository struct {
+	store *Store[shared.Endpoints]
+}
+
+func NewEtcdEndpointsRepository(
+	client EtcdClient,
+	transactioner Transactioner,
+) EndpointsRepository {
+	store := NewStore(client, transactioner, endpointsKey, shared.EndpointsResource,
+		func(endpoints *shared.Endpoints) string { return ObjectKey(endpoints.Namespace, endpoints.Name) },
+		func(endpoints *shared.Endpoints) *int64 { return &endpoints.ResourceVersion },
+	)
+	return &EtcdEndpointsRepository{store: store}
+}
+
+func (repo *EtcdEndpointsRepository) ListEndpoints(ctx context.Context, namespace string) ([]shared.Endpoints, error) {
+	return repo.store.ListWithPrefix(ctx, namespacePrefix(namespace))
+}
+
+func (repo *EtcdEndpointsRepository) GetEndpoints(ctx context.Context, namespace string, serviceName string) (*shared.Endpoints, error) {
+	return repo.store.Get(ctx, ObjectKey(namespace, serviceName))
+}
+
+func (repo *EtcdEndpointsRepository) CreateEndpoints(ctx context.Context, endpoints *shared.Endpoints) error {
+	return repo.store.Create(ctx, endpoints)
+}
+
+func (repo *EtcdEndpointsRepository) UpdateEndpoints(ctx context.Context, endpoints *shared.Endpoints) error {
+	return repo.store.Update(ctx, endpoints)
+}
+
+func (repo *EtcdEndpointsRepository) DeleteEndpoints(ctx context.Context, namespace string, serviceName string) error {
+	return repo.store.Delete(ctx, ObjectKey(namespace, serviceName))
+}
+
+func (repo *EtcdEndpointsRepository) WatchEndpoints(ctx context.Context) <-chan shared.WatchEvent[shared.Endpoints] {
+	return repo.store.Watch(ctx)
+}
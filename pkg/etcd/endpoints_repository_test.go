@@ -0,0 +1,64 @@
+package etcd
+
+import (
+	"maden/pkg/mocks"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestEtcdEndpointsRepositoryListEndpoints(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	repo := NewEtcdEndpointsRepository(mockClient, mocks.NewMockTransactioner(ctrl))
+
+	mockClient.EXPECT().
+		Get(gomock.Any(), endpointsKey+"default/", gomock.Any()).
+		Return(&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{{
+				Key:         []byte(endpointsKey + "default/web"),
+				Value:       []byte(`{"name": "web", "namespace": "default", "addresses": [{"ip": "172.17.0.2", "podId": "pod-1"}]}`),
+				ModRevision: 3,
+			}},
+		}, nil).Times(1)
+
+	// Act
+	endpoints, err := repo.ListEndpoints(context.Background(), "default")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "web", endpoints[0].Name)
+	assert.Equal(t, "172.17.0.2", endpoints[0].Addresses[0].IP)
+	assert.Equal(t, int64(3), endpoints[0].ResourceVersion)
+}
+
+func TestEtcdEndpointsRepositoryGetEndpoints(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	repo := NewEtcdEndpointsRepository(mockClient, mocks.NewMockTransactioner(ctrl))
+
+	mockClient.EXPECT().
+		Get(gomock.Any(), endpointsKey+"default/web").
+		Return(&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{{Value: []byte(`{"name": "web", "namespace": "default", "ports": [{"servicePort": 80, "port": 8080, "protocol": "tcp"}]}`)}},
+		}, nil).Times(1)
+
+	// Act
+	endpoints, err := repo.GetEndpoints(context.Background(), "default", "web")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, endpoints.Ports[0].Port)
+}
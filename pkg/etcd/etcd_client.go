@@ -14,6 +14,7 @@ type EtcdClient interface {
     Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error)
     Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error)
     Txn(ctx context.Context) clientv3.Txn
+    Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan
 }
 
 func NewEtcdClient(client *clientv3.Client) EtcdClient {
@@ -21,8 +22,13 @@ func NewEtcdClient(client *clientv3.Client) EtcdClient {
 }
 
 func NewClientv3() *clientv3.Client {
+	return NewClientv3WithEndpoints([]string{"etcd:2379"})
+}
+
+// Used by processes that do not run next to etcd, such as the node agent
+func NewClientv3WithEndpoints(endpoints []string) *clientv3.Client {
 	client, err := clientv3.New(clientv3.Config{
-		Endpoints: []string{"etcd:2379"},
+		Endpoints: endpoints,
 		DialTimeout: 5 * time.Second,
 	})
 	if err != nil {
@@ -3,62 +3,110 @@ package etcd
 import (
 	"context"
 	"maden/pkg/shared"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
+// Listing methods of namespaced objects return the objects of every namespace when namespace is empty
+
+type NamespaceRepository interface {
+	ListNamespaces(ctx context.Context) ([]shared.Namespace, error)
+	GetNamespaceByName(ctx context.Context, namespaceName string) (*shared.Namespace, error)
+	CreateNamespace(ctx context.Context, namespace *shared.Namespace) error
+	UpdateNamespace(ctx context.Context, namespace *shared.Namespace) error
+	DeleteNamespace(ctx context.Context, namespaceName string) error
+}
+
 type PodRepository interface {
-	ListPods() ([]shared.Pod, error)
-	GetPodsByDeploymentID(deploymentID string) ([]shared.Pod, error)
-	GetPodByID(podID string) (*shared.Pod, error)
-	CreatePod(pod *shared.Pod) error
-	UpdatePod(pod *shared.Pod) error
-	DeletePod(podID string) error
+	ListPods(ctx context.Context, namespace string) ([]shared.Pod, error)
+	GetPodsByDeploymentID(ctx context.Context, namespace string, deploymentID string) ([]shared.Pod, error)
+	GetPodByID(ctx context.Context, namespace string, podID string) (*shared.Pod, error)
+	CreatePod(ctx context.Context, pod *shared.Pod) error
+	UpdatePod(ctx context.Context, pod *shared.Pod) error
+	DeletePod(ctx context.Context, namespace string, podID string) error
+	WatchPods(ctx context.Context) <-chan shared.WatchEvent[shared.Pod]
 }
 
 type NodeRepository interface {
-	ListNodes() ([]shared.Node, error)
-	CreateNode(node *shared.Node) error
-	UpdateNode(node *shared.Node) error
-	DeleteNode(nodeName string) error
+	ListNodes(ctx context.Context) ([]shared.Node, error)
+	GetNodeByID(ctx context.Context, nodeID string) (*shared.Node, error)
+	CreateNode(ctx context.Context, node *shared.Node) error
+	UpdateNode(ctx context.Context, node *shared.Node) error
+	DeleteNode(ctx context.Context, nodeName string) error
+}
+
+type NodeLeaseRepository interface {
+	ListNodeLeases(ctx context.Context) ([]shared.NodeLease, error)
+	GetNodeLease(ctx context.Context, nodeID string) (*shared.NodeLease, error)
+	RenewNodeLease(ctx context.Context, lease *shared.NodeLease) error
+	DeleteNodeLease(ctx context.Context, nodeID string) error
 }
 
 type DeploymentRepository interface {
-	ListDeployments() ([]shared.Deployment, error)
-	GetDeploymentByName(deploymentName string) (*shared.Deployment, error)
-	CreateDeployment(deployment *shared.Deployment) error
-	UpdateDeployment(deployment *shared.Deployment) error
-	DeleteDeployment(deploymentName string) error
+	ListDeployments(ctx context.Context, namespace string) ([]shared.Deployment, error)
+	GetDeploymentByName(ctx context.Context, namespace string, deploymentName string) (*shared.Deployment, error)
+	CreateDeployment(ctx context.Context, deployment *shared.Deployment) error
+	UpdateDeployment(ctx context.Context, deployment *shared.Deployment) error
+	DeleteDeployment(ctx context.Context, namespace string, deploymentName string) error
+}
+
+type DeploymentRevisionRepository interface {
+	ListRevisions(ctx context.Context, namespace string, deploymentName string) ([]shared.DeploymentRevision, error)
+	GetRevision(ctx context.Context, namespace string, deploymentName string, revisionNumber int) (*shared.DeploymentRevision, error)
+	CreateRevision(ctx context.Context, revision *shared.DeploymentRevision) error
+	DeleteRevision(ctx context.Context, namespace string, deploymentName string, revisionNumber int) error
+	DeleteRevisions(ctx context.Context, namespace string, deploymentName string) error
 }
 
 type ServiceRepository interface {
-	ListServices() ([]shared.Service, error)
-	GetServiceByName(serviceName string) (*shared.Service, error)
-	CreateService(service *shared.Service) error
-	UpdateService(service *shared.Service) error
-	DeleteService(serviceName string) error
+	ListServices(ctx context.Context, namespace string) ([]shared.Service, error)
+	GetServiceByName(ctx context.Context, namespace string, serviceName string) (*shared.Service, error)
+	CreateService(ctx context.Context, service *shared.Service) error
+	UpdateService(ctx context.Context, service *shared.Service) error
+	DeleteService(ctx context.Context, namespace string, serviceName string) error
+	WatchServices(ctx context.Context) <-chan shared.WatchEvent[shared.Service]
+}
+
+type EndpointsRepository interface {
+	ListEndpoints(ctx context.Context, namespace string) ([]shared.Endpoints, error)
+	GetEndpoints(ctx context.Context, namespace string, serviceName string) (*shared.Endpoints, error)
+	CreateEndpoints(ctx context.Context, endpoints *shared.Endpoints) error
+	UpdateEndpoints(ctx context.Context, endpoints *shared.Endpoints) error
+	DeleteEndpoints(ctx context.Context, namespace string, serviceName string) error
+	WatchEndpoints(ctx context.Context) <-chan shared.WatchEvent[shared.Endpoints]
+}
+
+type IPAllocationRepository interface {
+	GetIPAllocation(ctx context.Context, rangeName string) (*shared.IPAllocation, error)
+	CreateIPAllocation(ctx context.Context, allocation *shared.IPAllocation) error
+	UpdateIPAllocation(ctx context.Context, allocation *shared.IPAllocation) error
 }
 
 type PersistentVolumeRepository interface {
-	ListPersistentVolumes() ([]shared.PersistentVolume, error)
-	GetPersistentVolumeByID(persistentVolumeID string) (*shared.PersistentVolume, error)
-	CreatePersistentVolume(volume *shared.PersistentVolume) error
-	UpdatePersistentVolume(volume *shared.PersistentVolume) error
-	DeletePersistentVolume(volumeName string) error
+	ListPersistentVolumes(ctx context.Context) ([]shared.PersistentVolume, error)
+	GetPersistentVolumeByID(ctx context.Context, persistentVolumeID string) (*shared.PersistentVolume, error)
+	CreatePersistentVolume(ctx context.Context, volume *shared.PersistentVolume) error
+	UpdatePersistentVolume(ctx context.Context, volume *shared.PersistentVolume) error
+	DeletePersistentVolume(ctx context.Context, volumeName string) error
 }
 
 type PersistentVolumeClaimRepository interface {
-	ListPersistentVolumeClaims() ([]shared.PersistentVolumeClaim, error)
-	GetPersistentVolumeClaimByID(persistentVolumeClaimID string) (*shared.PersistentVolumeClaim, error)
-	CreatePersistentVolumeClaim(volumeClaim *shared.PersistentVolumeClaim) error
-	UpdatePersistentVolumeClaim(volumeClaim *shared.PersistentVolumeClaim) error
-	DeletePersistentVolumeClaim(volumeClaimName string) error
+	ListPersistentVolumeClaims(ctx context.Context, namespace string) ([]shared.PersistentVolumeClaim, error)
+	GetPersistentVolumeClaimByID(ctx context.Context, namespace string, persistentVolumeClaimID string) (*shared.PersistentVolumeClaim, error)
+	CreatePersistentVolumeClaim(ctx context.Context, volumeClaim *shared.PersistentVolumeClaim) error
+	UpdatePersistentVolumeClaim(ctx context.Context, volumeClaim *shared.PersistentVolumeClaim) error
+	DeletePersistentVolumeClaim(ctx context.Context, namespace string, volumeClaimID string) error
 }
 
 type Transactioner interface {
-	PerformTransaction(ctx context.Context, key string, value string, resourceType shared.ResourceType) error
+	PerformTransaction(ctx context.Context, key string, value string, resourceType shared.ResourceType, ops ...clientv3.Op) (int64, error)
+	PerformUpdateTransaction(ctx context.Context, key string, value string, resourceVersion int64, resourceType shared.ResourceType) (int64, error)
+	PerformDeleteTransaction(ctx context.Context, key string, resourceVersion int64, resourceType shared.ResourceType, ops ...clientv3.Op) error
 }
 
 type DNSRepository interface {
-	RegisterService(serviceName string, serviceIP string) error
-	DeregisterService(serviceName string) error
-	ResolveService(serviceName string) (string, error)
+	RegisterService(ctx context.Context, service shared.Service) error
+	DeregisterService(ctx context.Context, namespace string, serviceName string) error
+	ListRecords(ctx context.Context) ([]shared.DNSRecord, int64, error)
+	WatchRecords(ctx context.Context, revision int64) <-chan shared.WatchEvent[shared.DNSRecord]
 }
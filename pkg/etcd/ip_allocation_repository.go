@@ -0,0 +1,37 @@
+package etcd
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+)
+
+var ipAllocationKey = "ipam/"
+
+type EtcdIPAllocationRepository struct {
+	store *Store[shared.IPAllocation]
+}
+
+func NewEtcdIPAllocationRepository(
+	client EtcdClient,
+	transactioner Transactioner,
+) IPAllocationRepository {
+	store := NewStore(client, transactioner, ipAllocationKey, shared.IPAllocationResource,
+		func(allocation *shared.IPAllocation) string { return allocation.Range },
+		func(allocation *shared.IPAllocation) *int64 { return &allocation.ResourceVersion },
+	)
+	return &EtcdIPAllocationRepository{store: store}
+}
+
+func (repo *EtcdIPAllocationRepository) GetIPAllocation(ctx context.Context, rangeName string) (*shared.IPAllocation, error) {
+	return repo.store.Get(ctx, rangeName)
+}
+
+func (repo *EtcdIPAllocationRepository) CreateIPAllocation(ctx context.Context, allocation *shared.IPAllocation) error {
+	return repo.store.Create(ctx, allocation)
+}
+
+// Fails with ErrConflict if the allocation changed since it was read
+func (repo *EtcdIPAllocationRepository) UpdateIPAllocation(ctx context.Context, allocation *shared.IPAllocation) error {
+	return repo.store.Update(ctx, allocation)
+}
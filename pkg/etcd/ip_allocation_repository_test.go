@@ -0,0 +1,40 @@
+package etcd
+
+import (
+	"maden/pkg/mocks"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestEtcdIPAllocationRepositoryGetIPAllocation(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	repo := NewEtcdIPAllocationRepository(mockClient, mocks.NewMockTransactioner(ctrl))
+
+	mockClient.EXPECT().
+		Get(gomock.Any(), ipAllocationKey+"services").
+		Return(&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{{
+				Value:       []byte(`{"range": "services", "cidr": "10.96.0.0/29", "bitmap": "gQ=="}`),
+				ModRevision: 4,
+			}},
+		}, nil).Times(1)
+
+	// Act
+	allocation, err := repo.GetIPAllocation(context.Background(), "services")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "10.96.0.0/29", allocation.CIDR)
+	assert.Equal(t, []byte{0x81}, allocation.Bitmap)
+	assert.Equal(t, int64(4), allocation.ResourceVersion)
+}
@@ -0,0 +1,44 @@
+package etcd
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+)
+
+var namespacesKey = "namespaces/"
+
+type EtcdNamespaceRepository struct {
+	store *Store[shared.Namespace]
+}
+
+func NewEtcdNamespaceRepository(
+	client EtcdClient,
+	transactioner Transactioner,
+) NamespaceRepository {
+	store := NewStore(client, transactioner, namespacesKey, shared.NamespaceResource,
+		func(namespace *shared.Namespace) string { return namespace.Name },
+		func(namespace *shared.Namespace) *int64 { return &namespace.ResourceVersion },
+	)
+	return &EtcdNamespaceRepository{store: store}
+}
+
+func (repo *EtcdNamespaceRepository) ListNamespaces(ctx context.Context) ([]shared.Namespace, error) {
+	return repo.store.List(ctx)
+}
+
+func (repo *EtcdNamespaceRepository) GetNamespaceByName(ctx context.Context, namespaceName string) (*shared.Namespace, error) {
+	return repo.store.Get(ctx, namespaceName)
+}
+
+func (repo *EtcdNamespaceRepository) CreateNamespace(ctx context.Context, namespace *shared.Namespace) error {
+	return repo.store.Create(ctx, namespace)
+}
+
+func (repo *EtcdNamespaceRepository) UpdateNamespace(ctx context.Context, namespace *shared.Namespace) error {
+	return repo.store.Update(ctx, namespace)
+}
+
+func (repo *EtcdNamespaceRepository) DeleteNamespace(ctx context.Context, namespaceName string) error {
+	return repo.store.Delete(ctx, namespaceName)
+}
@@ -0,0 +1,39 @@
+package etcd
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+)
+
+var nodeLeasesKey = "leases/nodes/"
+
+type EtcdNodeLeaseRepository struct {
+	store *Store[shared.NodeLease]
+}
+
+func NewEtcdNodeLeaseRepository(client EtcdClient) NodeLeaseRepository {
+	// Leases are only ever renewed by their own node, so they are written without transactions
+	store := NewStore(client, nil, nodeLeasesKey, shared.NodeLeaseResource,
+		func(lease *shared.NodeLease) string { return lease.NodeID },
+		func(lease *shared.NodeLease) *int64 { return &lease.ResourceVersion },
+	)
+	return &EtcdNodeLeaseRepository{store: store}
+}
+
+func (repo *EtcdNodeLeaseRepository) ListNodeLeases(ctx context.Context) ([]shared.NodeLease, error) {
+	return repo.store.List(ctx)
+}
+
+func (repo *EtcdNodeLeaseRepository) GetNodeLease(ctx context.Context, nodeID string) (*shared.NodeLease, error) {
+	return repo.store.Get(ctx, nodeID)
+}
+
+// Creates the lease on the first heartbeat of the node
+func (repo *EtcdNodeLeaseRepository) RenewNodeLease(ctx context.Context, lease *shared.NodeLease) error {
+	return repo.store.Put(ctx, lease)
+}
+
+func (repo *EtcdNodeLeaseRepository) DeleteNodeLease(ctx context.Context, nodeID string) error {
+	return repo.store.Delete(ctx, nodeID)
+}
@@ -0,0 +1,65 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestEtcdNodeLeaseRepositoryRenewNodeLease(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	repo := NewEtcdNodeLeaseRepository(mockClient)
+
+	lease := &shared.NodeLease{NodeID: "node-1", RenewTime: time.Now(), DurationSeconds: 40}
+	leaseData, _ := json.Marshal(lease)
+
+	mockClient.EXPECT().
+		Put(gomock.Any(), nodeLeasesKey+"node-1", string(leaseData)).
+		Return(&clientv3.PutResponse{}, nil).Times(1)
+
+	// Act
+	err := repo.RenewNodeLease(context.Background(), lease)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestEtcdNodeLeaseRepositoryGetNodeLease(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	repo := NewEtcdNodeLeaseRepository(mockClient)
+
+	mockClient.EXPECT().
+		Get(gomock.Any(), nodeLeasesKey+"node-1").
+		Return(&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{{Value: []byte(`{"nodeId": "node-1", "durationSeconds": 40}`)}},
+		}, nil).Times(1)
+	mockClient.EXPECT().
+		Get(gomock.Any(), nodeLeasesKey+"node-2").
+		Return(&clientv3.GetResponse{}, nil).Times(1)
+
+	// Act
+	lease, err := repo.GetNodeLease(context.Background(), "node-1")
+	_, errMissing := repo.GetNodeLease(context.Background(), "node-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 40, lease.DurationSeconds)
+	var errNotFound *shared.ErrNotFound
+	assert.ErrorAs(t, errMissing, &errNotFound)
+}
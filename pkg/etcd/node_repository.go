@@ -1,98 +1,44 @@
 package etcd
 
 import (
-	"context"
-	"encoding/json"
 	"maden/pkg/shared"
-	"time"
 
-	"go.etcd.io/etcd/client/v3"
+	"context"
 )
 
 var nodesKey = "nodes/"
 
 type EtcdNodeRepository struct {
-	client EtcdClient
-	transactioner Transactioner
+	store *Store[shared.Node]
 }
 
 func NewEtcdNodeRepository(
 	client EtcdClient,
 	transactioner Transactioner,
 ) NodeRepository {
-	return &EtcdNodeRepository{client: client, transactioner: transactioner}
+	store := NewStore(client, transactioner, nodesKey, shared.NodeResource,
+		func(node *shared.Node) string { return node.ID },
+		func(node *shared.Node) *int64 { return &node.ResourceVersion },
+	)
+	return &EtcdNodeRepository{store: store}
 }
 
-
-func (repo *EtcdNodeRepository) ListNodes() ([]shared.Node, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	resp, err := repo.client.Get(ctx, nodesKey, clientv3.WithPrefix())
-	if err != nil {
-		return nil, err
-	}
-
-	nodes := make([]shared.Node, 0)
-	for _, kv := range resp.Kvs {
-		var node shared.Node
-		if err := json.Unmarshal(kv.Value, &node); err != nil {
-			return nil, err
-		}
-		nodes = append(nodes, node)
-	}
-	return nodes, nil
+func (repo *EtcdNodeRepository) ListNodes(ctx context.Context) ([]shared.Node, error) {
+	return repo.store.List(ctx)
 }
 
-func (repo *EtcdNodeRepository) CreateNode(node *shared.Node) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	nodeData, err := json.Marshal(node)
-	if err != nil {
-		return err
-	}
-
-	key := nodesKey + node.ID
-
-	return repo.transactioner.PerformTransaction(ctx, key, string(nodeData), shared.NodeResource)
+func (repo *EtcdNodeRepository) GetNodeByID(ctx context.Context, nodeID string) (*shared.Node, error) {
+	return repo.store.Get(ctx, nodeID)
 }
 
-func (repo *EtcdNodeRepository) UpdateNode(node *shared.Node) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-    defer cancel()
-
-    nodeData, err := json.Marshal(node)
-    if err != nil {
-        return err
-    }
-
-    key := nodesKey + node.ID
-
-    resp, err := repo.client.Put(ctx, key, string(nodeData), clientv3.WithPrevKV())
-	if err != nil {
-		return err
-	}
-
-	if resp.PrevKv == nil {
-		return &shared.ErrNotFound{ID: node.ID, ResourceType: shared.NodeResource}
-	}
-    return err
+func (repo *EtcdNodeRepository) CreateNode(ctx context.Context, node *shared.Node) error {
+	return repo.store.Create(ctx, node)
 }
 
-func (repo *EtcdNodeRepository) DeleteNode(nodeID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	key := nodesKey + nodeID
-
-	resp, err := repo.client.Delete(ctx, key)
-	if err != nil {
-		return err
-	}
+func (repo *EtcdNodeRepository) UpdateNode(ctx context.Context, node *shared.Node) error {
+	return repo.store.Update(ctx, node)
+}
 
-	if resp.Deleted == 0 {
-		return &shared.ErrNotFound{ID: nodeID, ResourceType: shared.NodeResource}
-	}
-	return nil
-}
\ No newline at end of file
+func (repo *EtcdNodeRepository) DeleteNode(ctx context.Context, nodeID string) error {
+	return repo.store.Delete(ctx, nodeID)
+}
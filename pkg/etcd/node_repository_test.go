@@ -1,11 +1,13 @@
 package etcd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -34,7 +36,7 @@ func TestEtcdNodeRepositoryListNodes(t *testing.T) {
 		}, nil).Times(1)
 
 	// Act
-	nodes, err := repo.ListNodes()
+	nodes, err := repo.ListNodes(context.Background())
 
 	// Assert
 	assert.NoError(t, err)
@@ -54,7 +56,7 @@ func TestEtcdNodeRepositoryCreateNode(t *testing.T) {
 
     node := &shared.Node{
         ID:   "1",
-        Name: "test-node",
+        ObjectMeta: shared.ObjectMeta{Name: "test-node", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     nodeData, _ := json.Marshal(node)
@@ -62,9 +64,9 @@ func TestEtcdNodeRepositoryCreateNode(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(nodeData), shared.NodeResource).
-        Return(nil).Times(1)
+        Return(int64(1), nil).Times(1)
 
-    err := repo.CreateNode(node)
+    err := repo.CreateNode(context.Background(), node)
     assert.NoError(t, err)
 }
 
@@ -78,7 +80,7 @@ func TestEtcdNodeRepositoryCreateNodeError(t *testing.T) {
 
     node := &shared.Node{
         ID:   "1",
-        Name: "test-node",
+        ObjectMeta: shared.ObjectMeta{Name: "test-node", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     nodeData, _ := json.Marshal(node)
@@ -86,9 +88,9 @@ func TestEtcdNodeRepositoryCreateNodeError(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(nodeData), shared.NodeResource).
-        Return(errors.New("transaction failed")).Times(1)
+        Return(int64(0), errors.New("transaction failed")).Times(1)
 
-    err := repo.CreateNode(node)
+    err := repo.CreateNode(context.Background(), node)
     assert.Error(t, err)
     assert.Equal(t, "transaction failed", err.Error())
 }
@@ -102,14 +104,14 @@ func TestEtcdNodeRepositoryUpdateNode(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdNodeRepository(mockClient, mockTransactioner)
 
-    node := &shared.Node{ID: "1", Name: "updated-node"}
+    node := &shared.Node{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-node"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), nodesKey+node.ID, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: &mvccpb.KeyValue{}}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), nodesKey+node.ID, gomock.Any(), int64(0), shared.NodeResource).
+        Return(int64(5), nil).Times(1)
 
     // Act
-    err := repo.UpdateNode(node)
+    err := repo.UpdateNode(context.Background(), node)
 
     // Assert
     assert.NoError(t, err)
@@ -123,13 +125,13 @@ func TestEtcdNodeRepositoryUpdateNodeErrorOnPut(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdNodeRepository(mockClient, mockTransactioner)
 
-    node := &shared.Node{ID: "1", Name: "updated-node"}
+    node := &shared.Node{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-node"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), nodesKey+node.ID, gomock.Any(), gomock.Any()).
-        Return(nil, errors.New("etcd put error")).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), nodesKey+node.ID, gomock.Any(), int64(0), shared.NodeResource).
+        Return(int64(0), errors.New("etcd put error")).Times(1)
 
-    err := repo.UpdateNode(node)
+    err := repo.UpdateNode(context.Background(), node)
     assert.Error(t, err)
     assert.Equal(t, "etcd put error", err.Error())
 }
@@ -142,17 +144,59 @@ func TestEtcdNodeRepositoryUpdateNodeNoPreviousKeyValue(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdNodeRepository(mockClient, mockTransactioner)
 
-    node := &shared.Node{ID: "1", Name: "updated-node"}
+    node := &shared.Node{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-node"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), nodesKey+node.ID, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: nil}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), nodesKey+node.ID, gomock.Any(), int64(0), shared.NodeResource).
+        Return(int64(0), &shared.ErrNotFound{ID: nodesKey+node.ID, ResourceType: shared.NodeResource}).Times(1)
 
-    err := repo.UpdateNode(node)
+    err := repo.UpdateNode(context.Background(), node)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
 
+func TestEtcdNodeRepositoryUpdateNodeComparesResourceVersion(t *testing.T) {
+    // Arrange
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockClient := mocks.NewMockEtcdClient(ctrl)
+	mockTransactioner := mocks.NewMockTransactioner(ctrl)
+    repo := NewEtcdNodeRepository(mockClient, mockTransactioner)
+
+    node := &shared.Node{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-node"}, ResourceVersion: 7}
+
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), nodesKey+node.ID, gomock.Any(), int64(7), shared.NodeResource).
+        Return(int64(9), nil).Times(1)
+
+    // Act
+    err := repo.UpdateNode(context.Background(), node)
+
+    // Assert
+    assert.NoError(t, err)
+    assert.Equal(t, int64(9), node.ResourceVersion)
+}
+
+func TestEtcdNodeRepositoryUpdateNodeConflict(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockClient := mocks.NewMockEtcdClient(ctrl)
+	mockTransactioner := mocks.NewMockTransactioner(ctrl)
+    repo := NewEtcdNodeRepository(mockClient, mockTransactioner)
+
+    node := &shared.Node{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-node"}, ResourceVersion: 7}
+
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), nodesKey+node.ID, gomock.Any(), int64(7), shared.NodeResource).
+        Return(int64(0), &shared.ErrConflict{ID: node.ID, ResourceType: shared.NodeResource}).Times(1)
+
+    err := repo.UpdateNode(context.Background(), node)
+    assert.IsType(t, &shared.ErrConflict{}, err)
+    assert.Equal(t, int64(7), node.ResourceVersion)
+}
+
 func TestEtcdNodeRepositoryDeleteNode(t *testing.T) {
     // Arrange
     ctrl := gomock.NewController(t)
@@ -169,7 +213,7 @@ func TestEtcdNodeRepositoryDeleteNode(t *testing.T) {
         Return(&clientv3.DeleteResponse{Deleted: 1}, nil).Times(1)
 
     // Act
-    err := repo.DeleteNode(nodeID)
+    err := repo.DeleteNode(context.Background(), nodeID)
 
     // Assert
     assert.NoError(t, err)
@@ -189,7 +233,7 @@ func TestEtcdNodeRepositoryDeleteNodeErrorOnDelete(t *testing.T) {
         Delete(gomock.Any(), nodesKey+nodeID).
         Return(nil, errors.New("etcd delete error")).Times(1)
 
-    err := repo.DeleteNode(nodeID)
+    err := repo.DeleteNode(context.Background(), nodeID)
     assert.Error(t, err)
     assert.Equal(t, "etcd delete error", err.Error())
 }
@@ -208,7 +252,36 @@ func TestEtcdNodeRepositoryDeleteNodeNotFound(t *testing.T) {
         Delete(gomock.Any(), nodesKey+nodeID).
         Return(&clientv3.DeleteResponse{Deleted: 0}, nil).Times(1)
 
-    err := repo.DeleteNode(nodeID)
+    err := repo.DeleteNode(context.Background(), nodeID)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
+
+func TestEtcdNodeRepositoryGetNodeByID(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	mockTransactioner := mocks.NewMockTransactioner(ctrl)
+	repo := NewEtcdNodeRepository(mockClient, mockTransactioner)
+
+	mockClient.EXPECT().
+		Get(gomock.Any(), nodesKey+"1").
+		Return(&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{{Key: []byte(nodesKey + "1"), Value: []byte(`{"id": "1", "name": "test-node"}`)}},
+		}, nil).Times(1)
+	mockClient.EXPECT().
+		Get(gomock.Any(), nodesKey+"2").
+		Return(&clientv3.GetResponse{}, nil).Times(1)
+
+	// Act
+	node, err := repo.GetNodeByID(context.Background(), "1")
+	_, errMissing := repo.GetNodeByID(context.Background(), "2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "test-node", node.Name)
+	var errNotFound *shared.ErrNotFound
+	assert.ErrorAs(t, errMissing, &errNotFound)
+}
@@ -4,140 +4,55 @@ import (
 	"maden/pkg/shared"
 
 	"context"
-	"encoding/json"
-	"time"
-
-	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
-var podsKey = "pods/";
+var podsKey = "pods/"
+var podsByDeploymentKey = "pod-index/deployments/"
 
 type EtcdPodRepository struct {
-	client EtcdClient
-	transactioner Transactioner
+	store *Store[shared.Pod]
 }
 
 func NewEtcdPodRepository(
 	client EtcdClient,
-	transactioner Transactioner,	
+	transactioner Transactioner,
 ) PodRepository {
-	return &EtcdPodRepository{client: client, transactioner: transactioner}
-}
-
-
-func (repo *EtcdPodRepository) ListPods() ([]shared.Pod, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	resp, err := repo.client.Get(ctx, podsKey, clientv3.WithPrefix())
-	if err != nil {
-		return nil, err
-	}
-
-	pods := make([]shared.Pod, 0)
-	for _, kv := range resp.Kvs {
-		var pod shared.Pod
-		if err := json.Unmarshal(kv.Value, &pod); err != nil {
-			return nil, err
+	store := NewStore(client, transactioner, podsKey, shared.PodResource,
+		func(pod *shared.Pod) string { return ObjectKey(pod.Namespace, pod.ID) },
+		func(pod *shared.Pod) *int64 { return &pod.ResourceVersion },
+	).WithIndex(podsByDeploymentKey, func(pod *shared.Pod) string {
+		if pod.DeploymentID == "" {
+			return ""
 		}
-		pods = append(pods, pod)
-	}
-	return pods, nil
+		return ObjectKey(pod.Namespace, pod.DeploymentID, pod.ID)
+	})
+	return &EtcdPodRepository{store: store}
 }
 
-func (repo *EtcdPodRepository) GetPodsByDeploymentID(deploymentID string) ([]shared.Pod, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	resp, err := repo.client.Get(ctx, podsKey, clientv3.WithPrefix())
-	if err != nil {
-		return nil, err
-	}
-
-	pods := make([]shared.Pod, 0)
-	for _, kv := range resp.Kvs {
-		var pod shared.Pod
-		if err := json.Unmarshal(kv.Value, &pod); err != nil {
-			return nil, err
-		}
-		if pod.DeploymentID == deploymentID {
-			pods = append(pods, pod)
-		}
-	}
-	return pods, nil
+func (repo *EtcdPodRepository) ListPods(ctx context.Context, namespace string) ([]shared.Pod, error) {
+	return repo.store.ListWithPrefix(ctx, namespacePrefix(namespace))
 }
 
-func (repo *EtcdPodRepository) GetPodByID(podID string) (*shared.Pod, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	key := podsKey + podID
-
-	resp, err := repo.client.Get(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(resp.Kvs) == 0 {
-		return nil, &shared.ErrNotFound{ID: podID, ResourceType: shared.PodResource}
-	}
-
-	var pod shared.Pod
-	if err := json.Unmarshal(resp.Kvs[0].Value, &pod); err != nil {
-		return nil, err
-	}
-	return &pod, nil
+func (repo *EtcdPodRepository) GetPodsByDeploymentID(ctx context.Context, namespace string, deploymentID string) ([]shared.Pod, error) {
+	return repo.store.ListIndexed(ctx, ObjectKey(namespace, deploymentID)+"/")
 }
 
-func (repo *EtcdPodRepository) CreatePod(pod *shared.Pod) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	podData, err := json.Marshal(pod)
-	if err != nil {
-		return err
-	}
-
-	key := podsKey + pod.ID
-
-    return repo.transactioner.PerformTransaction(ctx, key, string(podData), shared.PodResource)
+func (repo *EtcdPodRepository) GetPodByID(ctx context.Context, namespace string, podID string) (*shared.Pod, error) {
+	return repo.store.Get(ctx, ObjectKey(namespace, podID))
 }
 
-func (repo *EtcdPodRepository) UpdatePod(pod *shared.Pod) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	podData, err := json.Marshal(pod)
-	if err != nil {
-		return err
-	}
-
-	key := podsKey + pod.ID
-
-	resp, err := repo.client.Put(ctx, key, string(podData), clientv3.WithPrevKV())
-	if err != nil {
-		return err
-	}
-
-	if resp.PrevKv == nil {
-		return &shared.ErrNotFound{ID: pod.ID, ResourceType: shared.PodResource}
-	}
-	return nil
+func (repo *EtcdPodRepository) CreatePod(ctx context.Context, pod *shared.Pod) error {
+	return repo.store.Create(ctx, pod)
 }
 
-func (repo *EtcdPodRepository) DeletePod(podID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	key := podsKey + podID
+func (repo *EtcdPodRepository) UpdatePod(ctx context.Context, pod *shared.Pod) error {
+	return repo.store.Update(ctx, pod)
+}
 
-	resp, err := repo.client.Delete(ctx, key)
-	if err != nil {
-		return err
-	}
+func (repo *EtcdPodRepository) DeletePod(ctx context.Context, namespace string, podID string) error {
+	return repo.store.Delete(ctx, ObjectKey(namespace, podID))
+}
 
-	if resp.Deleted == 0 {
-		return &shared.ErrNotFound{ID: podID, ResourceType: shared.PodResource}
-	}
-	return nil
-}
\ No newline at end of file
+func (repo *EtcdPodRepository) WatchPods(ctx context.Context) <-chan shared.WatchEvent[shared.Pod] {
+	return repo.store.Watch(ctx)
+}
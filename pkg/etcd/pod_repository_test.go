@@ -1,11 +1,13 @@
 package etcd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -23,18 +25,18 @@ func TestEtcdPodRepositoryListPods(t *testing.T) {
     repo := NewEtcdPodRepository(mockClient, mockTransactioner)
 
 	mockClient.EXPECT().
-		Get(gomock.Any(), podsKey, gomock.Any()).
+		Get(gomock.Any(), podsKey+"default/", gomock.Any()).
 		Return(&clientv3.GetResponse{
 			Kvs: []*mvccpb.KeyValue{
 				{
-					Key: []byte(podsKey + "1"),
+					Key: []byte(podsKey + "default/1"),
 					Value: []byte(`{"id": "1", "name": "test-pod", "deploymentID": "1"}`),
 				},
 			},
 		}, nil).Times(1)
 
 	// Act
-	pods, err := repo.ListPods()
+	pods, err := repo.ListPods(context.Background(), "default")
 
 	// Assert
 	assert.NoError(t, err)
@@ -55,26 +57,63 @@ func TestEtcdPodRepositoryGetPodsByDeploymentID(t *testing.T) {
 
     deploymentID := "1"
 
+    // Only the pods of the deployment are read, the one deleted since the index was read is skipped
     mockClient.EXPECT().
-        Get(gomock.Any(), podsKey, gomock.Any()).
+        Get(gomock.Any(), podsByDeploymentKey+"default/1/", gomock.Any()).
         Return(&clientv3.GetResponse{
             Kvs: []*mvccpb.KeyValue{
-                {
-                    Key:   []byte(podsKey + "1"),
-                    Value: []byte(`{"id": "1", "name": "test-pod", "deploymentID": "1"}`),
-                },
+                {Key: []byte(podsByDeploymentKey + "default/1/1"), Value: []byte("default/1")},
+                {Key: []byte(podsByDeploymentKey + "default/1/2"), Value: []byte("default/2")},
             },
         }, nil).Times(1)
+    mockClient.EXPECT().
+        Get(gomock.Any(), podsKey+"default/1").
+        Return(&clientv3.GetResponse{
+            Kvs: []*mvccpb.KeyValue{
+                {Key: []byte(podsKey + "default/1"), Value: []byte(`{"id": "1", "name": "test-pod", "deploymentID": "1"}`)},
+            },
+        }, nil).Times(1)
+    mockClient.EXPECT().
+        Get(gomock.Any(), podsKey+"default/2").
+        Return(&clientv3.GetResponse{}, nil).Times(1)
 
     // Act
-    pods, err := repo.GetPodsByDeploymentID(deploymentID)
+    pods, err := repo.GetPodsByDeploymentID(context.Background(), "default", deploymentID)
 
     // Assert
     assert.NoError(t, err)
-    assert.Len(t, pods, 1) 
+    assert.Len(t, pods, 1)
     assert.Equal(t, deploymentID, pods[0].DeploymentID)
 }
 
+func TestEtcdPodRepositoryCreatePodIndexesDeployment(t *testing.T) {
+    // Arrange
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockClient := mocks.NewMockEtcdClient(ctrl)
+    mockTransactioner := mocks.NewMockTransactioner(ctrl)
+    repo := NewEtcdPodRepository(mockClient, mockTransactioner)
+
+    pod := &shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "test-pod", Namespace: "default"}, DeploymentID: "dep-1"}
+
+    mockTransactioner.EXPECT().
+        PerformTransaction(gomock.Any(), podsKey+"default/1", gomock.Any(), shared.PodResource, gomock.Any()).
+        DoAndReturn(func(_ context.Context, _ string, _ string, _ shared.ResourceType, ops ...clientv3.Op) (int64, error) {
+            assert.Len(t, ops, 1)
+            assert.True(t, ops[0].IsPut())
+            assert.Equal(t, podsByDeploymentKey+"default/dep-1/1", string(ops[0].KeyBytes()))
+            assert.Equal(t, "default/1", string(ops[0].ValueBytes()))
+            return 1, nil
+        })
+
+    // Act
+    err := repo.CreatePod(context.Background(), pod)
+
+    // Assert
+    assert.NoError(t, err)
+}
+
 func TestEtcdPodRepositoryCreatePod(t *testing.T) {
     ctrl := gomock.NewController(t)
     defer ctrl.Finish()
@@ -86,7 +125,7 @@ func TestEtcdPodRepositoryCreatePod(t *testing.T) {
 
     pod := &shared.Pod{
         ID:   "1",
-        Name: "test-pod",
+        ObjectMeta: shared.ObjectMeta{Name: "test-pod", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     podData, _ := json.Marshal(pod)
@@ -94,9 +133,9 @@ func TestEtcdPodRepositoryCreatePod(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(podData), shared.PodResource).
-        Return(nil).Times(1)
+        Return(int64(1), nil).Times(1)
 
-    err := repo.CreatePod(pod)
+    err := repo.CreatePod(context.Background(), pod)
     assert.NoError(t, err)
 }
 
@@ -110,7 +149,7 @@ func TestEtcdPodRepositoryCreatePodError(t *testing.T) {
 
     pod := &shared.Pod{
         ID:   "1",
-        Name: "test-pod",
+        ObjectMeta: shared.ObjectMeta{Name: "test-pod", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     podData, _ := json.Marshal(pod)
@@ -118,9 +157,9 @@ func TestEtcdPodRepositoryCreatePodError(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(podData), shared.PodResource).
-        Return(errors.New("transaction failed")).Times(1)
+        Return(int64(0), errors.New("transaction failed")).Times(1)
 
-    err := repo.CreatePod(pod)
+    err := repo.CreatePod(context.Background(), pod)
     assert.Error(t, err)
     assert.Equal(t, "transaction failed", err.Error())
 }
@@ -134,14 +173,14 @@ func TestEtcdPodRepositoryUpdatePod(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdPodRepository(mockClient, mockTransactioner)
 
-    pod := &shared.Pod{ID: "1", Name: "updated-pod"}
+    pod := &shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-pod"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), podsKey+pod.ID, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: &mvccpb.KeyValue{}}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), podsKey+pod.ID, gomock.Any(), int64(0), shared.PodResource).
+        Return(int64(5), nil).Times(1)
 
     // Act
-    err := repo.UpdatePod(pod)
+    err := repo.UpdatePod(context.Background(), pod)
 
     // Assert
     assert.NoError(t, err)
@@ -155,13 +194,13 @@ func TestEtcdPodRepositoryUpdatePodErrorOnPut(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdPodRepository(mockClient, mockTransactioner)
 
-    pod := &shared.Pod{ID: "1", Name: "updated-pod"}
+    pod := &shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-pod"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), podsKey+pod.ID, gomock.Any(), gomock.Any()).
-        Return(nil, errors.New("etcd put error")).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), podsKey+pod.ID, gomock.Any(), int64(0), shared.PodResource).
+        Return(int64(0), errors.New("etcd put error")).Times(1)
 
-    err := repo.UpdatePod(pod)
+    err := repo.UpdatePod(context.Background(), pod)
     assert.Error(t, err)
     assert.Equal(t, "etcd put error", err.Error())
 }
@@ -174,13 +213,13 @@ func TestEtcdPodRepositoryUpdatePodNoPreviousKeyValue(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdPodRepository(mockClient, mockTransactioner)
 
-    pod := &shared.Pod{ID: "1", Name: "updated-pod"}
+    pod := &shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-pod"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), podsKey+pod.ID, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: nil}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), podsKey+pod.ID, gomock.Any(), int64(0), shared.PodResource).
+        Return(int64(0), &shared.ErrNotFound{ID: podsKey+pod.ID, ResourceType: shared.PodResource}).Times(1)
 
-    err := repo.UpdatePod(pod)
+    err := repo.UpdatePod(context.Background(), pod)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
@@ -197,11 +236,23 @@ func TestEtcdPodRepositoryDeletePod(t *testing.T) {
     podID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), podsKey+podID).
-        Return(&clientv3.DeleteResponse{Deleted: 1}, nil).Times(1)
+        Get(gomock.Any(), podsKey+"default/"+podID).
+        Return(&clientv3.GetResponse{
+            Kvs: []*mvccpb.KeyValue{
+                {Key: []byte(podsKey + "default/1"), Value: []byte(`{"id": "1", "namespace": "default", "deploymentId": "dep-1"}`), ModRevision: 7},
+            },
+        }, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformDeleteTransaction(gomock.Any(), podsKey+"default/"+podID, int64(7), shared.PodResource, gomock.Any()).
+        DoAndReturn(func(_ context.Context, _ string, _ int64, _ shared.ResourceType, ops ...clientv3.Op) error {
+            assert.Len(t, ops, 1)
+            assert.True(t, ops[0].IsDelete())
+            assert.Equal(t, podsByDeploymentKey+"default/dep-1/1", string(ops[0].KeyBytes()))
+            return nil
+        })
 
     // Act
-    err := repo.DeletePod(podID)
+    err := repo.DeletePod(context.Background(), "default", podID)
 
     // Assert
     assert.NoError(t, err)
@@ -218,10 +269,15 @@ func TestEtcdPodRepositoryDeletePodErrorOnDelete(t *testing.T) {
     podID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), podsKey+podID).
-        Return(nil, errors.New("etcd delete error")).Times(1)
+        Get(gomock.Any(), podsKey+"default/"+podID).
+        Return(&clientv3.GetResponse{
+            Kvs: []*mvccpb.KeyValue{{Key: []byte(podsKey + "default/1"), Value: []byte(`{"id": "1"}`), ModRevision: 7}},
+        }, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformDeleteTransaction(gomock.Any(), podsKey+"default/"+podID, int64(7), shared.PodResource).
+        Return(errors.New("etcd delete error")).Times(1)
 
-    err := repo.DeletePod(podID)
+    err := repo.DeletePod(context.Background(), "default", podID)
     assert.Error(t, err)
     assert.Equal(t, "etcd delete error", err.Error())
 }
@@ -237,10 +293,10 @@ func TestEtcdPodRepositoryDeletePodNotFound(t *testing.T) {
     podID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), podsKey+podID).
-        Return(&clientv3.DeleteResponse{Deleted: 0}, nil).Times(1)
+        Get(gomock.Any(), podsKey+"default/"+podID).
+        Return(&clientv3.GetResponse{}, nil).Times(1)
 
-    err := repo.DeletePod(podID)
+    err := repo.DeletePod(context.Background(), "default", podID)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
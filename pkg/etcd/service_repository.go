@@ -1,118 +1,48 @@
 package etcd
 
 import (
-	"context"
-	"encoding/json"
 	"maden/pkg/shared"
-	"time"
 
-	clientv3 "go.etcd.io/etcd/client/v3"
+	"context"
 )
 
 var servicesKey = "services/"
 
 type EtcdServiceRepository struct {
-	client EtcdClient
-	transactioner Transactioner
+	store *Store[shared.Service]
 }
 
 func NewEtcdServiceRepository(
 	client EtcdClient,
 	transactioner Transactioner,
 ) ServiceRepository {
-	return &EtcdServiceRepository{client: client, transactioner: transactioner}
+	store := NewStore(client, transactioner, servicesKey, shared.ServiceResource,
+		func(service *shared.Service) string { return ObjectKey(service.Namespace, service.Name) },
+		func(service *shared.Service) *int64 { return &service.ResourceVersion },
+	)
+	return &EtcdServiceRepository{store: store}
 }
 
-
-func (repo *EtcdServiceRepository) ListServices() ([]shared.Service, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	resp, err := repo.client.Get(ctx, servicesKey, clientv3.WithPrefix())
-	if err != nil {
-		return nil, err
-	}
-
-	services := make([]shared.Service, 0)
-	for _, kv := range resp.Kvs {
-		var service shared.Service
-		if err := json.Unmarshal(kv.Value, &service); err != nil {
-			return nil, err
-		}
-		services = append(services, service)
-	}
-	return services, nil
+func (repo *EtcdServiceRepository) ListServices(ctx context.Context, namespace string) ([]shared.Service, error) {
+	return repo.store.ListWithPrefix(ctx, namespacePrefix(namespace))
 }
 
-func (repo *EtcdServiceRepository) GetServiceByName(name string) (*shared.Service, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-    defer cancel()
-
-	key := servicesKey + name
-	resp, err := repo.client.Get(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if len(resp.Kvs) == 0 {
-		return nil, &shared.ErrNotFound{Name: name, ResourceType: shared.ServiceResource}
-	}
-
-	var service shared.Service
-	if err := json.Unmarshal(resp.Kvs[0].Value, &service); err != nil {
-		return nil, err
-	}
-	return &service, nil
-} 
-
-func (repo *EtcdServiceRepository) CreateService(service *shared.Service) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-    defer cancel()
-
-    serviceData, err := json.Marshal(service)
-    if err != nil {
-        return err
-    }
-
-	key := servicesKey + service.Name
-
-	return repo.transactioner.PerformTransaction(ctx, key, string(serviceData), shared.ServiceResource)
+func (repo *EtcdServiceRepository) GetServiceByName(ctx context.Context, namespace string, name string) (*shared.Service, error) {
+	return repo.store.Get(ctx, ObjectKey(namespace, name))
 }
 
-func (repo *EtcdServiceRepository) UpdateService(service *shared.Service) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-    defer cancel()
-
-    serviceData, err := json.Marshal(service)
-    if err != nil {
-        return err
-    }
-
-    key := servicesKey + service.Name
-
-    resp, err := repo.client.Put(ctx, key, string(serviceData), clientv3.WithPrevKV())
-    if err != nil {
-        return err
-    }
-
-	if resp.PrevKv == nil {
-		return &shared.ErrNotFound{ID: service.ID, ResourceType: shared.ServiceResource}
-	}
-	return nil
+func (repo *EtcdServiceRepository) CreateService(ctx context.Context, service *shared.Service) error {
+	return repo.store.Create(ctx, service)
 }
 
-func (repo *EtcdServiceRepository) DeleteService(serviceName string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	defer cancel()
-
-	key := servicesKey + serviceName
+func (repo *EtcdServiceRepository) UpdateService(ctx context.Context, service *shared.Service) error {
+	return repo.store.Update(ctx, service)
+}
 
-	resp, err := repo.client.Delete(ctx, key)
-	if err != nil {
-		return err
-	}
+func (repo *EtcdServiceRepository) DeleteService(ctx context.Context, namespace string, serviceName string) error {
+	return repo.store.Delete(ctx, ObjectKey(namespace, serviceName))
+}
 
-	if resp.Deleted == 0 {
-		return &shared.ErrNotFound{ID: serviceName, ResourceType: shared.ServiceResource}
-	}
-	return nil
-}
\ No newline at end of file
+func (repo *EtcdServiceRepository) WatchServices(ctx context.Context) <-chan shared.WatchEvent[shared.Service] {
+	return repo.store.Watch(ctx)
+}
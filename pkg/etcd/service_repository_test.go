@@ -1,11 +1,13 @@
 package etcd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -23,18 +25,18 @@ func TestEtcdServiceRepositoryListServices(t *testing.T) {
     repo := NewEtcdServiceRepository(mockClient, mockTransactioner)
 
 	mockClient.EXPECT().
-		Get(gomock.Any(), servicesKey, gomock.Any()).
+		Get(gomock.Any(), servicesKey+"default/", gomock.Any()).
 		Return(&clientv3.GetResponse{
 			Kvs: []*mvccpb.KeyValue{
 				{
-					Key: []byte(servicesKey + "1"),
+					Key: []byte(servicesKey + "default/1"),
 					Value: []byte(`{"id": "1", "name": "test-service"}`),
 				},
 			},
 		}, nil).Times(1)
 
 	// Act
-	services, err := repo.ListServices()
+	services, err := repo.ListServices(context.Background(), "default")
 
 	// Assert
 	assert.NoError(t, err)
@@ -54,7 +56,7 @@ func TestEtcdServiceRepositoryCreateService(t *testing.T) {
 
     service := &shared.Service{
         ID:   "1",
-        Name: "test-service",
+        ObjectMeta: shared.ObjectMeta{Name: "test-service", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     serviceData, _ := json.Marshal(service)
@@ -62,9 +64,9 @@ func TestEtcdServiceRepositoryCreateService(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(serviceData), shared.ServiceResource).
-        Return(nil).Times(1)
+        Return(int64(1), nil).Times(1)
 
-    err := repo.CreateService(service)
+    err := repo.CreateService(context.Background(), service)
     assert.NoError(t, err)
 }
 
@@ -78,7 +80,7 @@ func TestEtcdServiceRepositoryCreateServiceError(t *testing.T) {
 
     service := &shared.Service{
         ID:   "1",
-        Name: "test-service",
+        ObjectMeta: shared.ObjectMeta{Name: "test-service", UID: "uid-1", CreationTimestamp: time.Unix(1700000000, 0)},
     }
 
     serviceData, _ := json.Marshal(service)
@@ -86,9 +88,9 @@ func TestEtcdServiceRepositoryCreateServiceError(t *testing.T) {
 
     mockTransactioner.EXPECT().
         PerformTransaction(gomock.Any(), key, string(serviceData), shared.ServiceResource).
-        Return(errors.New("transaction failed")).Times(1)
+        Return(int64(0), errors.New("transaction failed")).Times(1)
 
-    err := repo.CreateService(service)
+    err := repo.CreateService(context.Background(), service)
     assert.Error(t, err)
     assert.Equal(t, "transaction failed", err.Error())
 }
@@ -102,14 +104,14 @@ func TestEtcdServiceRepositoryUpdateService(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdServiceRepository(mockClient, mockTransactioner)
 
-    service := &shared.Service{ID: "1", Name: "updated-service"}
+    service := &shared.Service{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-service"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), servicesKey + service.Name, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: &mvccpb.KeyValue{}}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), servicesKey + service.Name, gomock.Any(), int64(0), shared.ServiceResource).
+        Return(int64(5), nil).Times(1)
 
     // Act
-    err := repo.UpdateService(service)
+    err := repo.UpdateService(context.Background(), service)
 
     // Assert
     assert.NoError(t, err)
@@ -123,13 +125,13 @@ func TestEtcdServiceRepositoryUpdateServiceErrorOnPut(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdServiceRepository(mockClient, mockTransactioner)
 
-    service := &shared.Service{ID: "1", Name: "updated-service"}
+    service := &shared.Service{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-service"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), servicesKey + service.Name, gomock.Any(), gomock.Any()).
-        Return(nil, errors.New("etcd put error")).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), servicesKey + service.Name, gomock.Any(), int64(0), shared.ServiceResource).
+        Return(int64(0), errors.New("etcd put error")).Times(1)
 
-    err := repo.UpdateService(service)
+    err := repo.UpdateService(context.Background(), service)
     assert.Error(t, err)
     assert.Equal(t, "etcd put error", err.Error())
 }
@@ -142,13 +144,13 @@ func TestEtcdServiceRepositoryUpdateServiceNoPreviousKeyValue(t *testing.T) {
 	mockTransactioner := mocks.NewMockTransactioner(ctrl)
     repo := NewEtcdServiceRepository(mockClient, mockTransactioner)
 
-    service := &shared.Service{ID: "1", Name: "updated-service"}
+    service := &shared.Service{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "updated-service"}}
 
-    mockClient.EXPECT().
-        Put(gomock.Any(), servicesKey+service.Name, gomock.Any(), gomock.Any()).
-        Return(&clientv3.PutResponse{PrevKv: nil}, nil).Times(1)
+    mockTransactioner.EXPECT().
+        PerformUpdateTransaction(gomock.Any(), servicesKey+service.Name, gomock.Any(), int64(0), shared.ServiceResource).
+        Return(int64(0), &shared.ErrNotFound{ID: servicesKey+service.Name, ResourceType: shared.ServiceResource}).Times(1)
 
-    err := repo.UpdateService(service)
+    err := repo.UpdateService(context.Background(), service)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
@@ -165,11 +167,11 @@ func TestEtcdServiceRepositoryDeleteService(t *testing.T) {
     serviceID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), servicesKey+serviceID).
+        Delete(gomock.Any(), servicesKey+"default/"+serviceID).
         Return(&clientv3.DeleteResponse{Deleted: 1}, nil).Times(1)
 
     // Act
-    err := repo.DeleteService(serviceID)
+    err := repo.DeleteService(context.Background(), "default", serviceID)
 
     // Assert
     assert.NoError(t, err)
@@ -186,10 +188,10 @@ func TestEtcdServiceRepositoryDeleteServiceErrorOnDelete(t *testing.T) {
     serviceID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), servicesKey+serviceID).
+        Delete(gomock.Any(), servicesKey+"default/"+serviceID).
         Return(nil, errors.New("etcd delete error")).Times(1)
 
-    err := repo.DeleteService(serviceID)
+    err := repo.DeleteService(context.Background(), "default", serviceID)
     assert.Error(t, err)
     assert.Equal(t, "etcd delete error", err.Error())
 }
@@ -205,10 +207,10 @@ func TestEtcdServiceRepositoryDeleteServiceNotFound(t *testing.T) {
     serviceID := "1"
 
     mockClient.EXPECT().
-        Delete(gomock.Any(), servicesKey+serviceID).
+        Delete(gomock.Any(), servicesKey+"default/"+serviceID).
         Return(&clientv3.DeleteResponse{Deleted: 0}, nil).Times(1)
 
-    err := repo.DeleteService(serviceID)
+    err := repo.DeleteService(context.Background(), "default", serviceID)
     assert.Error(t, err)
     assert.IsType(t, &shared.ErrNotFound{}, err)
 }
@@ -0,0 +1,358 @@
+package etcd
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Upper bound of every etcd request, on top of whatever deadline the caller's context carries
+const requestTimeout = 5 * time.Second
+
+// JSON-encoded objects of a single kind, stored under a common key prefix.
+// The resource version of the objects is kept in sync with the etcd revision of their last write
+type Store[T any] struct {
+	client            EtcdClient
+	transactioner     Transactioner
+	prefix            string
+	resourceType      shared.ResourceType
+	keyOf             func(object *T) string
+	resourceVersionOf func(object *T) *int64
+	indexPrefix       string
+	indexKeyOf        func(object *T) string
+}
+
+// keyOf returns the key of an object below the prefix, see ObjectKey
+func NewStore[T any](
+	client EtcdClient,
+	transactioner Transactioner,
+	prefix string,
+	resourceType shared.ResourceType,
+	keyOf func(object *T) string,
+	resourceVersionOf func(object *T) *int64,
+) *Store[T] {
+	return &Store[T]{
+		client:            client,
+		transactioner:     transactioner,
+		prefix:            prefix,
+		resourceType:      resourceType,
+		keyOf:             keyOf,
+		resourceVersionOf: resourceVersionOf,
+	}
+}
+
+// Keeps an index of the objects below prefix, written and deleted in the same transaction as the objects, so that
+// ListIndexed finds them without listing the store. indexKeyOf is empty for objects left out of the index and must
+// not change once an object is created
+func (s *Store[T]) WithIndex(prefix string, indexKeyOf func(object *T) string) *Store[T] {
+	s.indexPrefix = prefix
+	s.indexKeyOf = indexKeyOf
+	return s
+}
+
+// Joins the parts identifying an object, such as its namespace and name, into its key below the prefix of a store.
+// Empty parts are skipped
+func ObjectKey(parts ...string) string {
+	nonEmptyParts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmptyParts = append(nonEmptyParts, part)
+		}
+	}
+	return strings.Join(nonEmptyParts, "/")
+}
+
+// Key prefix of the objects of a namespace, to be used with ListWithPrefix. Empty for all namespaces
+func namespacePrefix(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	return namespace + "/"
+}
+
+func (s *Store[T]) List(ctx context.Context) ([]T, error) {
+	return s.ListWithPrefix(ctx, "")
+}
+
+// Lists the objects whose key below the prefix of the store starts with keyPrefix
+func (s *Store[T]) ListWithPrefix(ctx context.Context, keyPrefix string) ([]T, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.Get(ctx, s.prefix+keyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]T, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		object, err := s.decode(kv.Value, kv.ModRevision)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, *object)
+	}
+	return objects, nil
+}
+
+// Lists the objects whose index key starts with indexKeyPrefix, see WithIndex
+func (s *Store[T]) ListIndexed(ctx context.Context, indexKeyPrefix string) ([]T, error) {
+	listCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.Get(listCtx, s.indexPrefix+indexKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]T, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		object, err := s.Get(ctx, string(kv.Value))
+		if err != nil {
+			var errNotFound *shared.ErrNotFound
+			if errors.As(err, &errNotFound) {
+				continue // Deleted since the index was read
+			}
+			return nil, err
+		}
+		objects = append(objects, *object)
+	}
+	return objects, nil
+}
+
+func (s *Store[T]) Get(ctx context.Context, key string) (*T, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.Get(ctx, s.prefix+key)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, &shared.ErrNotFound{ID: key, ResourceType: s.resourceType}
+	}
+
+	return s.decode(resp.Kvs[0].Value, resp.Kvs[0].ModRevision)
+}
+
+// Fails with ErrDuplicateResource if an object with the same key exists already.
+// Objects embedding ObjectMeta get their UID and creation timestamp here
+func (s *Store[T]) Create(ctx context.Context, object *T) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	if object, ok := any(object).(shared.Object); ok {
+		meta := object.GetObjectMeta()
+		if meta.UID == "" {
+			meta.UID = uuid.New().String()
+		}
+		if meta.CreationTimestamp.IsZero() {
+			meta.CreationTimestamp = time.Now()
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	resourceVersion, err := s.transactioner.PerformTransaction(ctx, s.prefix+s.keyOf(object), string(data), s.resourceType, s.indexOps(object, true)...)
+	if err != nil {
+		return err
+	}
+
+	*s.resourceVersionOf(object) = resourceVersion
+	return nil
+}
+
+// Fails with ErrConflict if the object was modified since it was read, unless it carries no resource version
+func (s *Store[T]) Update(ctx context.Context, object *T) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	resourceVersionRef := s.resourceVersionOf(object)
+	resourceVersion, err := s.transactioner.PerformUpdateTransaction(ctx, s.prefix+s.keyOf(object), string(data), *resourceVersionRef, s.resourceType)
+	if err != nil {
+		return err
+	}
+
+	*resourceVersionRef = resourceVersion
+	return nil
+}
+
+// Creates or overwrites the object unconditionally, for objects with a single writer
+func (s *Store[T]) Put(ctx context.Context, object *T) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Put(ctx, s.prefix+s.keyOf(object), string(data))
+	if err != nil {
+		return err
+	}
+
+	*s.resourceVersionOf(object) = resp.Header.GetRevision()
+	return nil
+}
+
+func (s *Store[T]) Delete(ctx context.Context, key string) error {
+	if s.indexKeyOf != nil {
+		return s.deleteIndexed(ctx, key)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.Delete(ctx, s.prefix+key)
+	if err != nil {
+		return err
+	}
+	if resp.Deleted == 0 {
+		return &shared.ErrNotFound{ID: key, ResourceType: s.resourceType}
+	}
+	return nil
+}
+
+// The object is read first for its index key, and only deleted if it did not change in between
+func (s *Store[T]) deleteIndexed(ctx context.Context, key string) error {
+	return shared.RetryOnConflict(func() error {
+		object, err := s.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		deleteCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		return s.transactioner.PerformDeleteTransaction(deleteCtx, s.prefix+key, *s.resourceVersionOf(object), s.resourceType, s.indexOps(object, false)...)
+	})
+}
+
+// Writes or deletes the index entry of object, which holds the key of the object. None for objects left out of the index
+func (s *Store[T]) indexOps(object *T, write bool) []clientv3.Op {
+	if s.indexKeyOf == nil {
+		return nil
+	}
+	indexKey := s.indexKeyOf(object)
+	if indexKey == "" {
+		return nil
+	}
+
+	if write {
+		return []clientv3.Op{clientv3.OpPut(s.indexPrefix+indexKey, s.keyOf(object))}
+	}
+	return []clientv3.Op{clientv3.OpDelete(s.indexPrefix + indexKey)}
+}
+
+// Deletes every object whose key below the prefix of the store starts with keyPrefix
+func (s *Store[T]) DeleteWithPrefix(ctx context.Context, keyPrefix string) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	_, err := s.client.Delete(ctx, s.prefix+keyPrefix, clientv3.WithPrefix())
+	return err
+}
+
+// Streams the changes of the objects until ctx is done, at which point the channel is closed.
+// Objects that fail to decode are logged and skipped
+func (s *Store[T]) Watch(ctx context.Context) <-chan shared.WatchEvent[T] {
+	events := make(chan shared.WatchEvent[T])
+
+	go func() {
+		defer close(events)
+
+		for wresp := range s.client.Watch(ctx, s.prefix, clientv3.WithPrefix(), clientv3.WithPrevKV()) {
+			if err := watchError(wresp); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case events <- shared.WatchEvent[T]{Type: shared.WatchEventError, Err: fmt.Errorf("watch of %s failed: %w", s.resourceType, err)}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			for _, ev := range wresp.Events {
+				event, err := s.decodeEvent(ev)
+				if err != nil {
+					shared.Log.Errorf("Failed to decode %s event: %v", s.resourceType, err)
+					continue
+				}
+
+				select {
+				case events <- *event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// Why etcd ended the watch, e.g. because the watched revision was compacted
+func watchError(wresp clientv3.WatchResponse) error {
+	if err := wresp.Err(); err != nil {
+		return err
+	}
+	if wresp.Canceled {
+		return errors.New("watch canceled by etcd")
+	}
+	return nil
+}
+
+func (s *Store[T]) decodeEvent(ev *clientv3.Event) (*shared.WatchEvent[T], error) {
+	if ev.Type == clientv3.EventTypeDelete {
+		if ev.PrevKv == nil {
+			return nil, &shared.ErrNotFound{ID: strings.TrimPrefix(string(ev.Kv.Key), s.prefix), ResourceType: s.resourceType}
+		}
+		object, err := s.decode(ev.PrevKv.Value, ev.PrevKv.ModRevision)
+		if err != nil {
+			return nil, err
+		}
+		return &shared.WatchEvent[T]{Type: shared.WatchEventDelete, Object: object}, nil
+	}
+
+	object, err := s.decode(ev.Kv.Value, ev.Kv.ModRevision)
+	if err != nil {
+		return nil, err
+	}
+	if ev.IsCreate() || ev.PrevKv == nil {
+		return &shared.WatchEvent[T]{Type: shared.WatchEventCreate, Object: object}, nil
+	}
+
+	prevObject, err := s.decode(ev.PrevKv.Value, ev.PrevKv.ModRevision)
+	if err != nil {
+		return nil, err
+	}
+	return &shared.WatchEvent[T]{Type: shared.WatchEventUpdate, Object: object, PrevObject: prevObject}, nil
+}
+
+func (s *Store[T]) decode(data []byte, modRevision int64) (*T, error) {
+	var object T
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	*s.resourceVersionOf(&object) = modRevision
+	return &object, nil
+}
@@ -0,0 +1,157 @@
+package etcd
+
+import (
+	"context"
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func newTestPodStore(client EtcdClient) *Store[shared.Pod] {
+	return NewStore(client, nil, podsKey, shared.PodResource,
+		func(pod *shared.Pod) string { return pod.ID },
+		func(pod *shared.Pod) *int64 { return &pod.ResourceVersion },
+	)
+}
+
+func TestObjectKeySkipsEmptyParts(t *testing.T) {
+	assert.Equal(t, "default/web", ObjectKey("default", "web"))
+	assert.Equal(t, "web", ObjectKey("", "web"))
+	assert.Equal(t, "web/3", ObjectKey("web", "", "3"))
+}
+
+func TestStoreGetSetsResourceVersion(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	store := newTestPodStore(mockClient)
+
+	mockClient.EXPECT().
+		Get(gomock.Any(), podsKey+"1").
+		Return(&clientv3.GetResponse{
+			Kvs: []*mvccpb.KeyValue{
+				{Key: []byte(podsKey + "1"), Value: []byte(`{"id": "1"}`), ModRevision: 42},
+			},
+		}, nil)
+
+	// Act
+	pod, err := store.Get(context.Background(), "1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "1", pod.ID)
+	assert.Equal(t, int64(42), pod.ResourceVersion)
+}
+
+func TestStoreWatchDecodesEvents(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	store := newTestPodStore(mockClient)
+
+	watchChan := make(chan clientv3.WatchResponse, 1)
+	watchChan <- clientv3.WatchResponse{
+		Events: []*clientv3.Event{
+			{
+				Type: clientv3.EventTypePut,
+				Kv:   &mvccpb.KeyValue{Value: []byte(`{"id": "1"}`), CreateRevision: 5, ModRevision: 5},
+			},
+			{
+				Type:   clientv3.EventTypePut,
+				Kv:     &mvccpb.KeyValue{Value: []byte(`{"id": "1", "nodeID": "node-1"}`), CreateRevision: 5, ModRevision: 6},
+				PrevKv: &mvccpb.KeyValue{Value: []byte(`{"id": "1"}`), CreateRevision: 5, ModRevision: 5},
+			},
+			{
+				Type:   clientv3.EventTypeDelete,
+				Kv:     &mvccpb.KeyValue{Key: []byte(podsKey + "1"), ModRevision: 7},
+				PrevKv: &mvccpb.KeyValue{Value: []byte(`{"id": "1", "nodeID": "node-1"}`), CreateRevision: 5, ModRevision: 6},
+			},
+		},
+	}
+	close(watchChan)
+
+	mockClient.EXPECT().
+		Watch(gomock.Any(), podsKey, gomock.Any(), gomock.Any()).
+		Return(clientv3.WatchChan(watchChan))
+
+	// Act
+	events := make([]shared.WatchEvent[shared.Pod], 0)
+	for event := range store.Watch(context.Background()) {
+		events = append(events, event)
+	}
+
+	// Assert
+	assert.Len(t, events, 3)
+	assert.Equal(t, shared.WatchEventCreate, events[0].Type)
+	assert.Equal(t, int64(5), events[0].Object.ResourceVersion)
+	assert.Equal(t, shared.WatchEventUpdate, events[1].Type)
+	assert.Equal(t, "node-1", events[1].Object.NodeID)
+	assert.Equal(t, "", events[1].PrevObject.NodeID)
+	assert.Equal(t, shared.WatchEventDelete, events[2].Type)
+	assert.Equal(t, "node-1", events[2].Object.NodeID)
+}
+
+func TestStoreWatchReportsFailure(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEtcdClient(ctrl)
+	store := newTestPodStore(mockClient)
+
+	// etcd cancels watches whose revision was compacted
+	watchChan := make(chan clientv3.WatchResponse, 1)
+	watchChan <- clientv3.WatchResponse{Canceled: true, CompactRevision: 3}
+	close(watchChan)
+
+	mockClient.EXPECT().
+		Watch(gomock.Any(), podsKey, gomock.Any(), gomock.Any()).
+		Return(clientv3.WatchChan(watchChan))
+
+	// Act
+	events := make([]shared.WatchEvent[shared.Pod], 0)
+	for event := range store.Watch(context.Background()) {
+		events = append(events, event)
+	}
+
+	// Assert
+	assert.Len(t, events, 1)
+	assert.Equal(t, shared.WatchEventError, events[0].Type)
+	assert.Nil(t, events[0].Object)
+	assert.ErrorContains(t, events[0].Err, "compacted")
+}
+
+func TestStoreCreateSetsObjectMeta(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactioner := mocks.NewMockTransactioner(ctrl)
+	store := NewStore(nil, mockTransactioner, podsKey, shared.PodResource,
+		func(pod *shared.Pod) string { return pod.ID },
+		func(pod *shared.Pod) *int64 { return &pod.ResourceVersion },
+	)
+
+	pod := &shared.Pod{ID: "1", ObjectMeta: shared.ObjectMeta{Name: "web"}}
+	mockTransactioner.EXPECT().
+		PerformTransaction(gomock.Any(), podsKey+"1", gomock.Any(), shared.PodResource).
+		Return(int64(3), nil)
+
+	// Act
+	err := store.Create(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pod.UID)
+	assert.False(t, pod.CreationTimestamp.IsZero())
+	assert.Equal(t, int64(3), pod.ResourceVersion)
+}
@@ -15,18 +15,67 @@ func NewEtcdTransactionRepository(client EtcdClient) Transactioner {
 	return &EtcdTransactionRepository{client: client}
 }
 
-func (etr *EtcdTransactionRepository) PerformTransaction(ctx context.Context, key string, value string, resourceType shared.ResourceType) error {
+// Creates the key if it does not exist yet, along with ops, and returns the revision of the write
+func (etr *EtcdTransactionRepository) PerformTransaction(ctx context.Context, key string, value string, resourceType shared.ResourceType, ops ...clientv3.Op) (int64, error) {
 	txnResp, err := etr.client.Txn(ctx).
 		If(clientv3.Compare(clientv3.Version(key), "=", 0)).
+		Then(append([]clientv3.Op{clientv3.OpPut(key, value)}, ops...)...).
+		Else(clientv3.OpGet(key)).
+		Commit()
+
+	if err != nil {
+		return 0, err
+	}
+	if !txnResp.Succeeded {
+		return 0, &shared.ErrDuplicateResource{ID: key, ResourceType: resourceType}
+	}
+	return txnResp.Header.Revision, nil
+}
+
+// Overwrites the key only if it was not modified since resourceVersion, and returns the revision of the write.
+// A resourceVersion of 0 skips the check, so callers that never read the object still replace it
+func (etr *EtcdTransactionRepository) PerformUpdateTransaction(ctx context.Context, key string, value string, resourceVersion int64, resourceType shared.ResourceType) (int64, error) {
+	condition := clientv3.Compare(clientv3.ModRevision(key), "=", resourceVersion)
+	if resourceVersion == 0 {
+		condition = clientv3.Compare(clientv3.Version(key), ">", 0)
+	}
+
+	txnResp, err := etr.client.Txn(ctx).
+		If(condition).
 		Then(clientv3.OpPut(key, value)).
 		Else(clientv3.OpGet(key)).
 		Commit()
 
+	if err != nil {
+		return 0, err
+	}
+	if !txnResp.Succeeded {
+		getResp := txnResp.Responses[0].GetResponseRange()
+		if getResp == nil || len(getResp.Kvs) == 0 {
+			return 0, &shared.ErrNotFound{ID: key, ResourceType: resourceType}
+		}
+		return 0, &shared.ErrConflict{ID: key, ResourceType: resourceType}
+	}
+	return txnResp.Header.Revision, nil
+}
+
+// Deletes the key, along with ops, only if it was not modified since resourceVersion
+func (etr *EtcdTransactionRepository) PerformDeleteTransaction(ctx context.Context, key string, resourceVersion int64, resourceType shared.ResourceType, ops ...clientv3.Op) error {
+	txnResp, err := etr.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", resourceVersion)).
+		Then(append([]clientv3.Op{clientv3.OpDelete(key)}, ops...)...).
+		Else(clientv3.OpGet(key)).
+		Commit()
+
 	if err != nil {
 		return err
 	}
 	if !txnResp.Succeeded {
-		return &shared.ErrDuplicateResource{ID: key, ResourceType: resourceType}
+		getResp := txnResp.Responses[0].GetResponseRange()
+		if getResp == nil || len(getResp.Kvs) == 0 {
+			return &shared.ErrNotFound{ID: key, ResourceType: resourceType}
+		}
+		return &shared.ErrConflict{ID: key, ResourceType: resourceType}
 	}
 	return nil
-}
\ No newline at end of file
+}
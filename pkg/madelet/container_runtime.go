@@ -4,13 +4,21 @@ import (
 	"maden/pkg/shared"
 
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
 )
 
 func NewDockerClient(client *client.Client) DockerClient {
@@ -33,11 +41,202 @@ func NewContainerRuntimeInterface(client DockerClient) ContainerRuntimeInterface
 	return &DockerRuntime{Client: client}
 }
 
-func (d *DockerRuntime) CreateContainer(image string) (string, error) {
+func (d *DockerRuntime) ImageExists(image string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, _, err := d.Client.ImageInspectWithRaw(ctx, image)
+	if client.IsErrNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Message of the progress stream of an image pull, one per line
+type pullMessage struct {
+	Status         string `json:"status"`
+	ID             string `json:"id"` // Layer the status is about
+	ProgressDetail struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	ErrorMessage string `json:"error"`
+}
+
+// Pulls the image, reporting the overall progress of its layers as it goes
+func (d *DockerRuntime) PullImage(ctx context.Context, imageName string, onProgress func(progress string)) error {
+	stream, err := d.Client.ImagePull(ctx, imageName, image.PullOptions{})
+	if err != nil {
+		shared.Log.Errorf("Failed to pull image %s: %v", imageName, err)
+		return err
+	}
+	defer stream.Close()
+
+	progress := newPullProgress()
+	decoder := json.NewDecoder(stream)
+	for {
+		var message pullMessage
+		if err := decoder.Decode(&message); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if message.ErrorMessage != "" {
+			return errors.New(message.ErrorMessage)
+		}
+
+		if progress.record(message) {
+			onProgress(progress.String())
+		}
+	}
+}
+
+type layerProgress struct {
+	current int64
+	total   int64
+	done    bool
+}
+
+// Progress of the layers of an image pull, in the order Docker reported them
+type pullProgress struct {
+	layers   map[string]*layerProgress
+	layerIDs []string
+}
+
+func newPullProgress() *pullProgress {
+	return &pullProgress{layers: make(map[string]*layerProgress)}
+}
+
+// Returns whether the message changed the progress
+func (p *pullProgress) record(message pullMessage) bool {
+	if message.ID == "" {
+		return false
+	}
+
+	layer, exists := p.layers[message.ID]
+	if !exists {
+		layer = &layerProgress{}
+		p.layers[message.ID] = layer
+		p.layerIDs = append(p.layerIDs, message.ID)
+	}
+
+	switch message.Status {
+	case "Downloading":
+		layer.current, layer.total = message.ProgressDetail.Current, message.ProgressDetail.Total
+	case "Download complete", "Pull complete", "Already exists":
+		layer.current = layer.total
+		layer.done = true
+	default:
+		return !exists
+	}
+	return true
+}
+
+// E.g. "2/5 layers done, 12.3MB/45.6MB downloaded"
+func (p *pullProgress) String() string {
+	var done int
+	var current, total int64
+	for _, id := range p.layerIDs {
+		layer := p.layers[id]
+		if layer.done {
+			done++
+		}
+		current += layer.current
+		total += layer.total
+	}
+	return fmt.Sprintf("%d/%d layers done, %.1fMB/%.1fMB downloaded", done, len(p.layerIDs), float64(current)/1e6, float64(total)/1e6)
+}
+
+// IP of the container on the default bridge, or else on the first network that gave it one
+func containerIP(inspect types.ContainerJSON) string {
+	if inspect.NetworkSettings == nil {
+		return ""
+	}
+	if inspect.NetworkSettings.IPAddress != "" {
+		return inspect.NetworkSettings.IPAddress
+	}
+	for _, endpoint := range inspect.NetworkSettings.Networks {
+		if endpoint != nil && endpoint.IPAddress != "" {
+			return endpoint.IPAddress
+		}
+	}
+	return ""
+}
+
+// ID of the Docker daemon, which tells the hosts the nodes run their containers on apart
+func (d *DockerRuntime) HostID() (string, error) {
+	info, err := d.Client.Info(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return info.ID, nil
+}
+
+// Creates the bridge network the sandboxes join unless it exists already
+func (d *DockerRuntime) EnsureNetwork(name string, cidr string) error {
+	ctx := context.Background()
+	existing, err := d.Client.NetworkInspect(ctx, name, types.NetworkInspectOptions{})
+	if err == nil {
+		for _, config := range existing.IPAM.Config {
+			if config.Subnet == cidr {
+				return nil
+			}
+		}
+		return fmt.Errorf("network %s exists already without subnet %s", name, cidr)
+	}
+	if !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	_, err = d.Client.NetworkCreate(ctx, name, types.NetworkCreate{
+		Driver: "bridge",
+		IPAM:   &network.IPAM{Config: []network.IPAMConfig{{Subnet: cidr}}},
+	})
+	if err != nil {
+		shared.Log.Errorf("Failed to create network %s: %v", name, err)
+		return err
+	}
+	return nil
+}
+
+// Creates the container holding the network namespace of a pod and publishing the ports of all its containers,
+// attached to networkName at ip when the node has a pod network and to the default bridge of Docker otherwise
+func (d *DockerRuntime) CreateSandbox(image string, ports []shared.Port, networkName string, ip string) (string, error) {
 	ctx := context.Background()
-	resp, err := d.Client.ContainerCreate(ctx, &container.Config{
-		Image: image,
-	}, nil, nil, nil, "")
+	config := &container.Config{Image: image}
+	hostConfig := &container.HostConfig{}
+	if err := addPorts(config, hostConfig, ports); err != nil {
+		return "", err
+	}
+
+	var networkingConfig *network.NetworkingConfig
+	if networkName != "" {
+		hostConfig.NetworkMode = container.NetworkMode(networkName)
+		networkingConfig = &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{
+			networkName: {IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: ip}},
+		}}
+	}
+
+	resp, err := d.Client.ContainerCreate(ctx, config, hostConfig, networkingConfig, nil, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to create sandbox container: %v", err)
+		return "", err
+	}
+
+	return resp.ID, nil
+}
+
+// Containers created with a sandbox share its network namespace, so they reach each other over localhost
+func (d *DockerRuntime) CreateContainer(spec shared.Container, sandboxID string) (string, error) {
+	ctx := context.Background()
+	config, hostConfig := dockerContainerConfig(spec)
+	if sandboxID != "" {
+		hostConfig.NetworkMode = container.NetworkMode("container:" + sandboxID)
+	} else if err := addPorts(config, hostConfig, spec.Ports); err != nil {
+		return "", err
+	}
+
+	resp, err := d.Client.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
 	if err != nil {
 		shared.Log.Errorf("Failed to create container: %v", err)
 		return "", err
@@ -46,6 +245,50 @@ func (d *DockerRuntime) CreateContainer(image string) (string, error) {
 	return resp.ID, nil
 }
 
+// Translates the container spec into its Docker counterpart, with CPU and memory limits enforced by Docker
+func dockerContainerConfig(spec shared.Container) (*container.Config, *container.HostConfig) {
+	config := &container.Config{
+		Image:      spec.Image,
+		Entrypoint: spec.Command,
+		Cmd:        spec.Args,
+		WorkingDir: spec.WorkingDir,
+	}
+	for _, env := range spec.Env {
+		config.Env = append(config.Env, env.Name+"="+env.Value)
+	}
+
+	hostConfig := &container.HostConfig{
+		Resources: container.Resources{
+			NanoCPUs: int64(spec.Resources.Limits.CPU) * 1e9,
+			Memory:   int64(spec.Resources.Limits.Memory) * 1024 * 1024,
+		},
+	}
+	return config, hostConfig
+}
+
+// Exposes the ports and publishes those with a host port on the node
+func addPorts(config *container.Config, hostConfig *container.HostConfig, ports []shared.Port) error {
+	config.ExposedPorts = nat.PortSet{}
+	hostConfig.PortBindings = nat.PortMap{}
+	for _, port := range ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		containerPort, err := nat.NewPort(strings.ToLower(protocol), strconv.Itoa(port.ContainerPort))
+		if err != nil {
+			return err
+		}
+
+		config.ExposedPorts[containerPort] = struct{}{}
+		if port.HostPort != 0 {
+			binding := nat.PortBinding{HostPort: strconv.Itoa(port.HostPort)}
+			hostConfig.PortBindings[containerPort] = append(hostConfig.PortBindings[containerPort], binding)
+		}
+	}
+	return nil
+}
+
 func (d *DockerRuntime) StartContainer(containerID string) error {
 	ctx := context.Background()
 	if err := d.Client.ContainerStart(ctx, containerID, container.StartOptions{}); err != nil {
@@ -91,7 +334,7 @@ func (d *DockerRuntime) GetContainerLogs(ctx context.Context, containerID string
 	return d.Client.ContainerLogs(ctx, containerID, options)
 }
 
-func (d *DockerRuntime) GetContainerStatus(containerID string) (shared.ContainerStatus, error) {
+func (d *DockerRuntime) GetContainerStatus(containerID string) (shared.ContainerRuntimeStatus, error) {
 	if containerID == "" {
 		shared.Log.Errorf("Empty container ID provided")
 		return shared.Dead, fmt.Errorf("empty container ID")
@@ -114,6 +357,17 @@ func (d *DockerRuntime) GetContainerStatus(containerID string) (shared.Container
 	return *containerStatus, nil
 }
 
+func (d *DockerRuntime) InspectContainer(containerID string) (types.ContainerJSON, error) {
+	if containerID == "" {
+		return types.ContainerJSON{}, fmt.Errorf("empty container ID")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return d.Client.ContainerInspect(ctx, containerID)
+}
+
 func (d *DockerRuntime) ExecCommandCreate(ctx context.Context, containerID string, execConfig types.ExecConfig) (string, error) {
 	execID, err := d.Client.ContainerExecCreate(ctx, containerID, execConfig)
 	if err != nil {
@@ -133,3 +387,7 @@ func (d *DockerRuntime) ExecCommandAttach(ctx context.Context, execID string, at
 
 	return &execAttach, nil
 }
+
+func (d *DockerRuntime) ExecCommandInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error) {
+	return d.Client.ContainerExecInspect(ctx, execID)
+}
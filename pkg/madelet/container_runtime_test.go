@@ -3,13 +3,19 @@ package madelet
 import (
 	"context"
 	"errors"
+	"io"
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
 	"github.com/golang/mock/gomock"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,28 +30,204 @@ func TestCreateContainer(t *testing.T) {
 
 	ctx := context.Background()
 	containerID := "abc123"
-	image := "nginx:latest"
+	spec := shared.Container{Image: "nginx:latest"}
 
-	mockClient.EXPECT().ContainerCreate(ctx, gomock.Any(), nil, nil, nil, "").Return(container.CreateResponse{ID: containerID}, nil)
+	mockClient.EXPECT().ContainerCreate(ctx, gomock.Any(), gomock.Any(), nil, nil, "").Return(container.CreateResponse{ID: containerID}, nil)
 
 	// Act
-	id, err := runtime.CreateContainer(image)
+	id, err := runtime.CreateContainer(spec, "")
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, containerID, id)
 
 	mockClient.EXPECT().
-		ContainerCreate(ctx, gomock.Any(), nil, nil, nil, "").
+		ContainerCreate(ctx, gomock.Any(), gomock.Any(), nil, nil, "").
 		Return(container.CreateResponse{}, errors.New("error creating container"))
 
 	// Act
-	_, err = runtime.CreateContainer(image)
+	_, err = runtime.CreateContainer(spec, "")
 
 	// Assert
 	assert.Error(t, err)
 }
 
+func TestDockerContainerConfig(t *testing.T) {
+	// Arrange
+	spec := shared.Container{
+		Image:      "nginx:latest",
+		Command:    []string{"nginx"},
+		Args:       []string{"-g", "daemon off;"},
+		Env:        []shared.EnvVar{{Name: "MODE", Value: "production"}},
+		WorkingDir: "/srv",
+		Resources:  shared.ResourceRequirements{Limits: shared.Resources{CPU: 2, Memory: 512}},
+	}
+
+	// Act
+	config, hostConfig := dockerContainerConfig(spec)
+
+	// Assert
+	assert.Equal(t, "nginx:latest", config.Image)
+	assert.Equal(t, []string{"nginx"}, []string(config.Entrypoint))
+	assert.Equal(t, []string{"-g", "daemon off;"}, []string(config.Cmd))
+	assert.Equal(t, []string{"MODE=production"}, config.Env)
+	assert.Equal(t, "/srv", config.WorkingDir)
+	assert.Equal(t, int64(2e9), hostConfig.NanoCPUs)
+	assert.Equal(t, int64(512*1024*1024), hostConfig.Memory)
+}
+
+func TestCreateSandboxPublishesPorts(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockDockerClient(ctrl)
+	runtime := NewContainerRuntimeInterface(mockClient)
+	ports := []shared.Port{{ContainerPort: 80, HostPort: 8080}, {ContainerPort: 53, Protocol: "UDP"}}
+
+	mockClient.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").DoAndReturn(
+		func(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *ocispec.Platform, _ string) (container.CreateResponse, error) {
+			assert.Equal(t, DefaultSandboxImage, config.Image)
+			assert.Contains(t, config.ExposedPorts, nat.Port("80/tcp"))
+			assert.Contains(t, config.ExposedPorts, nat.Port("53/udp"))
+			assert.Equal(t, []nat.PortBinding{{HostPort: "8080"}}, hostConfig.PortBindings["80/tcp"])
+			assert.NotContains(t, hostConfig.PortBindings, nat.Port("53/udp"))
+			return container.CreateResponse{ID: "sandboxID"}, nil
+		})
+
+	// Act
+	id, err := runtime.CreateSandbox(DefaultSandboxImage, ports, "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "sandboxID", id)
+}
+
+func TestCreateSandboxJoinsPodNetwork(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockDockerClient(ctrl)
+	runtime := NewContainerRuntimeInterface(mockClient)
+
+	mockClient.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil, "").DoAndReturn(
+		func(_ context.Context, _ *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, _ *ocispec.Platform, _ string) (container.CreateResponse, error) {
+			assert.Equal(t, container.NetworkMode(DefaultPodNetworkName), hostConfig.NetworkMode)
+			assert.Equal(t, "10.244.0.2", networkingConfig.EndpointsConfig[DefaultPodNetworkName].IPAMConfig.IPv4Address)
+			return container.CreateResponse{ID: "sandboxID"}, nil
+		})
+
+	// Act
+	id, err := runtime.CreateSandbox(DefaultSandboxImage, nil, DefaultPodNetworkName, "10.244.0.2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "sandboxID", id)
+}
+
+func TestEnsureNetwork(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockDockerClient(ctrl)
+	runtime := NewContainerRuntimeInterface(mockClient)
+	existing := types.NetworkResource{IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.244.0.0/16"}}}}
+
+	gomock.InOrder(
+		mockClient.EXPECT().NetworkInspect(gomock.Any(), "maden", gomock.Any()).Return(types.NetworkResource{}, errdefs.NotFound(errors.New("network maden not found"))),
+		mockClient.EXPECT().NetworkCreate(gomock.Any(), "maden", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, options types.NetworkCreate) (types.NetworkCreateResponse, error) {
+				assert.Equal(t, "10.244.0.0/16", options.IPAM.Config[0].Subnet)
+				return types.NetworkCreateResponse{ID: "networkID"}, nil
+			}),
+		mockClient.EXPECT().NetworkInspect(gomock.Any(), "maden", gomock.Any()).Times(2).Return(existing, nil),
+	)
+
+	// Act
+	errCreated := runtime.EnsureNetwork("maden", "10.244.0.0/16")
+	errExisting := runtime.EnsureNetwork("maden", "10.244.0.0/16")
+	errOtherSubnet := runtime.EnsureNetwork("maden", "10.245.0.0/16")
+
+	// Assert
+	assert.NoError(t, errCreated)
+	assert.NoError(t, errExisting)
+	assert.Error(t, errOtherSubnet)
+}
+
+func TestCreateContainerJoinsSandbox(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockDockerClient(ctrl)
+	runtime := NewContainerRuntimeInterface(mockClient)
+	spec := shared.Container{Image: "nginx:latest", Ports: []shared.Port{{ContainerPort: 80, HostPort: 8080}}}
+
+	mockClient.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").DoAndReturn(
+		func(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *ocispec.Platform, _ string) (container.CreateResponse, error) {
+			assert.Equal(t, container.NetworkMode("container:sandboxID"), hostConfig.NetworkMode)
+			assert.Empty(t, config.ExposedPorts) // Published by the sandbox
+			assert.Empty(t, hostConfig.PortBindings)
+			return container.CreateResponse{ID: "containerID"}, nil
+		})
+
+	// Act
+	id, err := runtime.CreateContainer(spec, "sandboxID")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "containerID", id)
+}
+
+func TestPullImage(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockDockerClient(ctrl)
+	runtime := NewContainerRuntimeInterface(mockClient)
+
+	stream := strings.Join([]string{
+		`{"status":"Pulling from library/nginx","id":"latest"}`,
+		`{"status":"Pulling fs layer","id":"a"}`,
+		`{"status":"Downloading","id":"a","progressDetail":{"current":500000,"total":2000000}}`,
+		`{"status":"Already exists","id":"b"}`,
+		`{"status":"Download complete","id":"a"}`,
+	}, "\n")
+	mockClient.EXPECT().ImagePull(gomock.Any(), "nginx:latest", gomock.Any()).Return(io.NopCloser(strings.NewReader(stream)), nil)
+
+	// Act
+	var progress []string
+	err := runtime.PullImage(context.Background(), "nginx:latest", func(message string) {
+		progress = append(progress, message)
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "0/2 layers done, 0.5MB/2.0MB downloaded", progress[2])
+	assert.Equal(t, "2/3 layers done, 2.0MB/2.0MB downloaded", progress[len(progress)-1])
+}
+
+func TestPullImageError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockDockerClient(ctrl)
+	runtime := NewContainerRuntimeInterface(mockClient)
+
+	stream := `{"error":"manifest for nginx:nope not found"}`
+	mockClient.EXPECT().ImagePull(gomock.Any(), "nginx:nope", gomock.Any()).Return(io.NopCloser(strings.NewReader(stream)), nil)
+
+	// Act
+	err := runtime.PullImage(context.Background(), "nginx:nope", func(string) {})
+
+	// Assert
+	assert.EqualError(t, err, "manifest for nginx:nope not found")
+}
+
 func TestStartContainer(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,53 @@
+package madelet
+
+import (
+	"maden/pkg/shared"
+
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const memInfoPath = "/proc/meminfo"
+
+// Reads the CPU cores and memory (in MB) of the host the agent runs on
+func ReadHostCapacity() (shared.Resources, error) {
+	file, err := os.Open(memInfoPath)
+	if err != nil {
+		return shared.Resources{}, err
+	}
+	defer file.Close()
+
+	memory, err := parseMemTotal(file)
+	if err != nil {
+		return shared.Resources{}, err
+	}
+
+	return shared.Resources{CPU: runtime.NumCPU(), Memory: memory}, nil
+}
+
+// Extracts the MemTotal entry of /proc/meminfo, which is given in kB
+func parseMemTotal(memInfo io.Reader) (int, error) {
+	scanner := bufio.NewScanner(memInfo)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+
+		memTotalKB, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid MemTotal entry: %v", err)
+		}
+		return memTotalKB / 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("MemTotal entry not found")
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -20,22 +22,40 @@ type DockerClient interface {
 	ContainerLogs(ctx context.Context, container string, options container.LogsOptions) (io.ReadCloser, error)
 	ContainerExecCreate(ctx context.Context, containerID string, config types.ExecConfig) (types.IDResponse, error)
 	ContainerExecAttach(ctx context.Context, execID string, config types.ExecStartCheck) (types.HijackedResponse, error)
+	ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error)
+	ImagePull(ctx context.Context, refStr string, options image.PullOptions) (io.ReadCloser, error)
+	ImageInspectWithRaw(ctx context.Context, imageID string) (types.ImageInspect, []byte, error)
+	NetworkInspect(ctx context.Context, networkID string, options types.NetworkInspectOptions) (types.NetworkResource, error)
+	NetworkCreate(ctx context.Context, name string, options types.NetworkCreate) (types.NetworkCreateResponse, error)
+	Info(ctx context.Context) (system.Info, error)
 }
 
 type ContainerRuntimeInterface interface {
-	CreateContainer(image string) (string, error)
+	ImageExists(image string) (bool, error)
+	PullImage(ctx context.Context, image string, onProgress func(progress string)) error
+	EnsureNetwork(name string, cidr string) error
+	HostID() (string, error)
+	CreateSandbox(image string, ports []shared.Port, networkName string, ip string) (string, error)
+	CreateContainer(spec shared.Container, sandboxID string) (string, error)
 	StartContainer(containerID string) error
 	StopContainer(containerID string) error
 	DeleteContainer(containerID string) error
 	GetContainerLogs(ctx context.Context, containerID string, follow bool) (io.ReadCloser, error)
-	GetContainerStatus(containerID string) (shared.ContainerStatus, error)
+	GetContainerStatus(containerID string) (shared.ContainerRuntimeStatus, error)
+	InspectContainer(containerID string) (types.ContainerJSON, error)
 	ExecCommandCreate(ctx context.Context, containerID string, execConfig types.ExecConfig) (string, error)
 	ExecCommandAttach(ctx context.Context, execID string, attachConfig types.ExecStartCheck, tty bool) (*types.HijackedResponse, error)
+	ExecCommandInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error)
+}
+
+type Prober interface {
+	Probe(ctx context.Context, containerID string, probe *shared.Probe) error
 }
 
 type PodManager interface {
 	RunPod(pod *shared.Pod)
 	StopPod(pod *shared.Pod) error
+	SyncPodStatus(pod *shared.Pod) error
 	GetContainerLogs(ctx context.Context, containerID string, follow bool) (io.ReadCloser, error)
 	ExecuteCommandInContainer(ctx context.Context, containerID string, command string) (string, error)
 }
\ No newline at end of file
@@ -6,49 +6,144 @@ import (
 	"io"
 	"maden/pkg/etcd"
 	"maden/pkg/shared"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types"
 )
 
+// Image of the container holding the network namespace of a pod, it does nothing but sleep
+const DefaultSandboxImage = "registry.k8s.io/pause:3.9"
+
+const (
+	initialRestartBackoff     = 10 * time.Second
+	maxRestartBackoff         = 5 * time.Minute
+	pullProgressInterval      = 2 * time.Second
+	initContainerPollInterval = time.Second
+)
+
+type probeKind string
+
+const (
+	livenessProbe  probeKind = "liveness"
+	readinessProbe probeKind = "readiness"
+	startupProbe   probeKind = "startup"
+)
+
+// Reason of the terminated state of a container stopped for failing its startup or liveness probe
+const unhealthyReason = "Unhealthy"
+
 type PodLifecycleManager struct {
-	Runtime ContainerRuntimeInterface
-	PodRepo etcd.PodRepository
+	Runtime      ContainerRuntimeInterface
+	PodRepo      etcd.PodRepository
+	Prober       Prober
+	Network      PodNetwork
+	SandboxImage string
+
+	now          func() time.Time
+	sleep        func(time.Duration)
+	mutex        sync.Mutex
+	probeResults map[string]*probeResult // By container ID and probe kind
+	unhealthy    map[string]bool         // Containers stopped for failing a probe, until they are restarted
+	networkMutex sync.Mutex
+	networkReady bool
+	hostID       string // Docker daemon the pod network is bound to
 }
 
 func NewPodLifecycleManager(
 	runtime ContainerRuntimeInterface,
 	podRepo etcd.PodRepository,
+	prober Prober,
+	network PodNetwork,
 ) PodManager {
-	return &PodLifecycleManager{Runtime: runtime, PodRepo: podRepo}
+	return &PodLifecycleManager{
+		Runtime:      runtime,
+		PodRepo:      podRepo,
+		Prober:       prober,
+		Network:      network,
+		SandboxImage: DefaultSandboxImage,
+		now:          time.Now,
+		sleep:        time.Sleep,
+		probeResults: make(map[string]*probeResult),
+		unhealthy:    make(map[string]bool),
+	}
 }
 
 func (p *PodLifecycleManager) RunPod(pod *shared.Pod) {
+	now := time.Now()
+	pod.Status.StartTime = &now
+	pod.Status.Reason = ""
+	pod.Status.Message = ""
+	pod.Status.ContainerStatuses = make([]shared.ContainerStatus, len(pod.Containers))
+	for i, container := range pod.Containers {
+		pod.Status.ContainerStatuses[i] = shared.ContainerStatus{
+			Image: container.Image,
+			State: shared.ContainerState{Waiting: &shared.ContainerStateWaiting{Reason: "ContainerCreating"}},
+		}
+	}
+	pod.Status.InitContainerStatuses = make([]shared.ContainerStatus, len(pod.InitContainers))
+	for i, container := range pod.InitContainers {
+		pod.Status.InitContainerStatuses[i] = shared.ContainerStatus{
+			Image: container.Image,
+			State: shared.ContainerState{Waiting: &shared.ContainerStateWaiting{Reason: "PodInitializing"}},
+		}
+	}
+	shared.SetPodCondition(pod, shared.PodCondition{
+		Type:               shared.PodInitializedCondition,
+		Status:             len(pod.InitContainers) == 0,
+		Reason:             initializedReason(pod),
+		LastTransitionTime: now,
+	})
+
+	if !p.createSandbox(pod) {
+		return
+	}
+	if !p.runInitContainers(pod) {
+		return
+	}
+
 	for containerIndex := range pod.Containers {
 		containerID := p.attemptContainerCreation(pod, containerIndex)
 		if containerID == nil {
 			return
 		}
 
-		p.attemptContainerStart(*containerID, pod)
+		if !p.attemptContainerStart(*containerID, pod, containerIndex) {
+			return
+		}
+	}
+
+	pod.Status.Phase = shared.PodRunning
+	updatePodPhase(pod)
+	if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+		shared.Log.Errorf("Failed to update pod status: %v", err)
 	}
 }
 
 func (p *PodLifecycleManager) attemptContainerCreation(pod *shared.Pod, containerIndex int) *string {
-	pod.Status = shared.PodContainerCreating
-	if err := p.PodRepo.UpdatePod(pod); err != nil {
+	pod.Status.Phase = shared.PodContainerCreating
+	if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+		return nil
+	}
+	if !p.ensureImage(pod, pod.Containers[containerIndex], &pod.Status.ContainerStatuses[containerIndex]) {
 		return nil
 	}
 
-	containerID, err := p.Runtime.CreateContainer(pod.Containers[containerIndex].Image)
+	containerID, err := p.Runtime.CreateContainer(containerSpecWithLimits(pod, containerIndex), pod.SandboxID)
 	if err != nil {
 		shared.Log.Errorf("Failed to create container: %v", err)
-		pod.Status = shared.PodFailed
-		_ = p.PodRepo.UpdatePod(pod)
+		pod.Status.ContainerStatuses[containerIndex].State = shared.ContainerState{
+			Waiting: &shared.ContainerStateWaiting{Reason: "CreateContainerError", Message: err.Error()},
+		}
+		p.failPod(pod, "ContainerCreateFailed", err)
 		return nil
 	}
 
 	pod.Containers[containerIndex].ID = containerID
-	if err := p.PodRepo.UpdatePod(pod); err != nil {
+	pod.Status.ContainerStatuses[containerIndex].ContainerID = containerID
+	if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
 		shared.Log.Errorf("Failed to update pod with ContainerID: %v", err)
 		return nil
 	}
@@ -56,39 +151,685 @@ func (p *PodLifecycleManager) attemptContainerCreation(pod *shared.Pod, containe
 	return &containerID
 }
 
-func (p *PodLifecycleManager) attemptContainerStart(containerID string, pod *shared.Pod) {
+// Sandbox
+// Creates and starts the sandbox of the pod, whose network namespace and IP all containers of the pod share
+func (p *PodLifecycleManager) createSandbox(pod *shared.Pod) bool {
+	pod.Status.Phase = shared.PodContainerCreating
+	if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+		return false
+	}
+
+	if err := p.startSandbox(pod); err != nil {
+		shared.Log.Errorf("Failed to create sandbox of pod %s: %v", pod.ID, err)
+		shared.RecordPodEvent(pod, shared.PodEventWarning, "SandboxCreateFailed", err.Error())
+		p.failPod(pod, "SandboxCreateFailed", err)
+		return false
+	}
+
+	shared.RecordPodEvent(pod, shared.PodEventNormal, "SandboxCreated", fmt.Sprintf("Created sandbox with IP %s", pod.Status.PodIP))
+	return true
+}
+
+func (p *PodLifecycleManager) startSandbox(pod *shared.Pod) error {
+	present, err := p.Runtime.ImageExists(p.SandboxImage)
+	if err != nil {
+		shared.Log.Errorf("Failed to inspect image %s: %v", p.SandboxImage, err)
+	}
+	if !present {
+		if err := p.Runtime.PullImage(context.Background(), p.SandboxImage, func(string) {}); err != nil {
+			return err
+		}
+	}
+
+	// An IP left from an earlier run of the pod is given back first
+	p.releasePodIP(pod)
+	pod.Status.PodIP = ""
+
+	var networkName string
+	if p.Network.IPManager != nil {
+		if err := p.ensureNetwork(); err != nil {
+			return err
+		}
+		// Set right away so that stopping the pod releases the IP even if the sandbox never starts
+		ip, err := p.Network.IPManager.AssignIP(context.Background())
+		if err != nil {
+			return err
+		}
+		networkName = p.Network.Name
+		pod.Status.PodIP = ip
+	}
+
+	var ports []shared.Port
+	for _, container := range pod.Containers {
+		ports = append(ports, container.Ports...)
+	}
+	sandboxID, err := p.Runtime.CreateSandbox(p.SandboxImage, ports, networkName, pod.Status.PodIP)
+	if err != nil {
+		return err
+	}
+	pod.SandboxID = sandboxID
+
+	if err := p.Runtime.StartContainer(sandboxID); err != nil {
+		return err
+	}
+
+	if pod.Status.PodIP != "" {
+		return nil
+	}
+	inspect, err := p.Runtime.InspectContainer(sandboxID)
+	if err != nil {
+		return err
+	}
+	pod.Status.PodIP = containerIP(inspect)
+	return nil
+}
+
+// The network is created once per run of the node agent, by the first pod that needs it. Pods reach each other
+// over the bridge network of their host, so the pod CIDR is bound to a single host, checked for every pod as
+// another host can take it over once all of its IPs are free
+func (p *PodLifecycleManager) ensureNetwork() error {
+	p.networkMutex.Lock()
+	defer p.networkMutex.Unlock()
+
+	if p.hostID == "" {
+		hostID, err := p.Runtime.HostID()
+		if err != nil {
+			return err
+		}
+		p.hostID = hostID
+	}
+	if err := p.Network.IPManager.Bind(context.Background(), p.hostID); err != nil {
+		return fmt.Errorf("the pod network spans a single Docker host: %w", err)
+	}
+
+	if p.networkReady {
+		return nil
+	}
+	if err := p.Runtime.EnsureNetwork(p.Network.Name, p.Network.CIDR); err != nil {
+		return err
+	}
+	p.networkReady = true
+	return nil
+}
+
+// Pods that got their IP from Docker, or from a former pod CIDR, have nothing to release
+func (p *PodLifecycleManager) releasePodIP(pod *shared.Pod) {
+	if p.Network.IPManager == nil || !p.Network.IPManager.Contains(pod.Status.PodIP) {
+		return
+	}
+	if err := p.Network.IPManager.ReleaseIP(context.Background(), pod.Status.PodIP); err != nil {
+		shared.Log.Errorf("Failed to release IP %s of pod %s: %v", pod.Status.PodIP, pod.ID, err)
+	}
+}
+
+// Init containers
+func initializedReason(pod *shared.Pod) string {
+	if len(pod.InitContainers) == 0 {
+		return ""
+	}
+	return "ContainersNotInitialized"
+}
+
+// Runs the init containers one after the other, each to completion, before the containers of the pod start
+func (p *PodLifecycleManager) runInitContainers(pod *shared.Pod) bool {
+	for i := range pod.InitContainers {
+		if !p.runInitContainer(pod, i) {
+			return false
+		}
+	}
+
+	if len(pod.InitContainers) > 0 {
+		shared.SetPodCondition(pod, shared.PodCondition{Type: shared.PodInitializedCondition, Status: true, LastTransitionTime: p.now()})
+	}
+	return true
+}
+
+// Failed init containers are started again after a back-off, unless the restart policy of the pod is Never
+func (p *PodLifecycleManager) runInitContainer(pod *shared.Pod, index int) bool {
+	container := &pod.InitContainers[index]
+	status := &pod.Status.InitContainerStatuses[index]
+	if !p.ensureImage(pod, *container, status) {
+		return false
+	}
+
+	containerID, err := p.Runtime.CreateContainer(*container, pod.SandboxID)
+	if err != nil {
+		status.State = shared.ContainerState{Waiting: &shared.ContainerStateWaiting{Reason: "CreateContainerError", Message: err.Error()}}
+		p.failPod(pod, "InitContainerCreateFailed", err)
+		return false
+	}
+	container.ID = containerID
+	status.ContainerID = containerID
+
+	for {
+		if err := p.Runtime.StartContainer(containerID); err != nil {
+			p.failPod(pod, "InitContainerStartFailed", err)
+			return false
+		}
+
+		terminated, err := p.waitForExit(pod, status)
+		if err != nil {
+			shared.Log.Errorf("Stopped waiting for init container %s of pod %s: %v", containerID, pod.ID, err)
+			return false
+		}
+		if terminated.ExitCode == 0 {
+			return true
+		}
+
+		err = fmt.Errorf("init container %s exited with code %d", containerDisplayName(*container), terminated.ExitCode)
+		if pod.RestartPolicy == shared.RestartNever {
+			p.failPod(pod, "InitContainerFailed", err)
+			return false
+		}
+
+		backoff := restartBackoff(status.RestartCount)
+		shared.RecordPodEvent(pod, shared.PodEventWarning, "BackOff", fmt.Sprintf("%v, restarting it in %v", err, backoff))
+		status.LastState = shared.ContainerState{Terminated: terminated}
+		status.State = shared.ContainerState{Waiting: &shared.ContainerStateWaiting{
+			Reason:  "CrashLoopBackOff",
+			Message: fmt.Sprintf("back-off %v restarting failed init container", backoff),
+		}}
+		if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+			return false
+		}
+		p.sleep(backoff)
+		status.RestartCount++
+	}
+}
+
+// Follows the state of a started container until it exits. Errors mean the container cannot be inspected,
+// which fails the pod, or that the pod cannot be updated any more, e.g. because it was deleted
+func (p *PodLifecycleManager) waitForExit(pod *shared.Pod, status *shared.ContainerStatus) (*shared.ContainerStateTerminated, error) {
+	for {
+		inspect, err := p.Runtime.InspectContainer(status.ContainerID)
+		if err == nil && (inspect.ContainerJSONBase == nil || inspect.State == nil) {
+			err = fmt.Errorf("no state reported for container %s", status.ContainerID)
+		}
+		if err != nil {
+			p.failPod(pod, "InitContainerFailed", err)
+			return nil, err
+		}
+
+		state := containerStateFromInspect(inspect.State)
+		if !reflect.DeepEqual(state, status.State) {
+			status.State = state
+			if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+				return nil, err
+			}
+		}
+		if state.Terminated != nil {
+			return state.Terminated, nil
+		}
+		p.sleep(initContainerPollInterval)
+	}
+}
+
+func containerDisplayName(container shared.Container) string {
+	if container.Name != "" {
+		return container.Name
+	}
+	return container.Image
+}
+
+// Images
+// Makes the image of the container available as its pull policy asks for. Failed pulls are retried with a back-off
+// for as long as the pod can be updated; false means the pod failed or is gone
+func (p *PodLifecycleManager) ensureImage(pod *shared.Pod, container shared.Container, status *shared.ContainerStatus) bool {
+	if container.ImagePullPolicy != shared.PullAlways {
+		present, err := p.Runtime.ImageExists(container.Image)
+		if err != nil {
+			shared.Log.Errorf("Failed to inspect image %s: %v", container.Image, err)
+		}
+		if present {
+			return true
+		}
+	}
+
+	if container.ImagePullPolicy == shared.PullNever {
+		err := fmt.Errorf("image %s is not present on the node and its pull policy is Never", container.Image)
+		status.State = shared.ContainerState{Waiting: &shared.ContainerStateWaiting{Reason: "ErrImageNeverPull", Message: err.Error()}}
+		shared.RecordPodEvent(pod, shared.PodEventWarning, "ErrImageNeverPull", err.Error())
+		p.failPod(pod, "ErrImageNeverPull", err)
+		return false
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := p.pullImage(pod, container.Image, status)
+		if err == nil {
+			return true
+		}
+
+		backoff := restartBackoff(attempt)
+		shared.RecordPodEvent(pod, shared.PodEventWarning, "Failed", fmt.Sprintf("Failed to pull image %s: %v", container.Image, err))
+		status.State = shared.ContainerState{Waiting: &shared.ContainerStateWaiting{
+			Reason:  "ImagePullBackOff",
+			Message: fmt.Sprintf("back-off %v pulling image %s", backoff, container.Image),
+		}}
+		if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+			shared.Log.Errorf("Giving up pulling image %s for pod %s: %v", container.Image, pod.ID, err)
+			return false
+		}
+		p.sleep(backoff)
+	}
+}
+
+// Progress is written to the waiting state of the container every pullProgressInterval
+func (p *PodLifecycleManager) pullImage(pod *shared.Pod, image string, status *shared.ContainerStatus) error {
+	setPulling := func(message string) {
+		status.State = shared.ContainerState{Waiting: &shared.ContainerStateWaiting{Reason: "ContainerCreating", Message: message}}
+	}
+
+	shared.Log.Infof("Pulling image %s for pod %s", image, pod.ID)
+	shared.RecordPodEvent(pod, shared.PodEventNormal, "Pulling", fmt.Sprintf("Pulling image %s", image))
+	setPulling("pulling image " + image)
+	if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+		return err
+	}
+
+	startedAt := p.now()
+	lastReport := startedAt
+	err := p.Runtime.PullImage(context.Background(), image, func(progress string) {
+		if p.now().Sub(lastReport) < pullProgressInterval {
+			return
+		}
+		lastReport = p.now()
+		setPulling("pulling image " + image + ": " + progress)
+		if err := p.PodRepo.UpdatePod(context.Background(), pod); err != nil {
+			shared.Log.Errorf("Failed to report pull progress of pod %s: %v", pod.ID, err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Successfully pulled image %s in %v", image, p.now().Sub(startedAt).Round(time.Millisecond))
+	shared.RecordPodEvent(pod, shared.PodEventNormal, "Pulled", message)
+	setPulling("")
+	return nil
+}
+
+// The resources of a pod with a single container are the limits of that container, unless it sets its own
+func containerSpecWithLimits(pod *shared.Pod, containerIndex int) shared.Container {
+	spec := pod.Containers[containerIndex]
+	if len(pod.Containers) == 1 && spec.Resources.Limits == (shared.Resources{}) {
+		spec.Resources.Limits = pod.Resources
+	}
+	return spec
+}
+
+func (p *PodLifecycleManager) attemptContainerStart(containerID string, pod *shared.Pod, containerIndex int) bool {
 	if err := p.Runtime.StartContainer(containerID); err != nil {
-		pod.Status = shared.PodFailed
-		_ = p.PodRepo.UpdatePod(pod)
 		shared.Log.Errorf("Failed to start container: %v", err)
+		p.refreshContainerStatus(pod, containerIndex)
+		p.failPod(pod, "ContainerStartFailed", err)
+		return false
+	}
+
+	p.refreshContainerStatus(pod, containerIndex)
+	return true
+}
+
+func (p *PodLifecycleManager) failPod(pod *shared.Pod, reason string, err error) {
+	pod.Status.Phase = shared.PodFailed
+	pod.Status.Reason = reason
+	pod.Status.Message = err.Error()
+	setReadyConditions(pod, false, reason, err.Error())
+	_ = p.PodRepo.UpdatePod(context.Background(), pod)
+}
+
+// Keeps the container statuses of a running pod up to date, e.g. with the exit code of a container that stopped,
+// and restarts exited containers as allowed by the restart policy of the pod
+func (p *PodLifecycleManager) SyncPodStatus(pod *shared.Pod) error {
+	return shared.RetryOnConflict(func() error {
+		latestPod, err := p.PodRepo.GetPodByID(context.Background(), pod.Namespace, pod.ID)
+		if err != nil {
+			return err
+		}
+		if latestPod.Status.Phase != shared.PodRunning {
+			return nil
+		}
+
+		previousStatus := latestPod.Status
+		previousStatus.Conditions = slices.Clone(previousStatus.Conditions)
+		previousStatus.ContainerStatuses = slices.Clone(previousStatus.ContainerStatuses)
+		for containerIndex := range latestPod.Containers {
+			p.refreshContainerStatus(latestPod, containerIndex)
+			p.probeContainer(latestPod, containerIndex)
+			p.restartContainerIfAllowed(latestPod, containerIndex)
+		}
+		updatePodPhase(latestPod)
+
+		if reflect.DeepEqual(previousStatus, latestPod.Status) {
+			return nil
+		}
+		return p.PodRepo.UpdatePod(context.Background(), latestPod)
+	})
+}
+
+// A container stopped for failing a probe counts as failed, even if it exited with code 0 when asked to stop
+func shouldRestartContainer(policy shared.RestartPolicy, terminated *shared.ContainerStateTerminated) bool {
+	switch policy {
+	case shared.RestartAlways:
+		return true
+	case shared.RestartOnFailure:
+		return terminated.ExitCode != 0 || terminated.Reason == unhealthyReason
+	default:
+		return false
+	}
+}
+
+// Doubles with every restart of the container, from initialRestartBackoff up to maxRestartBackoff
+func restartBackoff(restartCount int) time.Duration {
+	backoff := initialRestartBackoff
+	for i := 0; i < restartCount && backoff < maxRestartBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRestartBackoff)
+}
+
+// Containers that keep exiting are restarted in place once their back-off since the last exit has passed.
+// Until then they are waiting in CrashLoopBackOff
+func (p *PodLifecycleManager) restartContainerIfAllowed(pod *shared.Pod, containerIndex int) {
+	status := &pod.Status.ContainerStatuses[containerIndex]
+	terminated := status.State.Terminated
+	if terminated == nil || !shouldRestartContainer(pod.RestartPolicy, terminated) {
 		return
 	}
-	pod.Status = shared.PodRunning
-	if err := p.PodRepo.UpdatePod(pod); err != nil {
-		shared.Log.Errorf("Failed to update pod status: %v", err)
+
+	backoff := restartBackoff(status.RestartCount)
+	crashLoopBackOff := shared.ContainerState{Waiting: &shared.ContainerStateWaiting{
+		Reason:  "CrashLoopBackOff",
+		Message: fmt.Sprintf("back-off %v restarting exited container", backoff),
+	}}
+	status.LastState = shared.ContainerState{Terminated: terminated}
+	status.State = crashLoopBackOff
+
+	if p.now().Sub(terminated.FinishedAt) < backoff {
+		return
+	}
+
+	shared.Log.Infof("Restarting container %s of pod %s after %d restarts", status.ContainerID, pod.ID, status.RestartCount)
+	if err := p.Runtime.StartContainer(status.ContainerID); err != nil {
+		shared.Log.Errorf("Failed to restart container %s: %v", status.ContainerID, err)
+		return
+	}
+	status.RestartCount++
+	p.setUnhealthy(status.ContainerID, false)
+	p.refreshContainerStatus(pod, containerIndex)
+}
+
+// A pod runs as long as one of its containers runs or is going to be restarted. Once they all exited for good,
+// it succeeded if they all exited with code 0, and failed otherwise
+func updatePodPhase(pod *shared.Pod) {
+	ready, running := true, false
+	var failed *shared.ContainerStatus
+	for i, status := range pod.Status.ContainerStatuses {
+		terminated := status.State.Terminated
+		if !status.Ready {
+			ready = false
+		}
+		if terminated == nil {
+			running = true
+		} else if terminated.ExitCode != 0 && failed == nil {
+			failed = &pod.Status.ContainerStatuses[i]
+		}
+	}
+
+	switch {
+	case ready:
+		setReadyConditions(pod, true, "", "")
+		return
+	case running:
+		setReadyConditions(pod, false, "ContainersNotReady", "not all containers are ready")
+		return
+	case failed != nil:
+		pod.Status.Phase = shared.PodFailed
+		pod.Status.Reason = failed.State.Terminated.Reason
+		pod.Status.Message = fmt.Sprintf("container %s exited with code %d", failed.ContainerID, failed.State.Terminated.ExitCode)
+	default:
+		pod.Status.Phase = shared.PodSucceeded
+		pod.Status.Reason = "Completed"
+		pod.Status.Message = "all containers exited with code 0"
+	}
+	setReadyConditions(pod, false, "PodCompleted", pod.Status.Message)
+}
+
+// Containers that cannot be inspected keep their last known status.
+// Restarts are counted by the node agent, the runtime only knows about its own restarts
+func (p *PodLifecycleManager) refreshContainerStatus(pod *shared.Pod, containerIndex int) {
+	container := pod.Containers[containerIndex]
+	if containerIndex >= len(pod.Status.ContainerStatuses) {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, make([]shared.ContainerStatus, containerIndex+1-len(pod.Status.ContainerStatuses))...)
+	}
+
+	inspect, err := p.Runtime.InspectContainer(container.ID)
+	if err != nil || inspect.ContainerJSONBase == nil || inspect.State == nil {
+		shared.Log.Errorf("Failed to inspect container %s: %v", container.ID, err)
+		return
+	}
+
+	status := &pod.Status.ContainerStatuses[containerIndex]
+	status.ContainerID = container.ID
+	status.Image = container.Image
+	status.State = containerStateFromInspect(inspect.State)
+	if status.State.Terminated != nil && p.isUnhealthy(container.ID) {
+		status.State.Terminated.Reason = unhealthyReason
+	}
+	p.updateContainerReadiness(pod, containerIndex)
+}
+
+// Probes
+// Runs the probes of a running container that are due. Liveness and readiness probes wait for the startup probe,
+// and a container failing its startup or liveness probe is stopped, to be restarted as allowed by the restart policy
+func (p *PodLifecycleManager) probeContainer(pod *shared.Pod, containerIndex int) {
+	container := pod.Containers[containerIndex]
+	running := pod.Status.ContainerStatuses[containerIndex].State.Running
+	if running == nil {
+		return
+	}
+
+	if container.StartupProbe != nil {
+		result := p.runProbe(container.ID, startupProbe, container.StartupProbe, running.StartedAt)
+		if result.failed {
+			p.stopUnhealthyContainer(pod, containerIndex, startupProbe)
+			return
+		}
+		if !result.succeeded {
+			p.updateContainerReadiness(pod, containerIndex)
+			return
+		}
+	}
+
+	if container.LivenessProbe != nil && p.runProbe(container.ID, livenessProbe, container.LivenessProbe, running.StartedAt).failed {
+		p.stopUnhealthyContainer(pod, containerIndex, livenessProbe)
+		return
+	}
+	if container.ReadinessProbe != nil {
+		p.runProbe(container.ID, readinessProbe, container.ReadinessProbe, running.StartedAt)
+	}
+	p.updateContainerReadiness(pod, containerIndex)
+}
+
+// Checks the container if the probe is due. Startup probes are no longer run once they succeeded.
+// The mutex is released during the check, so that a slow probe does not hold up the probes of other containers
+func (p *PodLifecycleManager) runProbe(containerID string, kind probeKind, probe *shared.Probe, startedAt time.Time) probeResult {
+	p.mutex.Lock()
+	result := p.probeResult(containerID, kind, startedAt)
+	now := p.now()
+	if (kind == startupProbe && result.succeeded) || !result.isDue(probe, now) {
+		defer p.mutex.Unlock()
+		return *result
+	}
+	result.lastProbeTime = now // Keeps concurrent syncs of the pod from running the same check
+	p.mutex.Unlock()
+
+	err := p.Prober.Probe(context.Background(), containerID, probe)
+	if err != nil {
+		shared.Log.Infof("The %s probe of container %s failed: %v", kind, containerID, err)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	result.record(probe, err, now)
+	return *result
+}
+
+// Results start over whenever the container is restarted. Callers hold the mutex
+func (p *PodLifecycleManager) probeResult(containerID string, kind probeKind, startedAt time.Time) *probeResult {
+	key := containerID + "/" + string(kind)
+	result, exists := p.probeResults[key]
+	if !exists || !result.containerStartedAt.Equal(startedAt) {
+		result = &probeResult{containerStartedAt: startedAt}
+		p.probeResults[key] = result
+	}
+	return result
+}
+
+func (p *PodLifecycleManager) probeSucceeded(containerID string, kind probeKind, startedAt time.Time) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.probeResult(containerID, kind, startedAt).succeeded
+}
+
+func (p *PodLifecycleManager) forgetProbeResults(containerID string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, kind := range []probeKind{livenessProbe, readinessProbe, startupProbe} {
+		delete(p.probeResults, containerID+"/"+string(kind))
+	}
+	delete(p.unhealthy, containerID)
+}
+
+func (p *PodLifecycleManager) setUnhealthy(containerID string, unhealthy bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if unhealthy {
+		p.unhealthy[containerID] = true
+	} else {
+		delete(p.unhealthy, containerID)
+	}
+}
+
+func (p *PodLifecycleManager) isUnhealthy(containerID string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.unhealthy[containerID]
+}
+
+// A container is ready once it passed its startup probe and while it passes its readiness probe
+func (p *PodLifecycleManager) updateContainerReadiness(pod *shared.Pod, containerIndex int) {
+	container := pod.Containers[containerIndex]
+	status := &pod.Status.ContainerStatuses[containerIndex]
+	if status.State.Running == nil {
+		status.Started, status.Ready = false, false
+		return
+	}
+
+	startedAt := status.State.Running.StartedAt
+	status.Started = container.StartupProbe == nil || p.probeSucceeded(container.ID, startupProbe, startedAt)
+	status.Ready = status.Started && (container.ReadinessProbe == nil || p.probeSucceeded(container.ID, readinessProbe, startedAt))
+}
+
+func (p *PodLifecycleManager) stopUnhealthyContainer(pod *shared.Pod, containerIndex int, kind probeKind) {
+	containerID := pod.Containers[containerIndex].ID
+	shared.Log.Infof("Stopping container %s of pod %s, it failed its %s probe", containerID, pod.ID, kind)
+	if err := p.Runtime.StopContainer(containerID); err != nil {
+		shared.Log.Errorf("Failed to stop unhealthy container %s: %v", containerID, err)
 		return
 	}
+
+	p.forgetProbeResults(containerID)
+	p.setUnhealthy(containerID, true)
+	p.refreshContainerStatus(pod, containerIndex)
+}
+
+// Translates the state reported by the runtime, e.g. an exited container into a terminated one with its exit code
+func containerStateFromInspect(state *types.ContainerState) shared.ContainerState {
+	startedAt, _ := time.Parse(time.RFC3339Nano, state.StartedAt)
+
+	switch state.Status {
+	case "running", "paused":
+		return shared.ContainerState{Running: &shared.ContainerStateRunning{StartedAt: startedAt}}
+	case "restarting":
+		return shared.ContainerState{Waiting: &shared.ContainerStateWaiting{Reason: "ContainerRestarting"}}
+	case "created":
+		return shared.ContainerState{Waiting: &shared.ContainerStateWaiting{Reason: "ContainerCreated"}}
+	default: // exited, dead or removing
+		finishedAt, _ := time.Parse(time.RFC3339Nano, state.FinishedAt)
+		reason := "Completed"
+		if state.OOMKilled {
+			reason = "OOMKilled"
+		} else if state.ExitCode != 0 || state.Dead {
+			reason = "Error"
+		}
+		return shared.ContainerState{Terminated: &shared.ContainerStateTerminated{
+			ExitCode:   state.ExitCode,
+			Reason:     reason,
+			Message:    state.Error,
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+		}}
+	}
+}
+
+// ContainersReady and Ready go together as long as pods have no readiness checks
+func setReadyConditions(pod *shared.Pod, ready bool, reason string, message string) {
+	now := time.Now()
+	for _, conditionType := range []shared.PodConditionType{shared.ContainersReadyCondition, shared.PodReadyCondition} {
+		shared.SetPodCondition(pod, shared.PodCondition{
+			Type:               conditionType,
+			Status:             ready,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+		})
+	}
 }
 
+// Running containers are stopped first so that they can shut down gracefully; exited ones, e.g. of a failed
+// or completed pod, are only removed
 func (p *PodLifecycleManager) StopPod(pod *shared.Pod) error {
 	for _, container := range pod.Containers {
+		if container.ID == "" {
+			continue
+		}
+		p.forgetProbeResults(container.ID)
+
 		containerStatus, err := p.Runtime.GetContainerStatus(container.ID)
 		if err != nil {
 			shared.Log.Errorf("Failed to get container status: %v", err)
-			continue
 		}
-		if containerStatus != shared.Running {
-			continue
+		if err == nil && containerStatus == shared.Running {
+			if err := p.Runtime.StopContainer(container.ID); err != nil {
+				shared.Log.Errorf("Failed to stop container: %v", err)
+			}
 		}
 
-		if err := p.Runtime.StopContainer(container.ID); err != nil {
-			shared.Log.Errorf("Failed to stop container: %v", err)
+		if err := p.Runtime.DeleteContainer(container.ID); err != nil {
+			shared.Log.Errorf("Failed to remove container: %v", err)
 		}
+	}
 
+	for _, container := range pod.InitContainers {
+		if container.ID == "" {
+			continue
+		}
 		if err := p.Runtime.DeleteContainer(container.ID); err != nil {
-			shared.Log.Errorf("Failed to remove container: %v", err)
+			shared.Log.Errorf("Failed to remove init container: %v", err)
+		}
+	}
+
+	// Removed last, the containers live in its network namespace
+	if pod.SandboxID != "" {
+		if err := p.Runtime.DeleteContainer(pod.SandboxID); err != nil {
+			shared.Log.Errorf("Failed to remove sandbox of pod %s: %v", pod.ID, err)
 		}
 	}
+	p.releasePodIP(pod)
 	return nil
 }
 
@@ -4,10 +4,12 @@ import (
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 
+	"context"
 	"errors"
 	"testing"
+	"time"
 
-	// "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,25 +22,52 @@ func TestPodLifecycleManagerRunPodSuccess(t *testing.T) {
 
 	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
 	mockPodRepo := mocks.NewMockPodRepository(ctrl)
-	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{})
 
 	pod := &shared.Pod{
 		Containers: []shared.Container{
 			{Image: "example-image"},
 		},
-		Status: shared.PodPending,
+		Status: shared.PodStatus{Phase: shared.PodPending},
 	}
 
 	// Expectations
-	mockPodRepo.EXPECT().UpdatePod(gomock.Any()).AnyTimes().Return(nil) // Called twice, for creating and running status updates
-	mockRuntime.EXPECT().CreateContainer(gomock.Any()).Return("containerID", nil)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil) // Called twice, for creating and running status updates
+	expectSandbox(mockRuntime)
+	mockRuntime.EXPECT().ImageExists("example-image").Return(true, nil)
+	mockRuntime.EXPECT().CreateContainer(gomock.Any(), "sandboxID").Return("containerID", nil)
 	mockRuntime.EXPECT().StartContainer(gomock.Any()).Return(nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newInspectResponse(types.ContainerState{Status: "running", Running: true, StartedAt: "2024-01-02T03:04:05Z"}), nil)
 
 	// Act
 	manager.RunPod(pod)
 
 	// Assert
-	assert.Equal(t, shared.PodRunning, pod.Status)
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+	assert.Equal(t, "sandboxID", pod.SandboxID)
+	assert.Equal(t, "172.17.0.2", pod.Status.PodIP)
+	assert.NotNil(t, pod.Status.StartTime)
+	assert.Len(t, pod.Status.ContainerStatuses, 1)
+	assert.True(t, pod.Status.ContainerStatuses[0].Ready)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), pod.Status.ContainerStatuses[0].State.Running.StartedAt)
+	assert.Len(t, pod.Status.Conditions, 3) // Initialized, ContainersReady and Ready
+	for _, condition := range pod.Status.Conditions {
+		assert.True(t, condition.Status)
+	}
+}
+
+func expectSandbox(mockRuntime *mocks.MockContainerRuntimeInterface) {
+	sandbox := newInspectResponse(types.ContainerState{Status: "running", Running: true})
+	sandbox.NetworkSettings = &types.NetworkSettings{DefaultNetworkSettings: types.DefaultNetworkSettings{IPAddress: "172.17.0.2"}}
+
+	mockRuntime.EXPECT().ImageExists(DefaultSandboxImage).Return(true, nil)
+	mockRuntime.EXPECT().CreateSandbox(DefaultSandboxImage, gomock.Any(), "", "").Return("sandboxID", nil)
+	mockRuntime.EXPECT().StartContainer("sandboxID").Return(nil)
+	mockRuntime.EXPECT().InspectContainer("sandboxID").Return(sandbox, nil)
+}
+
+func newInspectResponse(state types.ContainerState) types.ContainerJSON {
+	return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{State: &state, RestartCount: 1}}
 }
 
 func TestPodLifecycleManagerRunPodFailCreateContainer(t *testing.T) {
@@ -47,24 +76,707 @@ func TestPodLifecycleManagerRunPodFailCreateContainer(t *testing.T) {
 
 	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
 	mockPodRepo := mocks.NewMockPodRepository(ctrl)
-	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{})
 
 	pod := &shared.Pod{
 		Containers: []shared.Container{
 			{Image: "example-image"},
 		},
-		Status: shared.PodPending,
+		Status: shared.PodStatus{Phase: shared.PodPending},
 	}
 
 	// Expectations
-	mockPodRepo.EXPECT().UpdatePod(gomock.Any()).AnyTimes().Return(nil) // Update to failed status
-	mockRuntime.EXPECT().CreateContainer(gomock.Any()).Return("", errors.New("creation error"))
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil) // Update to failed status
+	expectSandbox(mockRuntime)
+	mockRuntime.EXPECT().ImageExists("example-image").Return(true, nil)
+	mockRuntime.EXPECT().CreateContainer(gomock.Any(), "sandboxID").Return("", errors.New("creation error"))
+
+	// Act
+	manager.RunPod(pod)
+
+	// Assert
+	assert.Equal(t, shared.PodFailed, pod.Status.Phase)
+	assert.Equal(t, "ContainerCreateFailed", pod.Status.Reason)
+	assert.Equal(t, "CreateContainerError", pod.Status.ContainerStatuses[0].State.Waiting.Reason)
+}
+
+func TestPodLifecycleManagerRunPodPullsMissingImage(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{}).(*PodLifecycleManager)
+	var backoffs []time.Duration
+	manager.sleep = func(backoff time.Duration) { backoffs = append(backoffs, backoff) }
+
+	pod := &shared.Pod{
+		Containers: []shared.Container{{Image: "example-image"}},
+		Status:     shared.PodStatus{Phase: shared.PodScheduled},
+	}
+
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	expectSandbox(mockRuntime)
+	mockRuntime.EXPECT().ImageExists("example-image").Return(false, nil)
+	gomock.InOrder(
+		mockRuntime.EXPECT().PullImage(gomock.Any(), "example-image", gomock.Any()).Return(errors.New("connection reset")),
+		mockRuntime.EXPECT().PullImage(gomock.Any(), "example-image", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, onProgress func(string)) error {
+			onProgress("1/1 layers done, 1.0MB/1.0MB downloaded")
+			return nil
+		}),
+	)
+	mockRuntime.EXPECT().CreateContainer(gomock.Any(), "sandboxID").Return("containerID", nil)
+	mockRuntime.EXPECT().StartContainer("containerID").Return(nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newInspectResponse(types.ContainerState{Status: "running", Running: true}), nil)
+
+	// Act
+	manager.RunPod(pod)
+
+	// Assert
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+	assert.Equal(t, []time.Duration{10 * time.Second}, backoffs)
+	var reasons []string
+	for _, event := range pod.Status.Events {
+		reasons = append(reasons, event.Reason)
+	}
+	assert.Equal(t, []string{"SandboxCreated", "Pulling", "Failed", "Pulling", "Pulled"}, reasons)
+}
+
+func TestPodLifecycleManagerRunPodImageNeverPulled(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{})
+
+	pod := &shared.Pod{
+		Containers: []shared.Container{{Image: "example-image", ImagePullPolicy: shared.PullNever}},
+		Status:     shared.PodStatus{Phase: shared.PodScheduled},
+	}
+
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	expectSandbox(mockRuntime)
+	mockRuntime.EXPECT().ImageExists("example-image").Return(false, nil)
+	mockRuntime.EXPECT().PullImage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockRuntime.EXPECT().CreateContainer(gomock.Any(), "sandboxID").Times(0)
+
+	// Act
+	manager.RunPod(pod)
+
+	// Assert
+	assert.Equal(t, shared.PodFailed, pod.Status.Phase)
+	assert.Equal(t, "ErrImageNeverPull", pod.Status.Reason)
+	assert.Equal(t, "ErrImageNeverPull", pod.Status.ContainerStatuses[0].State.Waiting.Reason)
+}
+
+func TestPodLifecycleManagerRunPodFailCreateSandbox(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{})
+
+	pod := &shared.Pod{
+		Containers: []shared.Container{{Image: "example-image"}},
+		Status:     shared.PodStatus{Phase: shared.PodScheduled},
+	}
+
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	mockRuntime.EXPECT().ImageExists(DefaultSandboxImage).Return(true, nil)
+	mockRuntime.EXPECT().CreateSandbox(DefaultSandboxImage, gomock.Any(), "", "").Return("", errors.New("port is already allocated"))
+	mockRuntime.EXPECT().CreateContainer(gomock.Any(), gomock.Any()).Times(0)
+
+	// Act
+	manager.RunPod(pod)
+
+	// Assert
+	assert.Equal(t, shared.PodFailed, pod.Status.Phase)
+	assert.Equal(t, "SandboxCreateFailed", pod.Status.Reason)
+}
+
+func TestPodLifecycleManagerRunPodAssignsIPFromPodNetwork(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+	network := PodNetwork{Name: DefaultPodNetworkName, CIDR: "10.244.0.0/16", IPManager: mockIPManager}
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), network)
+
+	pods := []*shared.Pod{
+		{Containers: []shared.Container{{Image: "example-image"}}, Status: shared.PodStatus{Phase: shared.PodScheduled}},
+		{Containers: []shared.Container{{Image: "example-image"}}, Status: shared.PodStatus{Phase: shared.PodScheduled}},
+	}
+
+	// The network is only ensured for the first pod, while its binding to the host is checked for every pod;
+	// the IP of a sandbox that cannot be created is kept until the pod is stopped
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	mockRuntime.EXPECT().ImageExists(DefaultSandboxImage).Times(2).Return(true, nil)
+	mockRuntime.EXPECT().HostID().Times(1).Return("docker-host-1", nil)
+	mockIPManager.EXPECT().Bind(gomock.Any(), "docker-host-1").Times(2).Return(nil)
+	mockRuntime.EXPECT().EnsureNetwork(DefaultPodNetworkName, "10.244.0.0/16").Times(1).Return(nil)
+	mockIPManager.EXPECT().Contains("").Times(2).Return(false)
+	mockIPManager.EXPECT().AssignIP(gomock.Any()).Return("10.244.0.2", nil)
+	mockIPManager.EXPECT().AssignIP(gomock.Any()).Return("10.244.0.3", nil)
+	mockRuntime.EXPECT().CreateSandbox(DefaultSandboxImage, gomock.Any(), DefaultPodNetworkName, "10.244.0.2").Return("", errors.New("port is already allocated"))
+	mockRuntime.EXPECT().CreateSandbox(DefaultSandboxImage, gomock.Any(), DefaultPodNetworkName, "10.244.0.3").Return("sandboxID", nil)
+	mockRuntime.EXPECT().StartContainer("sandboxID").Return(nil)
+	mockRuntime.EXPECT().ImageExists("example-image").Return(true, nil)
+	mockRuntime.EXPECT().CreateContainer(gomock.Any(), "sandboxID").Return("containerID", nil)
+	mockRuntime.EXPECT().StartContainer("containerID").Return(nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newInspectResponse(types.ContainerState{Status: "running", Running: true}), nil)
+
+	// Act
+	manager.RunPod(pods[0])
+	manager.RunPod(pods[1])
+
+	// Assert
+	assert.Equal(t, shared.PodFailed, pods[0].Status.Phase)
+	assert.Equal(t, "10.244.0.2", pods[0].Status.PodIP)
+	assert.Equal(t, shared.PodRunning, pods[1].Status.Phase)
+	assert.Equal(t, "10.244.0.3", pods[1].Status.PodIP)
+}
+
+func TestPodLifecycleManagerRunPodOnAnotherHostOfThePodNetwork(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+	network := PodNetwork{Name: DefaultPodNetworkName, CIDR: "10.244.0.0/16", IPManager: mockIPManager}
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), network)
+
+	pod := &shared.Pod{Containers: []shared.Container{{Image: "example-image"}}, Status: shared.PodStatus{Phase: shared.PodScheduled}}
+
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	mockRuntime.EXPECT().ImageExists(DefaultSandboxImage).Return(true, nil)
+	mockIPManager.EXPECT().Contains("").Return(false)
+	mockRuntime.EXPECT().HostID().Return("docker-host-2", nil)
+	mockIPManager.EXPECT().Bind(gomock.Any(), "docker-host-2").Return(&shared.ErrIPRangeBound{Range: "pods", Owner: "docker-host-1", Allocated: 3})
+	mockRuntime.EXPECT().EnsureNetwork(gomock.Any(), gomock.Any()).Times(0)
+	mockIPManager.EXPECT().AssignIP(gomock.Any()).Times(0)
+
+	// Act
+	manager.RunPod(pod)
+
+	// Assert
+	assert.Equal(t, shared.PodFailed, pod.Status.Phase)
+	assert.Contains(t, pod.Status.Message, "docker-host-1")
+}
+
+func TestPodLifecycleManagerStopPodReleasesIP(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+	network := PodNetwork{Name: DefaultPodNetworkName, CIDR: "10.244.0.0/16", IPManager: mockIPManager}
+	manager := NewPodLifecycleManager(mockRuntime, mocks.NewMockPodRepository(ctrl), mocks.NewMockProber(ctrl), network)
+
+	pod := &shared.Pod{SandboxID: "sandboxID", Status: shared.PodStatus{PodIP: "10.244.0.2"}}
+
+	mockRuntime.EXPECT().DeleteContainer("sandboxID").Return(nil)
+	mockIPManager.EXPECT().Contains("10.244.0.2").Return(true)
+	mockIPManager.EXPECT().ReleaseIP(gomock.Any(), "10.244.0.2").Return(nil)
+
+	// Act
+	err := manager.StopPod(pod)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestPodLifecycleManagerStopPodRemovesSandbox(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mocks.NewMockPodRepository(ctrl), mocks.NewMockProber(ctrl), PodNetwork{})
+
+	pod := &shared.Pod{SandboxID: "sandboxID", Containers: []shared.Container{{ID: "containerID"}}}
+
+	gomock.InOrder(
+		mockRuntime.EXPECT().GetContainerStatus("containerID").Return(shared.Running, nil),
+		mockRuntime.EXPECT().StopContainer("containerID").Return(nil),
+		mockRuntime.EXPECT().DeleteContainer("containerID").Return(nil),
+		mockRuntime.EXPECT().DeleteContainer("sandboxID").Return(nil),
+	)
+
+	// Act
+	err := manager.StopPod(pod)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestPodLifecycleManagerStopPodRemovesExitedContainers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mocks.NewMockPodRepository(ctrl), mocks.NewMockProber(ctrl), PodNetwork{})
+
+	pod := &shared.Pod{
+		Status:     shared.PodStatus{Phase: shared.PodFailed},
+		Containers: []shared.Container{{ID: "exitedID"}, {ID: "unknownID"}, {}},
+	}
+
+	mockRuntime.EXPECT().GetContainerStatus("exitedID").Return(shared.Exited, nil)
+	mockRuntime.EXPECT().GetContainerStatus("unknownID").Return(shared.Dead, errors.New("inspect failed"))
+	mockRuntime.EXPECT().StopContainer(gomock.Any()).Times(0)
+	mockRuntime.EXPECT().DeleteContainer("exitedID").Return(nil)
+	mockRuntime.EXPECT().DeleteContainer("unknownID").Return(nil)
+
+	// Act
+	err := manager.StopPod(pod)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func newInitTestPod(restartPolicy shared.RestartPolicy) *shared.Pod {
+	return &shared.Pod{
+		InitContainers: []shared.Container{{Name: "init", Image: "init-image"}},
+		Containers:     []shared.Container{{Image: "example-image"}},
+		RestartPolicy:  restartPolicy,
+		Status:         shared.PodStatus{Phase: shared.PodScheduled},
+	}
+}
+
+func TestPodLifecycleManagerRunPodRunsInitContainersFirst(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{}).(*PodLifecycleManager)
+	manager.sleep = func(time.Duration) {}
+
+	pod := newInitTestPod(shared.RestartAlways)
+
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	expectSandbox(mockRuntime)
+	mockRuntime.EXPECT().ImageExists(gomock.Any()).AnyTimes().Return(true, nil)
+	gomock.InOrder(
+		mockRuntime.EXPECT().CreateContainer(pod.InitContainers[0], "sandboxID").Return("initID", nil),
+		mockRuntime.EXPECT().StartContainer("initID").Return(nil),
+		mockRuntime.EXPECT().InspectContainer("initID").Return(newRunningInspectResponse(), nil),
+		mockRuntime.EXPECT().InspectContainer("initID").Return(newExitedInspectResponse(0), nil),
+		mockRuntime.EXPECT().CreateContainer(gomock.Any(), "sandboxID").Return("containerID", nil),
+		mockRuntime.EXPECT().StartContainer("containerID").Return(nil),
+		mockRuntime.EXPECT().InspectContainer("containerID").Return(newRunningInspectResponse(), nil),
+	)
 
 	// Act
 	manager.RunPod(pod)
 
 	// Assert
-	assert.Equal(t, shared.PodFailed, pod.Status)
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+	assert.Equal(t, "initID", pod.InitContainers[0].ID)
+	assert.Equal(t, 0, pod.Status.InitContainerStatuses[0].State.Terminated.ExitCode)
+	assert.True(t, pod.Status.Conditions[0].Status) // Initialized
+}
+
+func TestPodLifecycleManagerRunPodInitContainerFailsWithRestartNever(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{})
+
+	pod := newInitTestPod(shared.RestartNever)
+
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	expectSandbox(mockRuntime)
+	mockRuntime.EXPECT().ImageExists("init-image").Return(true, nil)
+	mockRuntime.EXPECT().CreateContainer(pod.InitContainers[0], "sandboxID").Return("initID", nil)
+	mockRuntime.EXPECT().StartContainer("initID").Return(nil)
+	mockRuntime.EXPECT().InspectContainer("initID").Return(newExitedInspectResponse(1), nil)
+
+	// Act
+	manager.RunPod(pod)
+
+	// Assert
+	assert.Equal(t, shared.PodFailed, pod.Status.Phase)
+	assert.Equal(t, "InitContainerFailed", pod.Status.Reason)
+	assert.Equal(t, "ContainersNotInitialized", pod.Status.Conditions[0].Reason)
+	assert.False(t, pod.Status.Conditions[0].Status)
+	assert.Equal(t, "ContainerCreating", pod.Status.ContainerStatuses[0].State.Waiting.Reason)
+}
+
+func TestPodLifecycleManagerRunPodRestartsFailedInitContainer(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{}).(*PodLifecycleManager)
+	var backoffs []time.Duration
+	manager.sleep = func(backoff time.Duration) { backoffs = append(backoffs, backoff) }
+
+	pod := newInitTestPod(shared.RestartOnFailure)
+
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	expectSandbox(mockRuntime)
+	mockRuntime.EXPECT().ImageExists(gomock.Any()).AnyTimes().Return(true, nil)
+	mockRuntime.EXPECT().CreateContainer(pod.InitContainers[0], "sandboxID").Return("initID", nil)
+	mockRuntime.EXPECT().StartContainer("initID").Times(2).Return(nil)
+	gomock.InOrder(
+		mockRuntime.EXPECT().InspectContainer("initID").Return(newExitedInspectResponse(1), nil),
+		mockRuntime.EXPECT().InspectContainer("initID").Return(newExitedInspectResponse(0), nil),
+	)
+	mockRuntime.EXPECT().CreateContainer(gomock.Any(), "sandboxID").Return("containerID", nil)
+	mockRuntime.EXPECT().StartContainer("containerID").Return(nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newRunningInspectResponse(), nil)
+
+	// Act
+	manager.RunPod(pod)
+
+	// Assert
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+	assert.Equal(t, []time.Duration{initialRestartBackoff}, backoffs)
+	status := pod.Status.InitContainerStatuses[0]
+	assert.Equal(t, 1, status.RestartCount)
+	assert.Equal(t, 1, status.LastState.Terminated.ExitCode)
+	assert.Equal(t, 0, status.State.Terminated.ExitCode)
+}
+
+func TestPodLifecycleManagerSyncPodStatusTerminatedContainer(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{})
+
+	pod := &shared.Pod{
+		ID:         "pod-1",
+		ObjectMeta: shared.ObjectMeta{Namespace: "default"},
+		Containers: []shared.Container{{ID: "containerID", Image: "example-image"}},
+		Status:     shared.PodStatus{Phase: shared.PodRunning},
+		RestartPolicy: shared.RestartNever,
+	}
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newInspectResponse(types.ContainerState{
+		Status:     "exited",
+		ExitCode:   137,
+		OOMKilled:  true,
+		StartedAt:  "2024-01-02T03:04:05Z",
+		FinishedAt: "2024-01-02T03:05:05Z",
+	}), nil)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, pod *shared.Pod) error {
+		terminated := pod.Status.ContainerStatuses[0].State.Terminated
+		assert.Equal(t, 137, terminated.ExitCode)
+		assert.Equal(t, "OOMKilled", terminated.Reason)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 5, 5, 0, time.UTC), terminated.FinishedAt)
+		assert.Zero(t, pod.Status.ContainerStatuses[0].RestartCount)
+		return nil
+	})
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, shared.PodFailed, pod.Status.Phase)
+	assert.Equal(t, "OOMKilled", pod.Status.Reason)
+}
+
+func newCrashedTestPod(restartPolicy shared.RestartPolicy, restartCount int) *shared.Pod {
+	return &shared.Pod{
+		ID:            "pod-1",
+		ObjectMeta:    shared.ObjectMeta{Namespace: "default"},
+		Containers:    []shared.Container{{ID: "containerID", Image: "example-image"}},
+		RestartPolicy: restartPolicy,
+		Status: shared.PodStatus{
+			Phase:             shared.PodRunning,
+			ContainerStatuses: []shared.ContainerStatus{{ContainerID: "containerID", RestartCount: restartCount}},
+		},
+	}
+}
+
+func newExitedInspectResponse(exitCode int) types.ContainerJSON {
+	return newInspectResponse(types.ContainerState{
+		Status:     "exited",
+		ExitCode:   exitCode,
+		StartedAt:  "2024-01-02T03:04:05Z",
+		FinishedAt: "2024-01-02T03:05:05Z",
+	})
+}
+
+func TestPodLifecycleManagerSyncPodStatusRestartsCrashedContainer(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{}).(*PodLifecycleManager)
+	manager.now = func() time.Time { return time.Date(2024, 1, 2, 3, 6, 0, 0, time.UTC) }
+
+	pod := newCrashedTestPod(shared.RestartAlways, 2)
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	gomock.InOrder(
+		mockRuntime.EXPECT().InspectContainer("containerID").Return(newExitedInspectResponse(1), nil),
+		mockRuntime.EXPECT().StartContainer("containerID").Return(nil),
+		mockRuntime.EXPECT().InspectContainer("containerID").Return(newInspectResponse(types.ContainerState{
+			Status:    "running",
+			Running:   true,
+			StartedAt: "2024-01-02T03:06:00Z",
+		}), nil),
+	)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	status := pod.Status.ContainerStatuses[0]
+	assert.Equal(t, 3, status.RestartCount)
+	assert.NotNil(t, status.State.Running)
+	assert.Equal(t, 1, status.LastState.Terminated.ExitCode)
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+}
+
+func TestPodLifecycleManagerSyncPodStatusCrashLoopBackOff(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{}).(*PodLifecycleManager)
+	manager.now = func() time.Time { return time.Date(2024, 1, 2, 3, 5, 30, 0, time.UTC) }
+
+	pod := newCrashedTestPod(shared.RestartOnFailure, 2) // Backs off for 40s, only 25s passed
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newExitedInspectResponse(1), nil)
+	mockRuntime.EXPECT().StartContainer(gomock.Any()).Times(0)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	status := pod.Status.ContainerStatuses[0]
+	assert.Equal(t, 2, status.RestartCount)
+	assert.Equal(t, "CrashLoopBackOff", status.State.Waiting.Reason)
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+}
+
+func TestPodLifecycleManagerSyncPodStatusSucceeded(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mocks.NewMockProber(ctrl), PodNetwork{})
+
+	pod := newCrashedTestPod(shared.RestartOnFailure, 0)
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newExitedInspectResponse(0), nil)
+	mockRuntime.EXPECT().StartContainer(gomock.Any()).Times(0)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, shared.PodSucceeded, pod.Status.Phase)
+	assert.Equal(t, "Completed", pod.Status.Reason)
+}
+
+func newProbedTestPod(container shared.Container) *shared.Pod {
+	container.ID = "containerID"
+	return &shared.Pod{
+		ID:         "pod-1",
+		ObjectMeta: shared.ObjectMeta{Namespace: "default"},
+		Containers: []shared.Container{container},
+		Status: shared.PodStatus{
+			Phase:             shared.PodRunning,
+			ContainerStatuses: []shared.ContainerStatus{{ContainerID: "containerID"}},
+		},
+	}
+}
+
+func newRunningInspectResponse() types.ContainerJSON {
+	return newInspectResponse(types.ContainerState{Status: "running", Running: true, StartedAt: "2024-01-02T03:04:05Z"})
+}
+
+func TestPodLifecycleManagerSyncPodStatusReadinessProbe(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockProber := mocks.NewMockProber(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mockProber, PodNetwork{})
+
+	readinessProbe := &shared.Probe{HTTPGet: &shared.HTTPGetAction{Path: "/healthz", Port: 8080}}
+	pod := newProbedTestPod(shared.Container{ReadinessProbe: readinessProbe})
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newRunningInspectResponse(), nil)
+	mockProber.EXPECT().Probe(gomock.Any(), "containerID", readinessProbe).Return(errors.New("connection refused"))
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, pod.Status.ContainerStatuses[0].Started)
+	assert.False(t, pod.Status.ContainerStatuses[0].Ready)
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+	for _, condition := range pod.Status.Conditions {
+		assert.False(t, condition.Status)
+	}
+}
+
+func TestPodLifecycleManagerSyncPodStatusStartupProbeHoldsBackLiveness(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockProber := mocks.NewMockProber(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mockProber, PodNetwork{})
+
+	startupProbe := &shared.Probe{Exec: &shared.ExecAction{Command: []string{"cat", "/tmp/started"}}, FailureThreshold: 30}
+	livenessProbe := &shared.Probe{TCPSocket: &shared.TCPSocketAction{Port: 8080}}
+	pod := newProbedTestPod(shared.Container{StartupProbe: startupProbe, LivenessProbe: livenessProbe})
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(newRunningInspectResponse(), nil)
+	mockProber.EXPECT().Probe(gomock.Any(), "containerID", startupProbe).Return(errors.New("no such file"))
+	mockProber.EXPECT().Probe(gomock.Any(), "containerID", livenessProbe).Times(0)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, pod.Status.ContainerStatuses[0].Started)
+	assert.False(t, pod.Status.ContainerStatuses[0].Ready)
+}
+
+func TestPodLifecycleManagerSyncPodStatusLivenessProbeStopsContainer(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockProber := mocks.NewMockProber(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mockProber, PodNetwork{}).(*PodLifecycleManager)
+	manager.now = func() time.Time { return time.Date(2024, 1, 2, 3, 6, 0, 0, time.UTC) }
+
+	livenessProbe := &shared.Probe{TCPSocket: &shared.TCPSocketAction{Port: 8080}, FailureThreshold: 1}
+	pod := newProbedTestPod(shared.Container{LivenessProbe: livenessProbe})
+	pod.RestartPolicy = shared.RestartAlways
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	gomock.InOrder(
+		mockRuntime.EXPECT().InspectContainer("containerID").Return(newRunningInspectResponse(), nil),
+		mockProber.EXPECT().Probe(gomock.Any(), "containerID", livenessProbe).Return(errors.New("connection refused")),
+		mockRuntime.EXPECT().StopContainer("containerID").Return(nil),
+		mockRuntime.EXPECT().InspectContainer("containerID").Return(newInspectResponse(types.ContainerState{
+			Status:     "exited",
+			ExitCode:   137,
+			StartedAt:  "2024-01-02T03:04:05Z",
+			FinishedAt: "2024-01-02T03:06:00Z",
+		}), nil),
+	)
+	mockRuntime.EXPECT().StartContainer(gomock.Any()).Times(0) // Restarted after its back-off
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	status := pod.Status.ContainerStatuses[0]
+	assert.Equal(t, "CrashLoopBackOff", status.State.Waiting.Reason)
+	assert.Equal(t, 137, status.LastState.Terminated.ExitCode)
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+}
+
+func TestPodLifecycleManagerSyncPodStatusRestartsUnhealthyContainerOnFailure(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockProber := mocks.NewMockProber(ctrl)
+	manager := NewPodLifecycleManager(mockRuntime, mockPodRepo, mockProber, PodNetwork{}).(*PodLifecycleManager)
+	manager.now = func() time.Time { return time.Date(2024, 1, 2, 3, 6, 0, 0, time.UTC) }
+
+	livenessProbe := &shared.Probe{TCPSocket: &shared.TCPSocketAction{Port: 8080}, FailureThreshold: 1}
+	pod := newProbedTestPod(shared.Container{LivenessProbe: livenessProbe})
+	pod.RestartPolicy = shared.RestartOnFailure
+
+	mockPodRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pod-1").Return(pod, nil)
+	gomock.InOrder(
+		mockRuntime.EXPECT().InspectContainer("containerID").Return(newRunningInspectResponse(), nil),
+		mockProber.EXPECT().Probe(gomock.Any(), "containerID", livenessProbe).DoAndReturn(func(_ context.Context, _ string, _ *shared.Probe) error {
+			assert.True(t, manager.mutex.TryLock(), "probes must not hold the mutex")
+			manager.mutex.Unlock()
+			return errors.New("connection refused")
+		}),
+		mockRuntime.EXPECT().StopContainer("containerID").Return(nil),
+		mockRuntime.EXPECT().InspectContainer("containerID").Return(newInspectResponse(types.ContainerState{
+			Status:     "exited",
+			ExitCode:   0, // Shut down gracefully on SIGTERM
+			StartedAt:  "2024-01-02T03:04:05Z",
+			FinishedAt: "2024-01-02T03:06:00Z",
+		}), nil),
+	)
+	mockPodRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Act
+	err := manager.SyncPodStatus(pod)
+
+	// Assert
+	assert.NoError(t, err)
+	status := pod.Status.ContainerStatuses[0]
+	assert.Equal(t, "CrashLoopBackOff", status.State.Waiting.Reason)
+	assert.Equal(t, "Unhealthy", status.LastState.Terminated.Reason)
+	assert.Equal(t, shared.PodRunning, pod.Status.Phase)
+}
+
+func TestRestartBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, restartBackoff(0))
+	assert.Equal(t, 40*time.Second, restartBackoff(2))
+	assert.Equal(t, 5*time.Minute, restartBackoff(10))
 }
 
 // func TestPodLifecycleManagerExecuteCommandInContainer(t *testing.T) {
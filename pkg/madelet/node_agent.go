@@ -0,0 +1,251 @@
+package madelet
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/shared"
+
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHeartbeatInterval = 10 * time.Second
+	DefaultPodStatusInterval = 5 * time.Second
+	leaseDurationFactor      = 4 // A lease stays valid for this many missed heartbeats
+	podRewatchDelay          = 5 * time.Second
+)
+
+type NodeAgentConfig struct {
+	NodeID            string
+	NodeName          string
+	Labels            map[string]string
+	HeartbeatInterval time.Duration
+	PodStatusInterval time.Duration // Interval between checks of the containers of the running pods
+}
+
+// Agent running on every node: it registers the node with its actual capacity, keeps its lease alive
+// and runs the pods the scheduler assigned to it
+type NodeAgent struct {
+	Config     NodeAgentConfig
+	NodeRepo   etcd.NodeRepository
+	LeaseRepo  etcd.NodeLeaseRepository
+	PodRepo    etcd.PodRepository
+	PodManager PodManager
+
+	mutex        sync.Mutex
+	startingPods map[string]bool
+}
+
+func NewNodeAgent(
+	config NodeAgentConfig,
+	nodeRepo etcd.NodeRepository,
+	leaseRepo etcd.NodeLeaseRepository,
+	podRepo etcd.PodRepository,
+	podManager PodManager,
+) *NodeAgent {
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if config.PodStatusInterval <= 0 {
+		config.PodStatusInterval = DefaultPodStatusInterval
+	}
+	return &NodeAgent{
+		Config:       config,
+		NodeRepo:     nodeRepo,
+		LeaseRepo:    leaseRepo,
+		PodRepo:      podRepo,
+		PodManager:   podManager,
+		startingPods: make(map[string]bool),
+	}
+}
+
+func (a *NodeAgent) Run(ctx context.Context) error {
+	capacity, err := ReadHostCapacity()
+	if err != nil {
+		return err
+	}
+	err = shared.RetryOnConflict(func() error {
+		return a.registerNode(ctx, capacity)
+	})
+	if err != nil {
+		return err
+	}
+	a.renewLease(ctx)
+
+	go a.heartbeat(ctx)
+	go a.monitorPods(ctx)
+
+	// The pods are listed and watched anew whenever the watch ends, until the agent is stopped
+	for {
+		a.watchPods(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(podRewatchDelay):
+		}
+	}
+}
+
+// Creates the node on first start, and refreshes its capacity and status on later ones
+func (a *NodeAgent) registerNode(ctx context.Context, capacity shared.Resources) error {
+	node, err := a.NodeRepo.GetNodeByID(ctx, a.Config.NodeID)
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if !errors.As(err, &errNotFound) {
+			return err
+		}
+
+		node = &shared.Node{
+			ID:           a.Config.NodeID,
+			ObjectMeta:   shared.ObjectMeta{Name: a.Config.NodeName, Labels: a.Config.Labels},
+			Status:       shared.NodeReady,
+			Capacity:     capacity,
+			AgentManaged: true,
+		}
+		shared.Log.Infof("Registering node %s with %d CPUs and %d MB of memory", node.ID, capacity.CPU, capacity.Memory)
+		return a.NodeRepo.CreateNode(ctx, node)
+	}
+
+	node.Name = a.Config.NodeName
+	node.Status = shared.NodeReady
+	node.Capacity = capacity
+	node.AgentManaged = true
+	if a.Config.Labels != nil {
+		node.Labels = a.Config.Labels
+	}
+	shared.Log.Infof("Re-registering node %s with %d CPUs and %d MB of memory", node.ID, capacity.CPU, capacity.Memory)
+	return a.NodeRepo.UpdateNode(ctx, node)
+}
+
+func (a *NodeAgent) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.renewLease(ctx)
+		}
+	}
+}
+
+func (a *NodeAgent) monitorPods(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.PodStatusInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.syncPodStatuses(ctx)
+		}
+	}
+}
+
+// Reports the state of the containers of the running pods of this node and restarts the crashed ones
+func (a *NodeAgent) syncPodStatuses(ctx context.Context) {
+	pods, err := a.PodRepo.ListPods(ctx, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to list pods of node %s: %v", a.Config.NodeID, err)
+		return
+	}
+
+	for _, pod := range pods {
+		if pod.NodeID != a.Config.NodeID || pod.Status.Phase != shared.PodRunning {
+			continue
+		}
+		if err := a.PodManager.SyncPodStatus(&pod); err != nil {
+			shared.Log.Errorf("Failed to sync status of pod %s: %v", pod.ID, err)
+		}
+	}
+}
+
+func (a *NodeAgent) renewLease(ctx context.Context) {
+	lease := &shared.NodeLease{
+		NodeID:          a.Config.NodeID,
+		RenewTime:       time.Now(),
+		DurationSeconds: int(a.Config.HeartbeatInterval.Seconds()) * leaseDurationFactor,
+	}
+	if err := a.LeaseRepo.RenewNodeLease(ctx, lease); err != nil {
+		shared.Log.Errorf("Failed to renew lease of node %s: %v", a.Config.NodeID, err)
+	}
+}
+
+func (a *NodeAgent) syncPods(ctx context.Context) error {
+	pods, err := a.PodRepo.ListPods(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		a.handlePodPut(pod)
+	}
+	return nil
+}
+
+// Runs the pods assigned to this node until the watch ends
+func (a *NodeAgent) watchPods(ctx context.Context) {
+	// Watched before the pods are listed so that no change in between is missed
+	events := a.PodRepo.WatchPods(ctx)
+
+	// Pods may have been assigned while the agent was down or not watching
+	if err := a.syncPods(ctx); err != nil {
+		shared.Log.Errorf("Failed to sync pods of node %s: %v", a.Config.NodeID, err)
+	}
+	shared.Log.Infof("Watching pods of node %s...", a.Config.NodeID)
+
+	for event := range events {
+		switch event.Type {
+		case shared.WatchEventCreate, shared.WatchEventUpdate:
+			a.handlePodPut(*event.Object)
+		case shared.WatchEventDelete:
+			a.handlePodDelete(*event.Object)
+		case shared.WatchEventError:
+			shared.Log.Errorf("Watch of pods of node %s failed: %v", a.Config.NodeID, event.Err)
+		}
+	}
+}
+
+// Starts pods that were scheduled onto this node and are not running yet
+func (a *NodeAgent) handlePodPut(pod shared.Pod) {
+	if pod.NodeID != a.Config.NodeID || pod.Status.Phase != shared.PodScheduled {
+		return
+	}
+
+	a.mutex.Lock()
+	if a.startingPods[pod.ID] {
+		a.mutex.Unlock()
+		return
+	}
+	a.startingPods[pod.ID] = true
+	a.mutex.Unlock()
+
+	shared.Log.Infof("Starting pod %s on node %s", pod.ID, a.Config.NodeID)
+	go func() {
+		a.PodManager.RunPod(&pod)
+
+		a.mutex.Lock()
+		delete(a.startingPods, pod.ID)
+		a.mutex.Unlock()
+	}()
+}
+
+// The pod is already gone from etcd; only its containers are left to clean up
+func (a *NodeAgent) handlePodDelete(pod shared.Pod) {
+	if pod.NodeID != a.Config.NodeID {
+		return
+	}
+
+	shared.Log.Infof("Stopping pod %s on node %s", pod.ID, a.Config.NodeID)
+	// The API server gave the IP back when it deleted the pod, releasing it again could free it after it was handed out anew
+	pod.Status.PodIP = ""
+	if err := a.PodManager.StopPod(&pod); err != nil {
+		shared.Log.Errorf("Failed to stop pod %s: %v", pod.ID, err)
+	}
+}
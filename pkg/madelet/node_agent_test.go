@@ -0,0 +1,160 @@
+package madelet
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeAgentRegistersNewNode(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNodeRepo := mocks.NewMockNodeRepository(ctrl)
+	config := NodeAgentConfig{NodeID: "node-1", NodeName: "worker", Labels: map[string]string{"zone": "eu"}}
+	agent := NewNodeAgent(config, mockNodeRepo, nil, nil, nil)
+
+	capacity := shared.Resources{CPU: 4, Memory: 8192}
+	mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node-1").Return(nil, &shared.ErrNotFound{})
+	mockNodeRepo.EXPECT().CreateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+		assert.Equal(t, capacity, node.Capacity)
+		assert.Equal(t, shared.NodeReady, node.Status)
+		assert.True(t, node.AgentManaged)
+		assert.Equal(t, "eu", node.Labels["zone"])
+		return nil
+	})
+
+	// Act
+	err := agent.registerNode(context.Background(), capacity)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestNodeAgentReRegistersExistingNode(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNodeRepo := mocks.NewMockNodeRepository(ctrl)
+	agent := NewNodeAgent(NodeAgentConfig{NodeID: "node-1"}, mockNodeRepo, nil, nil, nil)
+
+	existingNode := &shared.Node{ID: "node-1", Status: shared.NodeOffline, Used: shared.Resources{CPU: 1, Memory: 256}}
+	mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node-1").Return(existingNode, nil)
+	mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+		assert.Equal(t, shared.NodeReady, node.Status)
+		assert.Equal(t, 4, node.Capacity.CPU)
+		assert.Equal(t, 1, node.Used.CPU) // Resources of pods already on the node stay accounted for
+		return nil
+	})
+
+	// Act
+	err := agent.registerNode(context.Background(), shared.Resources{CPU: 4, Memory: 8192})
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestNodeAgentRunsOnlyItsScheduledPods(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	agent := NewNodeAgent(NodeAgentConfig{NodeID: "node-1"}, nil, nil, nil, mockPodManager)
+
+	started := make(chan string, 1)
+	mockPodManager.EXPECT().RunPod(gomock.Any()).Times(1).Do(func(pod *shared.Pod) {
+		started <- pod.ID
+	})
+
+	// Act
+	agent.handlePodPut(shared.Pod{ID: "other-node-pod", NodeID: "node-2", Status: shared.PodStatus{Phase: shared.PodScheduled}})
+	agent.handlePodPut(shared.Pod{ID: "running-pod", NodeID: "node-1", Status: shared.PodStatus{Phase: shared.PodRunning}})
+	agent.handlePodPut(shared.Pod{ID: "scheduled-pod", NodeID: "node-1", Status: shared.PodStatus{Phase: shared.PodScheduled}})
+
+	// Assert
+	select {
+	case podID := <-started:
+		assert.Equal(t, "scheduled-pod", podID)
+	case <-time.After(time.Second):
+		t.Fatal("scheduled pod was not started")
+	}
+}
+
+func TestNodeAgentStopsDeletedPods(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	agent := NewNodeAgent(NodeAgentConfig{NodeID: "node-1"}, nil, nil, nil, mockPodManager)
+
+	mockPodManager.EXPECT().StopPod(gomock.Any()).Times(1).DoAndReturn(func(pod *shared.Pod) error {
+		assert.Equal(t, "pod-1", pod.ID)
+		assert.Empty(t, pod.Status.PodIP)
+		return nil
+	})
+
+	// Act
+	agent.handlePodDelete(shared.Pod{ID: "pod-1", NodeID: "node-1", Status: shared.PodStatus{PodIP: "10.244.0.2"}})
+	agent.handlePodDelete(shared.Pod{ID: "pod-2", NodeID: "node-2"})
+}
+
+func TestNodeAgentListsPodsAgainWhenWatchingThem(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	agent := NewNodeAgent(NodeAgentConfig{NodeID: "node-1"}, nil, nil, mockPodRepo, mockPodManager)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The watch ended right away, the pod assigned meanwhile is only found by listing
+	events := make(chan shared.WatchEvent[shared.Pod])
+	close(events)
+	scheduledPod := shared.Pod{ID: "pod-1", NodeID: "node-1", Status: shared.PodStatus{Phase: shared.PodScheduled}}
+
+	started := make(chan struct{})
+	gomock.InOrder(
+		mockPodRepo.EXPECT().WatchPods(ctx).Return(events),
+		mockPodRepo.EXPECT().ListPods(ctx, "").Return([]shared.Pod{scheduledPod}, nil),
+	)
+	mockPodManager.EXPECT().RunPod(gomock.Any()).Times(1).Do(func(pod *shared.Pod) {
+		assert.Equal(t, "pod-1", pod.ID)
+		close(started)
+	})
+
+	// Act
+	agent.watchPods(ctx)
+
+	// Assert
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("pod was not started")
+	}
+}
+
+func TestParseMemTotal(t *testing.T) {
+	memInfo := "MemTotal:       16318412 kB\nMemFree:         1204952 kB\n"
+
+	memory, err := parseMemTotal(strings.NewReader(memInfo))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 15935, memory)
+
+	_, err = parseMemTotal(strings.NewReader("MemFree: 1 kB\n"))
+	assert.Error(t, err)
+}
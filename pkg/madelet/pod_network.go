@@ -0,0 +1,24 @@
+package madelet
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/networking"
+)
+
+// Docker network the sandboxes of the pods join
+const DefaultPodNetworkName = "maden"
+
+// Network of the pods of the node, whose IPs are handed out from the pod CIDR shared by every node
+type PodNetwork struct {
+	Name      string
+	CIDR      string
+	IPManager networking.IPManager // The sandboxes stay on the default bridge, with IPs picked by Docker, when nil
+}
+
+func NewPodNetwork(config networking.IPAMConfig, repo etcd.IPAllocationRepository) (PodNetwork, error) {
+	ipManager, err := networking.NewPodIPManager(config, repo)
+	if err != nil {
+		return PodNetwork{}, err
+	}
+	return PodNetwork{Name: DefaultPodNetworkName, CIDR: config.PodCIDR, IPManager: ipManager}, nil
+}
@@ -0,0 +1,207 @@
+package madelet
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+const (
+	defaultProbePeriod           = 10 * time.Second
+	defaultProbeTimeout          = 1 * time.Second
+	defaultProbeSuccessThreshold = 1
+	defaultProbeFailureThreshold = 3
+)
+
+type ContainerProber struct {
+	Runtime ContainerRuntimeInterface
+}
+
+func NewContainerProber(runtime ContainerRuntimeInterface) Prober {
+	return &ContainerProber{Runtime: runtime}
+}
+
+// Runs a single check of the container, the error tells why it failed
+func (p *ContainerProber) Probe(ctx context.Context, containerID string, probe *shared.Probe) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout(probe))
+	defer cancel()
+
+	switch {
+	case probe.Exec != nil:
+		return p.probeExec(ctx, containerID, probe.Exec)
+	case probe.HTTPGet != nil:
+		return p.probeHTTPGet(ctx, containerID, probe.HTTPGet)
+	case probe.TCPSocket != nil:
+		return p.probeTCPSocket(ctx, containerID, probe.TCPSocket)
+	default:
+		return errors.New("probe has no action")
+	}
+}
+
+func (p *ContainerProber) probeExec(ctx context.Context, containerID string, action *shared.ExecAction) error {
+	execConfig := types.ExecConfig{Cmd: action.Command, AttachStdout: true, AttachStderr: true}
+	execID, err := p.Runtime.ExecCommandCreate(ctx, containerID, execConfig)
+	if err != nil {
+		return err
+	}
+
+	execAttach, err := p.Runtime.ExecCommandAttach(ctx, execID, types.ExecStartCheck{}, false)
+	if err != nil {
+		return err
+	}
+	defer execAttach.Close()
+
+	// The command has finished once its output is closed
+	if _, err := io.Copy(io.Discard, execAttach.Reader); err != nil {
+		return err
+	}
+
+	inspect, err := p.Runtime.ExecCommandInspect(ctx, execID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("command %v exited with code %d", action.Command, inspect.ExitCode)
+	}
+	return nil
+}
+
+func (p *ContainerProber) probeHTTPGet(ctx context.Context, containerID string, action *shared.HTTPGetAction) error {
+	host, err := p.probeHost(containerID, action.Host)
+	if err != nil {
+		return err
+	}
+
+	scheme := action.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(action.Port)), action.Path)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("GET %s returned status %d", url, response.StatusCode)
+	}
+	return nil
+}
+
+func (p *ContainerProber) probeTCPSocket(ctx context.Context, containerID string, action *shared.TCPSocketAction) error {
+	host, err := p.probeHost(containerID, action.Host)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(action.Port)))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// Network probes target the IP of the container unless they name a host.
+// Containers in the network namespace of a sandbox have the IP of the sandbox
+func (p *ContainerProber) probeHost(containerID string, host string) (string, error) {
+	if host != "" {
+		return host, nil
+	}
+
+	inspect, err := p.Runtime.InspectContainer(containerID)
+	if err != nil {
+		return "", err
+	}
+	if inspect.ContainerJSONBase != nil && inspect.HostConfig != nil && inspect.HostConfig.NetworkMode.IsContainer() {
+		if inspect, err = p.Runtime.InspectContainer(inspect.HostConfig.NetworkMode.ConnectedContainer()); err != nil {
+			return "", err
+		}
+	}
+
+	ip := containerIP(inspect)
+	if ip == "" {
+		return "", fmt.Errorf("container %s has no IP address", containerID)
+	}
+	return ip, nil
+}
+
+// Checks happen at most once per pod status sync, see PodStatusInterval
+func probePeriod(probe *shared.Probe) time.Duration {
+	if probe.PeriodSeconds <= 0 {
+		return defaultProbePeriod
+	}
+	return time.Duration(probe.PeriodSeconds) * time.Second
+}
+
+func probeTimeout(probe *shared.Probe) time.Duration {
+	if probe.TimeoutSeconds <= 0 {
+		return defaultProbeTimeout
+	}
+	return time.Duration(probe.TimeoutSeconds) * time.Second
+}
+
+func probeSuccessThreshold(probe *shared.Probe) int {
+	if probe.SuccessThreshold <= 0 {
+		return defaultProbeSuccessThreshold
+	}
+	return probe.SuccessThreshold
+}
+
+func probeFailureThreshold(probe *shared.Probe) int {
+	if probe.FailureThreshold <= 0 {
+		return defaultProbeFailureThreshold
+	}
+	return probe.FailureThreshold
+}
+
+// Outcome of the consecutive checks of one probe of a container since it last started
+type probeResult struct {
+	containerStartedAt time.Time
+	lastProbeTime      time.Time
+	successes          int
+	failures           int
+	succeeded          bool
+	failed             bool
+}
+
+// Counts a check towards the thresholds of the probe, flipping the result once one is reached
+func (r *probeResult) record(probe *shared.Probe, err error, now time.Time) {
+	r.lastProbeTime = now
+	if err == nil {
+		r.successes++
+		r.failures = 0
+		if r.successes >= probeSuccessThreshold(probe) {
+			r.succeeded, r.failed = true, false
+		}
+		return
+	}
+
+	r.failures++
+	r.successes = 0
+	if r.failures >= probeFailureThreshold(probe) {
+		r.succeeded, r.failed = false, true
+	}
+}
+
+func (r *probeResult) isDue(probe *shared.Probe, now time.Time) bool {
+	if now.Before(r.containerStartedAt.Add(time.Duration(probe.InitialDelaySeconds) * time.Second)) {
+		return false
+	}
+	return r.lastProbeTime.IsZero() || !now.Before(r.lastProbeTime.Add(probePeriod(probe)))
+}
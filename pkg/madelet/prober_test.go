@@ -0,0 +1,157 @@
+package madelet
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHijackedResponse(output string) *types.HijackedResponse {
+	clientConn, serverConn := net.Pipe()
+	serverConn.Close()
+	return &types.HijackedResponse{Conn: clientConn, Reader: bufio.NewReader(strings.NewReader(output))}
+}
+
+func TestContainerProberExec(t *testing.T) {
+	tests := []struct {
+		name      string
+		exitCode  int
+		expectErr bool
+	}{
+		{"Command succeeds", 0, false},
+		{"Command fails", 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+			prober := NewContainerProber(mockRuntime)
+			probe := &shared.Probe{Exec: &shared.ExecAction{Command: []string{"cat", "/tmp/healthy"}}}
+
+			mockRuntime.EXPECT().ExecCommandCreate(gomock.Any(), "containerID", gomock.Any()).Return("execID", nil)
+			mockRuntime.EXPECT().ExecCommandAttach(gomock.Any(), "execID", gomock.Any(), false).Return(newHijackedResponse("ok"), nil)
+			mockRuntime.EXPECT().ExecCommandInspect(gomock.Any(), "execID").Return(types.ContainerExecInspect{ExitCode: tt.exitCode}, nil)
+
+			// Act
+			err := prober.Probe(context.Background(), "containerID", probe)
+
+			// Assert
+			assert.Equal(t, tt.expectErr, err != nil)
+		})
+	}
+}
+
+func TestContainerProberHTTPGet(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+
+	prober := NewContainerProber(mocks.NewMockContainerRuntimeInterface(ctrl))
+	healthy := &shared.Probe{HTTPGet: &shared.HTTPGetAction{Path: "/healthz", Port: portNumber, Host: host}}
+	unhealthy := &shared.Probe{HTTPGet: &shared.HTTPGetAction{Path: "/broken", Port: portNumber, Host: host}}
+
+	// Act
+	healthyErr := prober.Probe(context.Background(), "containerID", healthy)
+	unhealthyErr := prober.Probe(context.Background(), "containerID", unhealthy)
+
+	// Assert
+	assert.NoError(t, healthyErr)
+	assert.Error(t, unhealthyErr)
+}
+
+func TestContainerProberTCPSocketUsesContainerIP(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	prober := NewContainerProber(mockRuntime)
+	probe := &shared.Probe{TCPSocket: &shared.TCPSocketAction{Port: port}}
+
+	inspect := newRunningInspectResponse()
+	inspect.NetworkSettings = &types.NetworkSettings{DefaultNetworkSettings: types.DefaultNetworkSettings{IPAddress: "127.0.0.1"}}
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(inspect, nil)
+
+	// Act
+	err = prober.Probe(context.Background(), "containerID", probe)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestProbeResultThresholds(t *testing.T) {
+	probe := &shared.Probe{SuccessThreshold: 2, FailureThreshold: 2}
+	now := time.Now()
+	result := probeResult{containerStartedAt: now}
+
+	result.record(probe, nil, now)
+	assert.False(t, result.succeeded)
+	result.record(probe, nil, now)
+	assert.True(t, result.succeeded)
+
+	result.record(probe, assert.AnError, now)
+	assert.True(t, result.succeeded) // A single failure is tolerated
+	result.record(probe, assert.AnError, now)
+	assert.True(t, result.failed)
+	assert.False(t, result.succeeded)
+}
+
+func TestContainerProberTCPSocketUsesSandboxIP(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	mockRuntime := mocks.NewMockContainerRuntimeInterface(ctrl)
+	prober := NewContainerProber(mockRuntime)
+	probe := &shared.Probe{TCPSocket: &shared.TCPSocketAction{Port: port}}
+
+	app := newRunningInspectResponse()
+	app.HostConfig = &container.HostConfig{NetworkMode: "container:sandboxID"}
+	sandbox := newRunningInspectResponse()
+	sandbox.NetworkSettings = &types.NetworkSettings{DefaultNetworkSettings: types.DefaultNetworkSettings{IPAddress: "127.0.0.1"}}
+	mockRuntime.EXPECT().InspectContainer("containerID").Return(app, nil)
+	mockRuntime.EXPECT().InspectContainer("sandboxID").Return(sandbox, nil)
+
+	// Act
+	err = prober.Probe(context.Background(), "containerID", probe)
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	shared "maden/pkg/shared"
 	reflect "reflect"
 
@@ -12,6 +13,85 @@ import (
 	mvccpb "go.etcd.io/etcd/api/v3/mvccpb"
 )
 
+// MockNamespaceController is a mock of NamespaceController interface.
+type MockNamespaceController struct {
+	ctrl     *gomock.Controller
+	recorder *MockNamespaceControllerMockRecorder
+}
+
+// MockNamespaceControllerMockRecorder is the mock recorder for MockNamespaceController.
+type MockNamespaceControllerMockRecorder struct {
+	mock *MockNamespaceController
+}
+
+// NewMockNamespaceController creates a new mock instance.
+func NewMockNamespaceController(ctrl *gomock.Controller) *MockNamespaceController {
+	mock := &MockNamespaceController{ctrl: ctrl}
+	mock.recorder = &MockNamespaceControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNamespaceController) EXPECT() *MockNamespaceControllerMockRecorder {
+	return m.recorder
+}
+
+// EnsureDefaultNamespace mocks base method.
+func (m *MockNamespaceController) EnsureDefaultNamespace(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureDefaultNamespace", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureDefaultNamespace indicates an expected call of EnsureDefaultNamespace.
+func (mr *MockNamespaceControllerMockRecorder) EnsureDefaultNamespace(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureDefaultNamespace", reflect.TypeOf((*MockNamespaceController)(nil).EnsureDefaultNamespace), ctx)
+}
+
+// HandleIncomingNamespace mocks base method.
+func (m *MockNamespaceController) HandleIncomingNamespace(ctx context.Context, namespaceSpec shared.NamespaceSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleIncomingNamespace", ctx, namespaceSpec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleIncomingNamespace indicates an expected call of HandleIncomingNamespace.
+func (mr *MockNamespaceControllerMockRecorder) HandleIncomingNamespace(ctx, namespaceSpec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingNamespace", reflect.TypeOf((*MockNamespaceController)(nil).HandleIncomingNamespace), ctx, namespaceSpec)
+}
+
+// HandleNamespaceDeletion mocks base method.
+func (m *MockNamespaceController) HandleNamespaceDeletion(ctx context.Context, namespaceName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleNamespaceDeletion", ctx, namespaceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleNamespaceDeletion indicates an expected call of HandleNamespaceDeletion.
+func (mr *MockNamespaceControllerMockRecorder) HandleNamespaceDeletion(ctx, namespaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNamespaceDeletion", reflect.TypeOf((*MockNamespaceController)(nil).HandleNamespaceDeletion), ctx, namespaceName)
+}
+
+// ValidateNamespace mocks base method.
+func (m *MockNamespaceController) ValidateNamespace(ctx context.Context, namespaceName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateNamespace", ctx, namespaceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateNamespace indicates an expected call of ValidateNamespace.
+func (mr *MockNamespaceControllerMockRecorder) ValidateNamespace(ctx, namespaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateNamespace", reflect.TypeOf((*MockNamespaceController)(nil).ValidateNamespace), ctx, namespaceName)
+}
+
 // MockDeploymentController is a mock of DeploymentController interface.
 type MockDeploymentController struct {
 	ctrl     *gomock.Controller
@@ -36,17 +116,17 @@ func (m *MockDeploymentController) EXPECT() *MockDeploymentControllerMockRecorde
 }
 
 // HandleIncomingDeployment mocks base method.
-func (m *MockDeploymentController) HandleIncomingDeployment(deploymentSpec shared.DeploymentSpec) error {
+func (m *MockDeploymentController) HandleIncomingDeployment(ctx context.Context, deploymentSpec shared.DeploymentSpec) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleIncomingDeployment", deploymentSpec)
+	ret := m.ctrl.Call(m, "HandleIncomingDeployment", ctx, deploymentSpec)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleIncomingDeployment indicates an expected call of HandleIncomingDeployment.
-func (mr *MockDeploymentControllerMockRecorder) HandleIncomingDeployment(deploymentSpec interface{}) *gomock.Call {
+func (mr *MockDeploymentControllerMockRecorder) HandleIncomingDeployment(ctx, deploymentSpec interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingDeployment", reflect.TypeOf((*MockDeploymentController)(nil).HandleIncomingDeployment), deploymentSpec)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingDeployment", reflect.TypeOf((*MockDeploymentController)(nil).HandleIncomingDeployment), ctx, deploymentSpec)
 }
 
 // MockDeploymentUpdaterController is a mock of DeploymentUpdaterController interface.
@@ -96,18 +176,32 @@ func (mr *MockDeploymentUpdaterControllerMockRecorder) HandleDeploymentDelete(kv
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeploymentDelete", reflect.TypeOf((*MockDeploymentUpdaterController)(nil).HandleDeploymentDelete), kv)
 }
 
+// HandleDeploymentRollback mocks base method.
+func (m *MockDeploymentUpdaterController) HandleDeploymentRollback(ctx context.Context, deployment *shared.Deployment, toRevision int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDeploymentRollback", ctx, deployment, toRevision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleDeploymentRollback indicates an expected call of HandleDeploymentRollback.
+func (mr *MockDeploymentUpdaterControllerMockRecorder) HandleDeploymentRollback(ctx, deployment, toRevision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeploymentRollback", reflect.TypeOf((*MockDeploymentUpdaterController)(nil).HandleDeploymentRollback), ctx, deployment, toRevision)
+}
+
 // HandleDeploymentRolloutRestart mocks base method.
-func (m *MockDeploymentUpdaterController) HandleDeploymentRolloutRestart(deployment *shared.Deployment) error {
+func (m *MockDeploymentUpdaterController) HandleDeploymentRolloutRestart(ctx context.Context, deployment *shared.Deployment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleDeploymentRolloutRestart", deployment)
+	ret := m.ctrl.Call(m, "HandleDeploymentRolloutRestart", ctx, deployment)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleDeploymentRolloutRestart indicates an expected call of HandleDeploymentRolloutRestart.
-func (mr *MockDeploymentUpdaterControllerMockRecorder) HandleDeploymentRolloutRestart(deployment interface{}) *gomock.Call {
+func (mr *MockDeploymentUpdaterControllerMockRecorder) HandleDeploymentRolloutRestart(ctx, deployment interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeploymentRolloutRestart", reflect.TypeOf((*MockDeploymentUpdaterController)(nil).HandleDeploymentRolloutRestart), deployment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeploymentRolloutRestart", reflect.TypeOf((*MockDeploymentUpdaterController)(nil).HandleDeploymentRolloutRestart), ctx, deployment)
 }
 
 // HandleDeploymentUpdate mocks base method.
@@ -122,6 +216,236 @@ func (mr *MockDeploymentUpdaterControllerMockRecorder) HandleDeploymentUpdate(ol
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeploymentUpdate", reflect.TypeOf((*MockDeploymentUpdaterController)(nil).HandleDeploymentUpdate), oldKv, newKv)
 }
 
+// MockDeploymentReconciler is a mock of DeploymentReconciler interface.
+type MockDeploymentReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeploymentReconcilerMockRecorder
+}
+
+// MockDeploymentReconcilerMockRecorder is the mock recorder for MockDeploymentReconciler.
+type MockDeploymentReconcilerMockRecorder struct {
+	mock *MockDeploymentReconciler
+}
+
+// NewMockDeploymentReconciler creates a new mock instance.
+func NewMockDeploymentReconciler(ctrl *gomock.Controller) *MockDeploymentReconciler {
+	mock := &MockDeploymentReconciler{ctrl: ctrl}
+	mock.recorder = &MockDeploymentReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeploymentReconciler) EXPECT() *MockDeploymentReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockDeploymentReconciler) Enqueue(namespace, deploymentName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enqueue", namespace, deploymentName)
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockDeploymentReconcilerMockRecorder) Enqueue(namespace, deploymentName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockDeploymentReconciler)(nil).Enqueue), namespace, deploymentName)
+}
+
+// Run mocks base method.
+func (m *MockDeploymentReconciler) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockDeploymentReconcilerMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDeploymentReconciler)(nil).Run), ctx)
+}
+
+// MockDeploymentRolloutEngine is a mock of DeploymentRolloutEngine interface.
+type MockDeploymentRolloutEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeploymentRolloutEngineMockRecorder
+}
+
+// MockDeploymentRolloutEngineMockRecorder is the mock recorder for MockDeploymentRolloutEngine.
+type MockDeploymentRolloutEngineMockRecorder struct {
+	mock *MockDeploymentRolloutEngine
+}
+
+// NewMockDeploymentRolloutEngine creates a new mock instance.
+func NewMockDeploymentRolloutEngine(ctrl *gomock.Controller) *MockDeploymentRolloutEngine {
+	mock := &MockDeploymentRolloutEngine{ctrl: ctrl}
+	mock.recorder = &MockDeploymentRolloutEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeploymentRolloutEngine) EXPECT() *MockDeploymentRolloutEngineMockRecorder {
+	return m.recorder
+}
+
+// Rollout mocks base method.
+func (m *MockDeploymentRolloutEngine) Rollout(ctx context.Context, deployment *shared.Deployment, currentPods, outdatedPods []shared.Pod) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollout", ctx, deployment, currentPods, outdatedPods)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollout indicates an expected call of Rollout.
+func (mr *MockDeploymentRolloutEngineMockRecorder) Rollout(ctx, deployment, currentPods, outdatedPods interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollout", reflect.TypeOf((*MockDeploymentRolloutEngine)(nil).Rollout), ctx, deployment, currentPods, outdatedPods)
+}
+
+// MockGarbageCollector is a mock of GarbageCollector interface.
+type MockGarbageCollector struct {
+	ctrl     *gomock.Controller
+	recorder *MockGarbageCollectorMockRecorder
+}
+
+// MockGarbageCollectorMockRecorder is the mock recorder for MockGarbageCollector.
+type MockGarbageCollectorMockRecorder struct {
+	mock *MockGarbageCollector
+}
+
+// NewMockGarbageCollector creates a new mock instance.
+func NewMockGarbageCollector(ctrl *gomock.Controller) *MockGarbageCollector {
+	mock := &MockGarbageCollector{ctrl: ctrl}
+	mock.recorder = &MockGarbageCollectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGarbageCollector) EXPECT() *MockGarbageCollectorMockRecorder {
+	return m.recorder
+}
+
+// DeleteDeployment mocks base method.
+func (m *MockGarbageCollector) DeleteDeployment(ctx context.Context, namespace, deploymentName string, policy shared.DeletionPropagation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeployment", ctx, namespace, deploymentName, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeployment indicates an expected call of DeleteDeployment.
+func (mr *MockGarbageCollectorMockRecorder) DeleteDeployment(ctx, namespace, deploymentName, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockGarbageCollector)(nil).DeleteDeployment), ctx, namespace, deploymentName, policy)
+}
+
+// DeletePersistentVolume mocks base method.
+func (m *MockGarbageCollector) DeletePersistentVolume(ctx context.Context, volumeID string, policy shared.DeletionPropagation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePersistentVolume", ctx, volumeID, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePersistentVolume indicates an expected call of DeletePersistentVolume.
+func (mr *MockGarbageCollectorMockRecorder) DeletePersistentVolume(ctx, volumeID, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePersistentVolume", reflect.TypeOf((*MockGarbageCollector)(nil).DeletePersistentVolume), ctx, volumeID, policy)
+}
+
+// Run mocks base method.
+func (m *MockGarbageCollector) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockGarbageCollectorMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockGarbageCollector)(nil).Run), ctx)
+}
+
+// MockNodeUpdaterController is a mock of NodeUpdaterController interface.
+type MockNodeUpdaterController struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeUpdaterControllerMockRecorder
+}
+
+// MockNodeUpdaterControllerMockRecorder is the mock recorder for MockNodeUpdaterController.
+type MockNodeUpdaterControllerMockRecorder struct {
+	mock *MockNodeUpdaterController
+}
+
+// NewMockNodeUpdaterController creates a new mock instance.
+func NewMockNodeUpdaterController(ctrl *gomock.Controller) *MockNodeUpdaterController {
+	mock := &MockNodeUpdaterController{ctrl: ctrl}
+	mock.recorder = &MockNodeUpdaterControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeUpdaterController) EXPECT() *MockNodeUpdaterControllerMockRecorder {
+	return m.recorder
+}
+
+// HandleNodeCreate mocks base method.
+func (m *MockNodeUpdaterController) HandleNodeCreate(kv *mvccpb.KeyValue) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleNodeCreate", kv)
+}
+
+// HandleNodeCreate indicates an expected call of HandleNodeCreate.
+func (mr *MockNodeUpdaterControllerMockRecorder) HandleNodeCreate(kv interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNodeCreate", reflect.TypeOf((*MockNodeUpdaterController)(nil).HandleNodeCreate), kv)
+}
+
+// HandleNodeUpdate mocks base method.
+func (m *MockNodeUpdaterController) HandleNodeUpdate(oldKv, newKv *mvccpb.KeyValue) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleNodeUpdate", oldKv, newKv)
+}
+
+// HandleNodeUpdate indicates an expected call of HandleNodeUpdate.
+func (mr *MockNodeUpdaterControllerMockRecorder) HandleNodeUpdate(oldKv, newKv interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNodeUpdate", reflect.TypeOf((*MockNodeUpdaterController)(nil).HandleNodeUpdate), oldKv, newKv)
+}
+
+// MockNodeLifecycleController is a mock of NodeLifecycleController interface.
+type MockNodeLifecycleController struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeLifecycleControllerMockRecorder
+}
+
+// MockNodeLifecycleControllerMockRecorder is the mock recorder for MockNodeLifecycleController.
+type MockNodeLifecycleControllerMockRecorder struct {
+	mock *MockNodeLifecycleController
+}
+
+// NewMockNodeLifecycleController creates a new mock instance.
+func NewMockNodeLifecycleController(ctrl *gomock.Controller) *MockNodeLifecycleController {
+	mock := &MockNodeLifecycleController{ctrl: ctrl}
+	mock.recorder = &MockNodeLifecycleControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeLifecycleController) EXPECT() *MockNodeLifecycleControllerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockNodeLifecycleController) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockNodeLifecycleControllerMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockNodeLifecycleController)(nil).Run), ctx)
+}
+
 // MockServiceController is a mock of ServiceController interface.
 type MockServiceController struct {
 	ctrl     *gomock.Controller
@@ -146,17 +470,17 @@ func (m *MockServiceController) EXPECT() *MockServiceControllerMockRecorder {
 }
 
 // HandleIncomingService mocks base method.
-func (m *MockServiceController) HandleIncomingService(serviceSpec shared.ServiceSpec) error {
+func (m *MockServiceController) HandleIncomingService(ctx context.Context, serviceSpec shared.ServiceSpec) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleIncomingService", serviceSpec)
+	ret := m.ctrl.Call(m, "HandleIncomingService", ctx, serviceSpec)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleIncomingService indicates an expected call of HandleIncomingService.
-func (mr *MockServiceControllerMockRecorder) HandleIncomingService(serviceSpec interface{}) *gomock.Call {
+func (mr *MockServiceControllerMockRecorder) HandleIncomingService(ctx, serviceSpec interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingService", reflect.TypeOf((*MockServiceController)(nil).HandleIncomingService), serviceSpec)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingService", reflect.TypeOf((*MockServiceController)(nil).HandleIncomingService), ctx, serviceSpec)
 }
 
 // MockServiceUpdaterController is a mock of ServiceUpdaterController interface.
@@ -218,39 +542,39 @@ func (mr *MockServiceUpdaterControllerMockRecorder) HandleServiceUpdate(prevKv,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleServiceUpdate", reflect.TypeOf((*MockServiceUpdaterController)(nil).HandleServiceUpdate), prevKv, newKv)
 }
 
-// MockPodUpdaterController is a mock of PodUpdaterController interface.
-type MockPodUpdaterController struct {
+// MockEndpointsController is a mock of EndpointsController interface.
+type MockEndpointsController struct {
 	ctrl     *gomock.Controller
-	recorder *MockPodUpdaterControllerMockRecorder
+	recorder *MockEndpointsControllerMockRecorder
 }
 
-// MockPodUpdaterControllerMockRecorder is the mock recorder for MockPodUpdaterController.
-type MockPodUpdaterControllerMockRecorder struct {
-	mock *MockPodUpdaterController
+// MockEndpointsControllerMockRecorder is the mock recorder for MockEndpointsController.
+type MockEndpointsControllerMockRecorder struct {
+	mock *MockEndpointsController
 }
 
-// NewMockPodUpdaterController creates a new mock instance.
-func NewMockPodUpdaterController(ctrl *gomock.Controller) *MockPodUpdaterController {
-	mock := &MockPodUpdaterController{ctrl: ctrl}
-	mock.recorder = &MockPodUpdaterControllerMockRecorder{mock}
+// NewMockEndpointsController creates a new mock instance.
+func NewMockEndpointsController(ctrl *gomock.Controller) *MockEndpointsController {
+	mock := &MockEndpointsController{ctrl: ctrl}
+	mock.recorder = &MockEndpointsControllerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockPodUpdaterController) EXPECT() *MockPodUpdaterControllerMockRecorder {
+func (m *MockEndpointsController) EXPECT() *MockEndpointsControllerMockRecorder {
 	return m.recorder
 }
 
-// HandlePodUpdate mocks base method.
-func (m *MockPodUpdaterController) HandlePodUpdate(oldKv, newKv *mvccpb.KeyValue) {
+// Run mocks base method.
+func (m *MockEndpointsController) Run(ctx context.Context) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "HandlePodUpdate", oldKv, newKv)
+	m.ctrl.Call(m, "Run", ctx)
 }
 
-// HandlePodUpdate indicates an expected call of HandlePodUpdate.
-func (mr *MockPodUpdaterControllerMockRecorder) HandlePodUpdate(oldKv, newKv interface{}) *gomock.Call {
+// Run indicates an expected call of Run.
+func (mr *MockEndpointsControllerMockRecorder) Run(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePodUpdate", reflect.TypeOf((*MockPodUpdaterController)(nil).HandlePodUpdate), oldKv, newKv)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockEndpointsController)(nil).Run), ctx)
 }
 
 // MockPersistentVolumeController is a mock of PersistentVolumeController interface.
@@ -277,17 +601,17 @@ func (m *MockPersistentVolumeController) EXPECT() *MockPersistentVolumeControlle
 }
 
 // HandleIncomingPersistentVolume mocks base method.
-func (m *MockPersistentVolumeController) HandleIncomingPersistentVolume(volumeSpec shared.PersistentVolumeSpec) error {
+func (m *MockPersistentVolumeController) HandleIncomingPersistentVolume(ctx context.Context, volumeSpec shared.PersistentVolumeSpec) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleIncomingPersistentVolume", volumeSpec)
+	ret := m.ctrl.Call(m, "HandleIncomingPersistentVolume", ctx, volumeSpec)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleIncomingPersistentVolume indicates an expected call of HandleIncomingPersistentVolume.
-func (mr *MockPersistentVolumeControllerMockRecorder) HandleIncomingPersistentVolume(volumeSpec interface{}) *gomock.Call {
+func (mr *MockPersistentVolumeControllerMockRecorder) HandleIncomingPersistentVolume(ctx, volumeSpec interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingPersistentVolume", reflect.TypeOf((*MockPersistentVolumeController)(nil).HandleIncomingPersistentVolume), volumeSpec)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingPersistentVolume", reflect.TypeOf((*MockPersistentVolumeController)(nil).HandleIncomingPersistentVolume), ctx, volumeSpec)
 }
 
 // MockPersistentVolumeClaimController is a mock of PersistentVolumeClaimController interface.
@@ -314,15 +638,15 @@ func (m *MockPersistentVolumeClaimController) EXPECT() *MockPersistentVolumeClai
 }
 
 // HandleIncomingPersistentVolumeClaim mocks base method.
-func (m *MockPersistentVolumeClaimController) HandleIncomingPersistentVolumeClaim(volumeClaimSpec shared.PersistentVolumeClaimSpec) error {
+func (m *MockPersistentVolumeClaimController) HandleIncomingPersistentVolumeClaim(ctx context.Context, volumeClaimSpec shared.PersistentVolumeClaimSpec) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleIncomingPersistentVolumeClaim", volumeClaimSpec)
+	ret := m.ctrl.Call(m, "HandleIncomingPersistentVolumeClaim", ctx, volumeClaimSpec)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleIncomingPersistentVolumeClaim indicates an expected call of HandleIncomingPersistentVolumeClaim.
-func (mr *MockPersistentVolumeClaimControllerMockRecorder) HandleIncomingPersistentVolumeClaim(volumeClaimSpec interface{}) *gomock.Call {
+func (mr *MockPersistentVolumeClaimControllerMockRecorder) HandleIncomingPersistentVolumeClaim(ctx, volumeClaimSpec interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingPersistentVolumeClaim", reflect.TypeOf((*MockPersistentVolumeClaimController)(nil).HandleIncomingPersistentVolumeClaim), volumeClaimSpec)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingPersistentVolumeClaim", reflect.TypeOf((*MockPersistentVolumeClaimController)(nil).HandleIncomingPersistentVolumeClaim), ctx, volumeClaimSpec)
 }
@@ -38,18 +38,33 @@ func (m *MockContainerRuntimeInterface) EXPECT() *MockContainerRuntimeInterfaceM
 }
 
 // CreateContainer mocks base method.
-func (m *MockContainerRuntimeInterface) CreateContainer(arg0 string) (string, error) {
+func (m *MockContainerRuntimeInterface) CreateContainer(arg0 shared.Container, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateContainer", arg0)
+	ret := m.ctrl.Call(m, "CreateContainer", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateContainer indicates an expected call of CreateContainer.
-func (mr *MockContainerRuntimeInterfaceMockRecorder) CreateContainer(arg0 interface{}) *gomock.Call {
+func (mr *MockContainerRuntimeInterfaceMockRecorder) CreateContainer(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContainer", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).CreateContainer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContainer", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).CreateContainer), arg0, arg1)
+}
+
+// CreateSandbox mocks base method.
+func (m *MockContainerRuntimeInterface) CreateSandbox(arg0 string, arg1 []shared.Port, arg2, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSandbox", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSandbox indicates an expected call of CreateSandbox.
+func (mr *MockContainerRuntimeInterfaceMockRecorder) CreateSandbox(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSandbox", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).CreateSandbox), arg0, arg1, arg2, arg3)
 }
 
 // DeleteContainer mocks base method.
@@ -66,6 +81,20 @@ func (mr *MockContainerRuntimeInterfaceMockRecorder) DeleteContainer(arg0 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContainer", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).DeleteContainer), arg0)
 }
 
+// EnsureNetwork mocks base method.
+func (m *MockContainerRuntimeInterface) EnsureNetwork(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureNetwork", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureNetwork indicates an expected call of EnsureNetwork.
+func (mr *MockContainerRuntimeInterfaceMockRecorder) EnsureNetwork(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNetwork", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).EnsureNetwork), arg0, arg1)
+}
+
 // ExecCommandAttach mocks base method.
 func (m *MockContainerRuntimeInterface) ExecCommandAttach(arg0 context.Context, arg1 string, arg2 types.ExecStartCheck, arg3 bool) (*types.HijackedResponse, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +125,21 @@ func (mr *MockContainerRuntimeInterfaceMockRecorder) ExecCommandCreate(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecCommandCreate", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).ExecCommandCreate), arg0, arg1, arg2)
 }
 
+// ExecCommandInspect mocks base method.
+func (m *MockContainerRuntimeInterface) ExecCommandInspect(arg0 context.Context, arg1 string) (types.ContainerExecInspect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecCommandInspect", arg0, arg1)
+	ret0, _ := ret[0].(types.ContainerExecInspect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecCommandInspect indicates an expected call of ExecCommandInspect.
+func (mr *MockContainerRuntimeInterfaceMockRecorder) ExecCommandInspect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecCommandInspect", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).ExecCommandInspect), arg0, arg1)
+}
+
 // GetContainerLogs mocks base method.
 func (m *MockContainerRuntimeInterface) GetContainerLogs(arg0 context.Context, arg1 string, arg2 bool) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
@@ -112,10 +156,10 @@ func (mr *MockContainerRuntimeInterfaceMockRecorder) GetContainerLogs(arg0, arg1
 }
 
 // GetContainerStatus mocks base method.
-func (m *MockContainerRuntimeInterface) GetContainerStatus(arg0 string) (shared.ContainerStatus, error) {
+func (m *MockContainerRuntimeInterface) GetContainerStatus(arg0 string) (shared.ContainerRuntimeStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetContainerStatus", arg0)
-	ret0, _ := ret[0].(shared.ContainerStatus)
+	ret0, _ := ret[0].(shared.ContainerRuntimeStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -126,6 +170,65 @@ func (mr *MockContainerRuntimeInterfaceMockRecorder) GetContainerStatus(arg0 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainerStatus", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).GetContainerStatus), arg0)
 }
 
+// HostID mocks base method.
+func (m *MockContainerRuntimeInterface) HostID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HostID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HostID indicates an expected call of HostID.
+func (mr *MockContainerRuntimeInterfaceMockRecorder) HostID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostID", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).HostID))
+}
+
+// ImageExists mocks base method.
+func (m *MockContainerRuntimeInterface) ImageExists(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImageExists", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImageExists indicates an expected call of ImageExists.
+func (mr *MockContainerRuntimeInterfaceMockRecorder) ImageExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImageExists", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).ImageExists), arg0)
+}
+
+// InspectContainer mocks base method.
+func (m *MockContainerRuntimeInterface) InspectContainer(arg0 string) (types.ContainerJSON, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectContainer", arg0)
+	ret0, _ := ret[0].(types.ContainerJSON)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectContainer indicates an expected call of InspectContainer.
+func (mr *MockContainerRuntimeInterfaceMockRecorder) InspectContainer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectContainer", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).InspectContainer), arg0)
+}
+
+// PullImage mocks base method.
+func (m *MockContainerRuntimeInterface) PullImage(arg0 context.Context, arg1 string, arg2 func(string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullImage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PullImage indicates an expected call of PullImage.
+func (mr *MockContainerRuntimeInterfaceMockRecorder) PullImage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullImage", reflect.TypeOf((*MockContainerRuntimeInterface)(nil).PullImage), arg0, arg1, arg2)
+}
+
 // StartContainer mocks base method.
 func (m *MockContainerRuntimeInterface) StartContainer(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	shared "maden/pkg/shared"
 	reflect "reflect"
 
@@ -35,73 +36,73 @@ func (m *MockDeploymentRepository) EXPECT() *MockDeploymentRepositoryMockRecorde
 }
 
 // CreateDeployment mocks base method.
-func (m *MockDeploymentRepository) CreateDeployment(arg0 *shared.Deployment) error {
+func (m *MockDeploymentRepository) CreateDeployment(arg0 context.Context, arg1 *shared.Deployment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDeployment", arg0)
+	ret := m.ctrl.Call(m, "CreateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateDeployment indicates an expected call of CreateDeployment.
-func (mr *MockDeploymentRepositoryMockRecorder) CreateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentRepositoryMockRecorder) CreateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockDeploymentRepository)(nil).CreateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockDeploymentRepository)(nil).CreateDeployment), arg0, arg1)
 }
 
 // DeleteDeployment mocks base method.
-func (m *MockDeploymentRepository) DeleteDeployment(arg0 string) error {
+func (m *MockDeploymentRepository) DeleteDeployment(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDeployment", arg0)
+	ret := m.ctrl.Call(m, "DeleteDeployment", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteDeployment indicates an expected call of DeleteDeployment.
-func (mr *MockDeploymentRepositoryMockRecorder) DeleteDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentRepositoryMockRecorder) DeleteDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockDeploymentRepository)(nil).DeleteDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockDeploymentRepository)(nil).DeleteDeployment), arg0, arg1, arg2)
 }
 
 // GetDeploymentByName mocks base method.
-func (m *MockDeploymentRepository) GetDeploymentByName(arg0 string) (*shared.Deployment, error) {
+func (m *MockDeploymentRepository) GetDeploymentByName(arg0 context.Context, arg1, arg2 string) (*shared.Deployment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDeploymentByName", arg0)
+	ret := m.ctrl.Call(m, "GetDeploymentByName", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*shared.Deployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDeploymentByName indicates an expected call of GetDeploymentByName.
-func (mr *MockDeploymentRepositoryMockRecorder) GetDeploymentByName(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentRepositoryMockRecorder) GetDeploymentByName(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentByName", reflect.TypeOf((*MockDeploymentRepository)(nil).GetDeploymentByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentByName", reflect.TypeOf((*MockDeploymentRepository)(nil).GetDeploymentByName), arg0, arg1, arg2)
 }
 
 // ListDeployments mocks base method.
-func (m *MockDeploymentRepository) ListDeployments() ([]shared.Deployment, error) {
+func (m *MockDeploymentRepository) ListDeployments(arg0 context.Context, arg1 string) ([]shared.Deployment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDeployments")
+	ret := m.ctrl.Call(m, "ListDeployments", arg0, arg1)
 	ret0, _ := ret[0].([]shared.Deployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListDeployments indicates an expected call of ListDeployments.
-func (mr *MockDeploymentRepositoryMockRecorder) ListDeployments() *gomock.Call {
+func (mr *MockDeploymentRepositoryMockRecorder) ListDeployments(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeployments", reflect.TypeOf((*MockDeploymentRepository)(nil).ListDeployments))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeployments", reflect.TypeOf((*MockDeploymentRepository)(nil).ListDeployments), arg0, arg1)
 }
 
 // UpdateDeployment mocks base method.
-func (m *MockDeploymentRepository) UpdateDeployment(arg0 *shared.Deployment) error {
+func (m *MockDeploymentRepository) UpdateDeployment(arg0 context.Context, arg1 *shared.Deployment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDeployment", arg0)
+	ret := m.ctrl.Call(m, "UpdateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateDeployment indicates an expected call of UpdateDeployment.
-func (mr *MockDeploymentRepositoryMockRecorder) UpdateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentRepositoryMockRecorder) UpdateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeployment", reflect.TypeOf((*MockDeploymentRepository)(nil).UpdateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeployment", reflect.TypeOf((*MockDeploymentRepository)(nil).UpdateDeployment), arg0, arg1)
 }
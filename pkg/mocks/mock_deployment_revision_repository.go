@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/etcd (interfaces: DeploymentRevisionRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeploymentRevisionRepository is a mock of DeploymentRevisionRepository interface.
+type MockDeploymentRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeploymentRevisionRepositoryMockRecorder
+}
+
+// MockDeploymentRevisionRepositoryMockRecorder is the mock recorder for MockDeploymentRevisionRepository.
+type MockDeploymentRevisionRepositoryMockRecorder struct {
+	mock *MockDeploymentRevisionRepository
+}
+
+// NewMockDeploymentRevisionRepository creates a new mock instance.
+func NewMockDeploymentRevisionRepository(ctrl *gomock.Controller) *MockDeploymentRevisionRepository {
+	mock := &MockDeploymentRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockDeploymentRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeploymentRevisionRepository) EXPECT() *MockDeploymentRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRevision mocks base method.
+func (m *MockDeploymentRevisionRepository) CreateRevision(arg0 context.Context, arg1 *shared.DeploymentRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevision", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRevision indicates an expected call of CreateRevision.
+func (mr *MockDeploymentRevisionRepositoryMockRecorder) CreateRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevision", reflect.TypeOf((*MockDeploymentRevisionRepository)(nil).CreateRevision), arg0, arg1)
+}
+
+// DeleteRevision mocks base method.
+func (m *MockDeploymentRevisionRepository) DeleteRevision(arg0 context.Context, arg1, arg2 string, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRevision indicates an expected call of DeleteRevision.
+func (mr *MockDeploymentRevisionRepositoryMockRecorder) DeleteRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRevision", reflect.TypeOf((*MockDeploymentRevisionRepository)(nil).DeleteRevision), arg0, arg1, arg2, arg3)
+}
+
+// DeleteRevisions mocks base method.
+func (m *MockDeploymentRevisionRepository) DeleteRevisions(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRevisions indicates an expected call of DeleteRevisions.
+func (mr *MockDeploymentRevisionRepositoryMockRecorder) DeleteRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRevisions", reflect.TypeOf((*MockDeploymentRevisionRepository)(nil).DeleteRevisions), arg0, arg1, arg2)
+}
+
+// GetRevision mocks base method.
+func (m *MockDeploymentRevisionRepository) GetRevision(arg0 context.Context, arg1, arg2 string, arg3 int) (*shared.DeploymentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*shared.DeploymentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockDeploymentRevisionRepositoryMockRecorder) GetRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockDeploymentRevisionRepository)(nil).GetRevision), arg0, arg1, arg2, arg3)
+}
+
+// ListRevisions mocks base method.
+func (m *MockDeploymentRevisionRepository) ListRevisions(arg0 context.Context, arg1, arg2 string) ([]shared.DeploymentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]shared.DeploymentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockDeploymentRevisionRepositoryMockRecorder) ListRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockDeploymentRevisionRepository)(nil).ListRevisions), arg0, arg1, arg2)
+}
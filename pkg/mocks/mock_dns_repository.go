@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	context "context"
+	shared "maden/pkg/shared"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,21 +36,37 @@ func (m *MockDNSRepository) EXPECT() *MockDNSRepositoryMockRecorder {
 }
 
 // DeregisterService mocks base method.
-func (m *MockDNSRepository) DeregisterService(arg0 string) error {
+func (m *MockDNSRepository) DeregisterService(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeregisterService", arg0)
+	ret := m.ctrl.Call(m, "DeregisterService", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeregisterService indicates an expected call of DeregisterService.
-func (mr *MockDNSRepositoryMockRecorder) DeregisterService(arg0 interface{}) *gomock.Call {
+func (mr *MockDNSRepositoryMockRecorder) DeregisterService(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterService", reflect.TypeOf((*MockDNSRepository)(nil).DeregisterService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterService", reflect.TypeOf((*MockDNSRepository)(nil).DeregisterService), arg0, arg1, arg2)
+}
+
+// ListRecords mocks base method.
+func (m *MockDNSRepository) ListRecords(arg0 context.Context) ([]shared.DNSRecord, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecords", arg0)
+	ret0, _ := ret[0].([]shared.DNSRecord)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRecords indicates an expected call of ListRecords.
+func (mr *MockDNSRepositoryMockRecorder) ListRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecords", reflect.TypeOf((*MockDNSRepository)(nil).ListRecords), arg0)
 }
 
 // RegisterService mocks base method.
-func (m *MockDNSRepository) RegisterService(arg0, arg1 string) error {
+func (m *MockDNSRepository) RegisterService(arg0 context.Context, arg1 shared.Service) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterService", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -61,17 +79,16 @@ func (mr *MockDNSRepositoryMockRecorder) RegisterService(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterService", reflect.TypeOf((*MockDNSRepository)(nil).RegisterService), arg0, arg1)
 }
 
-// ResolveService mocks base method.
-func (m *MockDNSRepository) ResolveService(arg0 string) (string, error) {
+// WatchRecords mocks base method.
+func (m *MockDNSRepository) WatchRecords(arg0 context.Context, arg1 int64) <-chan shared.WatchEvent[shared.DNSRecord] {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveService", arg0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WatchRecords", arg0, arg1)
+	ret0, _ := ret[0].(<-chan shared.WatchEvent[shared.DNSRecord])
+	return ret0
 }
 
-// ResolveService indicates an expected call of ResolveService.
-func (mr *MockDNSRepositoryMockRecorder) ResolveService(arg0 interface{}) *gomock.Call {
+// WatchRecords indicates an expected call of WatchRecords.
+func (mr *MockDNSRepositoryMockRecorder) WatchRecords(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveService", reflect.TypeOf((*MockDNSRepository)(nil).ResolveService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRecords", reflect.TypeOf((*MockDNSRepository)(nil).WatchRecords), arg0, arg1)
 }
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/etcd (interfaces: EndpointsRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEndpointsRepository is a mock of EndpointsRepository interface.
+type MockEndpointsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEndpointsRepositoryMockRecorder
+}
+
+// MockEndpointsRepositoryMockRecorder is the mock recorder for MockEndpointsRepository.
+type MockEndpointsRepositoryMockRecorder struct {
+	mock *MockEndpointsRepository
+}
+
+// NewMockEndpointsRepository creates a new mock instance.
+func NewMockEndpointsRepository(ctrl *gomock.Controller) *MockEndpointsRepository {
+	mock := &MockEndpointsRepository{ctrl: ctrl}
+	mock.recorder = &MockEndpointsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEndpointsRepository) EXPECT() *MockEndpointsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEndpoints mocks base method.
+func (m *MockEndpointsRepository) CreateEndpoints(arg0 context.Context, arg1 *shared.Endpoints) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEndpoints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEndpoints indicates an expected call of CreateEndpoints.
+func (mr *MockEndpointsRepositoryMockRecorder) CreateEndpoints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEndpoints", reflect.TypeOf((*MockEndpointsRepository)(nil).CreateEndpoints), arg0, arg1)
+}
+
+// DeleteEndpoints mocks base method.
+func (m *MockEndpointsRepository) DeleteEndpoints(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEndpoints", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEndpoints indicates an expected call of DeleteEndpoints.
+func (mr *MockEndpointsRepositoryMockRecorder) DeleteEndpoints(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEndpoints", reflect.TypeOf((*MockEndpointsRepository)(nil).DeleteEndpoints), arg0, arg1, arg2)
+}
+
+// GetEndpoints mocks base method.
+func (m *MockEndpointsRepository) GetEndpoints(arg0 context.Context, arg1, arg2 string) (*shared.Endpoints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpoints", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*shared.Endpoints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpoints indicates an expected call of GetEndpoints.
+func (mr *MockEndpointsRepositoryMockRecorder) GetEndpoints(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoints", reflect.TypeOf((*MockEndpointsRepository)(nil).GetEndpoints), arg0, arg1, arg2)
+}
+
+// ListEndpoints mocks base method.
+func (m *MockEndpointsRepository) ListEndpoints(arg0 context.Context, arg1 string) ([]shared.Endpoints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEndpoints", arg0, arg1)
+	ret0, _ := ret[0].([]shared.Endpoints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEndpoints indicates an expected call of ListEndpoints.
+func (mr *MockEndpointsRepositoryMockRecorder) ListEndpoints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpoints", reflect.TypeOf((*MockEndpointsRepository)(nil).ListEndpoints), arg0, arg1)
+}
+
+// UpdateEndpoints mocks base method.
+func (m *MockEndpointsRepository) UpdateEndpoints(arg0 context.Context, arg1 *shared.Endpoints) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEndpoints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEndpoints indicates an expected call of UpdateEndpoints.
+func (mr *MockEndpointsRepositoryMockRecorder) UpdateEndpoints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEndpoints", reflect.TypeOf((*MockEndpointsRepository)(nil).UpdateEndpoints), arg0, arg1)
+}
+
+// WatchEndpoints mocks base method.
+func (m *MockEndpointsRepository) WatchEndpoints(arg0 context.Context) <-chan shared.WatchEvent[shared.Endpoints] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchEndpoints", arg0)
+	ret0, _ := ret[0].(<-chan shared.WatchEvent[shared.Endpoints])
+	return ret0
+}
+
+// WatchEndpoints indicates an expected call of WatchEndpoints.
+func (mr *MockEndpointsRepositoryMockRecorder) WatchEndpoints(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEndpoints", reflect.TypeOf((*MockEndpointsRepository)(nil).WatchEndpoints), arg0)
+}
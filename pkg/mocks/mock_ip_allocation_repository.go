@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/etcd (interfaces: IPAllocationRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPAllocationRepository is a mock of IPAllocationRepository interface.
+type MockIPAllocationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPAllocationRepositoryMockRecorder
+}
+
+// MockIPAllocationRepositoryMockRecorder is the mock recorder for MockIPAllocationRepository.
+type MockIPAllocationRepositoryMockRecorder struct {
+	mock *MockIPAllocationRepository
+}
+
+// NewMockIPAllocationRepository creates a new mock instance.
+func NewMockIPAllocationRepository(ctrl *gomock.Controller) *MockIPAllocationRepository {
+	mock := &MockIPAllocationRepository{ctrl: ctrl}
+	mock.recorder = &MockIPAllocationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPAllocationRepository) EXPECT() *MockIPAllocationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIPAllocation mocks base method.
+func (m *MockIPAllocationRepository) CreateIPAllocation(arg0 context.Context, arg1 *shared.IPAllocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIPAllocation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIPAllocation indicates an expected call of CreateIPAllocation.
+func (mr *MockIPAllocationRepositoryMockRecorder) CreateIPAllocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIPAllocation", reflect.TypeOf((*MockIPAllocationRepository)(nil).CreateIPAllocation), arg0, arg1)
+}
+
+// GetIPAllocation mocks base method.
+func (m *MockIPAllocationRepository) GetIPAllocation(arg0 context.Context, arg1 string) (*shared.IPAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIPAllocation", arg0, arg1)
+	ret0, _ := ret[0].(*shared.IPAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIPAllocation indicates an expected call of GetIPAllocation.
+func (mr *MockIPAllocationRepositoryMockRecorder) GetIPAllocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPAllocation", reflect.TypeOf((*MockIPAllocationRepository)(nil).GetIPAllocation), arg0, arg1)
+}
+
+// UpdateIPAllocation mocks base method.
+func (m *MockIPAllocationRepository) UpdateIPAllocation(arg0 context.Context, arg1 *shared.IPAllocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIPAllocation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIPAllocation indicates an expected call of UpdateIPAllocation.
+func (mr *MockIPAllocationRepositoryMockRecorder) UpdateIPAllocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIPAllocation", reflect.TypeOf((*MockIPAllocationRepository)(nil).UpdateIPAllocation), arg0, arg1)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,30 +35,86 @@ func (m *MockIPManager) EXPECT() *MockIPManagerMockRecorder {
 }
 
 // AssignIP mocks base method.
-func (m *MockIPManager) AssignIP() (string, error) {
+func (m *MockIPManager) AssignIP(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignIP")
+	ret := m.ctrl.Call(m, "AssignIP", arg0)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssignIP indicates an expected call of AssignIP.
-func (mr *MockIPManagerMockRecorder) AssignIP() *gomock.Call {
+func (mr *MockIPManagerMockRecorder) AssignIP(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignIP", reflect.TypeOf((*MockIPManager)(nil).AssignIP))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignIP", reflect.TypeOf((*MockIPManager)(nil).AssignIP), arg0)
+}
+
+// AssignSpecificIP mocks base method.
+func (m *MockIPManager) AssignSpecificIP(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignSpecificIP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignSpecificIP indicates an expected call of AssignSpecificIP.
+func (mr *MockIPManagerMockRecorder) AssignSpecificIP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignSpecificIP", reflect.TypeOf((*MockIPManager)(nil).AssignSpecificIP), arg0, arg1)
+}
+
+// Bind mocks base method.
+func (m *MockIPManager) Bind(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bind", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bind indicates an expected call of Bind.
+func (mr *MockIPManagerMockRecorder) Bind(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bind", reflect.TypeOf((*MockIPManager)(nil).Bind), arg0, arg1)
+}
+
+// Contains mocks base method.
+func (m *MockIPManager) Contains(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contains", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Contains indicates an expected call of Contains.
+func (mr *MockIPManagerMockRecorder) Contains(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contains", reflect.TypeOf((*MockIPManager)(nil).Contains), arg0)
 }
 
 // ReleaseIP mocks base method.
-func (m *MockIPManager) ReleaseIP(arg0 string) error {
+func (m *MockIPManager) ReleaseIP(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReleaseIP", arg0)
+	ret := m.ctrl.Call(m, "ReleaseIP", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ReleaseIP indicates an expected call of ReleaseIP.
-func (mr *MockIPManagerMockRecorder) ReleaseIP(arg0 interface{}) *gomock.Call {
+func (mr *MockIPManagerMockRecorder) ReleaseIP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIP", reflect.TypeOf((*MockIPManager)(nil).ReleaseIP), arg0, arg1)
+}
+
+// Repair mocks base method.
+func (m *MockIPManager) Repair(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repair", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Repair indicates an expected call of Repair.
+func (mr *MockIPManagerMockRecorder) Repair(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIP", reflect.TypeOf((*MockIPManager)(nil).ReleaseIP), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockIPManager)(nil).Repair), arg0, arg1)
 }
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/etcd (interfaces: NamespaceRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNamespaceRepository is a mock of NamespaceRepository interface.
+type MockNamespaceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNamespaceRepositoryMockRecorder
+}
+
+// MockNamespaceRepositoryMockRecorder is the mock recorder for MockNamespaceRepository.
+type MockNamespaceRepositoryMockRecorder struct {
+	mock *MockNamespaceRepository
+}
+
+// NewMockNamespaceRepository creates a new mock instance.
+func NewMockNamespaceRepository(ctrl *gomock.Controller) *MockNamespaceRepository {
+	mock := &MockNamespaceRepository{ctrl: ctrl}
+	mock.recorder = &MockNamespaceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNamespaceRepository) EXPECT() *MockNamespaceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateNamespace mocks base method.
+func (m *MockNamespaceRepository) CreateNamespace(arg0 context.Context, arg1 *shared.Namespace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamespace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNamespace indicates an expected call of CreateNamespace.
+func (mr *MockNamespaceRepositoryMockRecorder) CreateNamespace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespace", reflect.TypeOf((*MockNamespaceRepository)(nil).CreateNamespace), arg0, arg1)
+}
+
+// DeleteNamespace mocks base method.
+func (m *MockNamespaceRepository) DeleteNamespace(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNamespace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNamespace indicates an expected call of DeleteNamespace.
+func (mr *MockNamespaceRepositoryMockRecorder) DeleteNamespace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNamespace", reflect.TypeOf((*MockNamespaceRepository)(nil).DeleteNamespace), arg0, arg1)
+}
+
+// GetNamespaceByName mocks base method.
+func (m *MockNamespaceRepository) GetNamespaceByName(arg0 context.Context, arg1 string) (*shared.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespaceByName", arg0, arg1)
+	ret0, _ := ret[0].(*shared.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamespaceByName indicates an expected call of GetNamespaceByName.
+func (mr *MockNamespaceRepositoryMockRecorder) GetNamespaceByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceByName", reflect.TypeOf((*MockNamespaceRepository)(nil).GetNamespaceByName), arg0, arg1)
+}
+
+// ListNamespaces mocks base method.
+func (m *MockNamespaceRepository) ListNamespaces(arg0 context.Context) ([]shared.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNamespaces", arg0)
+	ret0, _ := ret[0].([]shared.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNamespaces indicates an expected call of ListNamespaces.
+func (mr *MockNamespaceRepositoryMockRecorder) ListNamespaces(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNamespaces", reflect.TypeOf((*MockNamespaceRepository)(nil).ListNamespaces), arg0)
+}
+
+// UpdateNamespace mocks base method.
+func (m *MockNamespaceRepository) UpdateNamespace(arg0 context.Context, arg1 *shared.Namespace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNamespace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNamespace indicates an expected call of UpdateNamespace.
+func (mr *MockNamespaceRepositoryMockRecorder) UpdateNamespace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespace", reflect.TypeOf((*MockNamespaceRepository)(nil).UpdateNamespace), arg0, arg1)
+}
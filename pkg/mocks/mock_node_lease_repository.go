@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/etcd (interfaces: NodeLeaseRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNodeLeaseRepository is a mock of NodeLeaseRepository interface.
+type MockNodeLeaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeLeaseRepositoryMockRecorder
+}
+
+// MockNodeLeaseRepositoryMockRecorder is the mock recorder for MockNodeLeaseRepository.
+type MockNodeLeaseRepositoryMockRecorder struct {
+	mock *MockNodeLeaseRepository
+}
+
+// NewMockNodeLeaseRepository creates a new mock instance.
+func NewMockNodeLeaseRepository(ctrl *gomock.Controller) *MockNodeLeaseRepository {
+	mock := &MockNodeLeaseRepository{ctrl: ctrl}
+	mock.recorder = &MockNodeLeaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeLeaseRepository) EXPECT() *MockNodeLeaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteNodeLease mocks base method.
+func (m *MockNodeLeaseRepository) DeleteNodeLease(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNodeLease", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNodeLease indicates an expected call of DeleteNodeLease.
+func (mr *MockNodeLeaseRepositoryMockRecorder) DeleteNodeLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNodeLease", reflect.TypeOf((*MockNodeLeaseRepository)(nil).DeleteNodeLease), arg0, arg1)
+}
+
+// GetNodeLease mocks base method.
+func (m *MockNodeLeaseRepository) GetNodeLease(arg0 context.Context, arg1 string) (*shared.NodeLease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeLease", arg0, arg1)
+	ret0, _ := ret[0].(*shared.NodeLease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeLease indicates an expected call of GetNodeLease.
+func (mr *MockNodeLeaseRepositoryMockRecorder) GetNodeLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeLease", reflect.TypeOf((*MockNodeLeaseRepository)(nil).GetNodeLease), arg0, arg1)
+}
+
+// ListNodeLeases mocks base method.
+func (m *MockNodeLeaseRepository) ListNodeLeases(arg0 context.Context) ([]shared.NodeLease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeLeases", arg0)
+	ret0, _ := ret[0].([]shared.NodeLease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeLeases indicates an expected call of ListNodeLeases.
+func (mr *MockNodeLeaseRepositoryMockRecorder) ListNodeLeases(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeLeases", reflect.TypeOf((*MockNodeLeaseRepository)(nil).ListNodeLeases), arg0)
+}
+
+// RenewNodeLease mocks base method.
+func (m *MockNodeLeaseRepository) RenewNodeLease(arg0 context.Context, arg1 *shared.NodeLease) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewNodeLease", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewNodeLease indicates an expected call of RenewNodeLease.
+func (mr *MockNodeLeaseRepositoryMockRecorder) RenewNodeLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewNodeLease", reflect.TypeOf((*MockNodeLeaseRepository)(nil).RenewNodeLease), arg0, arg1)
+}
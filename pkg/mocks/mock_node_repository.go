@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	shared "maden/pkg/shared"
 	reflect "reflect"
 
@@ -35,58 +36,73 @@ func (m *MockNodeRepository) EXPECT() *MockNodeRepositoryMockRecorder {
 }
 
 // CreateNode mocks base method.
-func (m *MockNodeRepository) CreateNode(arg0 *shared.Node) error {
+func (m *MockNodeRepository) CreateNode(arg0 context.Context, arg1 *shared.Node) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNode", arg0)
+	ret := m.ctrl.Call(m, "CreateNode", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateNode indicates an expected call of CreateNode.
-func (mr *MockNodeRepositoryMockRecorder) CreateNode(arg0 interface{}) *gomock.Call {
+func (mr *MockNodeRepositoryMockRecorder) CreateNode(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNode", reflect.TypeOf((*MockNodeRepository)(nil).CreateNode), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNode", reflect.TypeOf((*MockNodeRepository)(nil).CreateNode), arg0, arg1)
 }
 
 // DeleteNode mocks base method.
-func (m *MockNodeRepository) DeleteNode(arg0 string) error {
+func (m *MockNodeRepository) DeleteNode(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNode", arg0)
+	ret := m.ctrl.Call(m, "DeleteNode", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNode indicates an expected call of DeleteNode.
-func (mr *MockNodeRepositoryMockRecorder) DeleteNode(arg0 interface{}) *gomock.Call {
+func (mr *MockNodeRepositoryMockRecorder) DeleteNode(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNode", reflect.TypeOf((*MockNodeRepository)(nil).DeleteNode), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNode", reflect.TypeOf((*MockNodeRepository)(nil).DeleteNode), arg0, arg1)
+}
+
+// GetNodeByID mocks base method.
+func (m *MockNodeRepository) GetNodeByID(arg0 context.Context, arg1 string) (*shared.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeByID", arg0, arg1)
+	ret0, _ := ret[0].(*shared.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeByID indicates an expected call of GetNodeByID.
+func (mr *MockNodeRepositoryMockRecorder) GetNodeByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeByID", reflect.TypeOf((*MockNodeRepository)(nil).GetNodeByID), arg0, arg1)
 }
 
 // ListNodes mocks base method.
-func (m *MockNodeRepository) ListNodes() ([]shared.Node, error) {
+func (m *MockNodeRepository) ListNodes(arg0 context.Context) ([]shared.Node, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListNodes")
+	ret := m.ctrl.Call(m, "ListNodes", arg0)
 	ret0, _ := ret[0].([]shared.Node)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListNodes indicates an expected call of ListNodes.
-func (mr *MockNodeRepositoryMockRecorder) ListNodes() *gomock.Call {
+func (mr *MockNodeRepositoryMockRecorder) ListNodes(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodes", reflect.TypeOf((*MockNodeRepository)(nil).ListNodes))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodes", reflect.TypeOf((*MockNodeRepository)(nil).ListNodes), arg0)
 }
 
 // UpdateNode mocks base method.
-func (m *MockNodeRepository) UpdateNode(arg0 *shared.Node) error {
+func (m *MockNodeRepository) UpdateNode(arg0 context.Context, arg1 *shared.Node) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNode", arg0)
+	ret := m.ctrl.Call(m, "UpdateNode", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateNode indicates an expected call of UpdateNode.
-func (mr *MockNodeRepositoryMockRecorder) UpdateNode(arg0 interface{}) *gomock.Call {
+func (mr *MockNodeRepositoryMockRecorder) UpdateNode(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNode", reflect.TypeOf((*MockNodeRepository)(nil).UpdateNode), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNode", reflect.TypeOf((*MockNodeRepository)(nil).UpdateNode), arg0, arg1)
 }
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/orchestrator (interfaces: PersistentVolumeClaimOrchestrator)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersistentVolumeClaimOrchestrator is a mock of PersistentVolumeClaimOrchestrator interface.
+type MockPersistentVolumeClaimOrchestrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersistentVolumeClaimOrchestratorMockRecorder
+}
+
+// MockPersistentVolumeClaimOrchestratorMockRecorder is the mock recorder for MockPersistentVolumeClaimOrchestrator.
+type MockPersistentVolumeClaimOrchestratorMockRecorder struct {
+	mock *MockPersistentVolumeClaimOrchestrator
+}
+
+// NewMockPersistentVolumeClaimOrchestrator creates a new mock instance.
+func NewMockPersistentVolumeClaimOrchestrator(ctrl *gomock.Controller) *MockPersistentVolumeClaimOrchestrator {
+	mock := &MockPersistentVolumeClaimOrchestrator{ctrl: ctrl}
+	mock.recorder = &MockPersistentVolumeClaimOrchestratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersistentVolumeClaimOrchestrator) EXPECT() *MockPersistentVolumeClaimOrchestratorMockRecorder {
+	return m.recorder
+}
+
+// OrchestratePersistentVolumeClaimCreation mocks base method.
+func (m *MockPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimCreation(arg0 context.Context, arg1 *shared.PersistentVolumeClaimSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrchestratePersistentVolumeClaimCreation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrchestratePersistentVolumeClaimCreation indicates an expected call of OrchestratePersistentVolumeClaimCreation.
+func (mr *MockPersistentVolumeClaimOrchestratorMockRecorder) OrchestratePersistentVolumeClaimCreation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestratePersistentVolumeClaimCreation", reflect.TypeOf((*MockPersistentVolumeClaimOrchestrator)(nil).OrchestratePersistentVolumeClaimCreation), arg0, arg1)
+}
+
+// OrchestratePersistentVolumeClaimDeletion mocks base method.
+func (m *MockPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimDeletion(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrchestratePersistentVolumeClaimDeletion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrchestratePersistentVolumeClaimDeletion indicates an expected call of OrchestratePersistentVolumeClaimDeletion.
+func (mr *MockPersistentVolumeClaimOrchestratorMockRecorder) OrchestratePersistentVolumeClaimDeletion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestratePersistentVolumeClaimDeletion", reflect.TypeOf((*MockPersistentVolumeClaimOrchestrator)(nil).OrchestratePersistentVolumeClaimDeletion), arg0, arg1, arg2)
+}
+
+// OrchestratePersistentVolumeClaimUpdate mocks base method.
+func (m *MockPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimUpdate(arg0 context.Context, arg1 *shared.PersistentVolumeClaim, arg2 *shared.PersistentVolumeClaimSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrchestratePersistentVolumeClaimUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrchestratePersistentVolumeClaimUpdate indicates an expected call of OrchestratePersistentVolumeClaimUpdate.
+func (mr *MockPersistentVolumeClaimOrchestratorMockRecorder) OrchestratePersistentVolumeClaimUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestratePersistentVolumeClaimUpdate", reflect.TypeOf((*MockPersistentVolumeClaimOrchestrator)(nil).OrchestratePersistentVolumeClaimUpdate), arg0, arg1, arg2)
+}
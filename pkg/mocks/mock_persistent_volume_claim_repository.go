@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/etcd (interfaces: PersistentVolumeClaimRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersistentVolumeClaimRepository is a mock of PersistentVolumeClaimRepository interface.
+type MockPersistentVolumeClaimRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersistentVolumeClaimRepositoryMockRecorder
+}
+
+// MockPersistentVolumeClaimRepositoryMockRecorder is the mock recorder for MockPersistentVolumeClaimRepository.
+type MockPersistentVolumeClaimRepositoryMockRecorder struct {
+	mock *MockPersistentVolumeClaimRepository
+}
+
+// NewMockPersistentVolumeClaimRepository creates a new mock instance.
+func NewMockPersistentVolumeClaimRepository(ctrl *gomock.Controller) *MockPersistentVolumeClaimRepository {
+	mock := &MockPersistentVolumeClaimRepository{ctrl: ctrl}
+	mock.recorder = &MockPersistentVolumeClaimRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersistentVolumeClaimRepository) EXPECT() *MockPersistentVolumeClaimRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePersistentVolumeClaim mocks base method.
+func (m *MockPersistentVolumeClaimRepository) CreatePersistentVolumeClaim(arg0 context.Context, arg1 *shared.PersistentVolumeClaim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersistentVolumeClaim", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePersistentVolumeClaim indicates an expected call of CreatePersistentVolumeClaim.
+func (mr *MockPersistentVolumeClaimRepositoryMockRecorder) CreatePersistentVolumeClaim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersistentVolumeClaim", reflect.TypeOf((*MockPersistentVolumeClaimRepository)(nil).CreatePersistentVolumeClaim), arg0, arg1)
+}
+
+// DeletePersistentVolumeClaim mocks base method.
+func (m *MockPersistentVolumeClaimRepository) DeletePersistentVolumeClaim(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePersistentVolumeClaim", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePersistentVolumeClaim indicates an expected call of DeletePersistentVolumeClaim.
+func (mr *MockPersistentVolumeClaimRepositoryMockRecorder) DeletePersistentVolumeClaim(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePersistentVolumeClaim", reflect.TypeOf((*MockPersistentVolumeClaimRepository)(nil).DeletePersistentVolumeClaim), arg0, arg1, arg2)
+}
+
+// GetPersistentVolumeClaimByID mocks base method.
+func (m *MockPersistentVolumeClaimRepository) GetPersistentVolumeClaimByID(arg0 context.Context, arg1, arg2 string) (*shared.PersistentVolumeClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersistentVolumeClaimByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*shared.PersistentVolumeClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersistentVolumeClaimByID indicates an expected call of GetPersistentVolumeClaimByID.
+func (mr *MockPersistentVolumeClaimRepositoryMockRecorder) GetPersistentVolumeClaimByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersistentVolumeClaimByID", reflect.TypeOf((*MockPersistentVolumeClaimRepository)(nil).GetPersistentVolumeClaimByID), arg0, arg1, arg2)
+}
+
+// ListPersistentVolumeClaims mocks base method.
+func (m *MockPersistentVolumeClaimRepository) ListPersistentVolumeClaims(arg0 context.Context, arg1 string) ([]shared.PersistentVolumeClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPersistentVolumeClaims", arg0, arg1)
+	ret0, _ := ret[0].([]shared.PersistentVolumeClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPersistentVolumeClaims indicates an expected call of ListPersistentVolumeClaims.
+func (mr *MockPersistentVolumeClaimRepositoryMockRecorder) ListPersistentVolumeClaims(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPersistentVolumeClaims", reflect.TypeOf((*MockPersistentVolumeClaimRepository)(nil).ListPersistentVolumeClaims), arg0, arg1)
+}
+
+// UpdatePersistentVolumeClaim mocks base method.
+func (m *MockPersistentVolumeClaimRepository) UpdatePersistentVolumeClaim(arg0 context.Context, arg1 *shared.PersistentVolumeClaim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePersistentVolumeClaim", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePersistentVolumeClaim indicates an expected call of UpdatePersistentVolumeClaim.
+func (mr *MockPersistentVolumeClaimRepositoryMockRecorder) UpdatePersistentVolumeClaim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePersistentVolumeClaim", reflect.TypeOf((*MockPersistentVolumeClaimRepository)(nil).UpdatePersistentVolumeClaim), arg0, arg1)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/etcd (interfaces: PersistentVolumeRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersistentVolumeRepository is a mock of PersistentVolumeRepository interface.
+type MockPersistentVolumeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersistentVolumeRepositoryMockRecorder
+}
+
+// MockPersistentVolumeRepositoryMockRecorder is the mock recorder for MockPersistentVolumeRepository.
+type MockPersistentVolumeRepositoryMockRecorder struct {
+	mock *MockPersistentVolumeRepository
+}
+
+// NewMockPersistentVolumeRepository creates a new mock instance.
+func NewMockPersistentVolumeRepository(ctrl *gomock.Controller) *MockPersistentVolumeRepository {
+	mock := &MockPersistentVolumeRepository{ctrl: ctrl}
+	mock.recorder = &MockPersistentVolumeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersistentVolumeRepository) EXPECT() *MockPersistentVolumeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePersistentVolume mocks base method.
+func (m *MockPersistentVolumeRepository) CreatePersistentVolume(arg0 context.Context, arg1 *shared.PersistentVolume) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersistentVolume", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePersistentVolume indicates an expected call of CreatePersistentVolume.
+func (mr *MockPersistentVolumeRepositoryMockRecorder) CreatePersistentVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersistentVolume", reflect.TypeOf((*MockPersistentVolumeRepository)(nil).CreatePersistentVolume), arg0, arg1)
+}
+
+// DeletePersistentVolume mocks base method.
+func (m *MockPersistentVolumeRepository) DeletePersistentVolume(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePersistentVolume", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePersistentVolume indicates an expected call of DeletePersistentVolume.
+func (mr *MockPersistentVolumeRepositoryMockRecorder) DeletePersistentVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePersistentVolume", reflect.TypeOf((*MockPersistentVolumeRepository)(nil).DeletePersistentVolume), arg0, arg1)
+}
+
+// GetPersistentVolumeByID mocks base method.
+func (m *MockPersistentVolumeRepository) GetPersistentVolumeByID(arg0 context.Context, arg1 string) (*shared.PersistentVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersistentVolumeByID", arg0, arg1)
+	ret0, _ := ret[0].(*shared.PersistentVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersistentVolumeByID indicates an expected call of GetPersistentVolumeByID.
+func (mr *MockPersistentVolumeRepositoryMockRecorder) GetPersistentVolumeByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersistentVolumeByID", reflect.TypeOf((*MockPersistentVolumeRepository)(nil).GetPersistentVolumeByID), arg0, arg1)
+}
+
+// ListPersistentVolumes mocks base method.
+func (m *MockPersistentVolumeRepository) ListPersistentVolumes(arg0 context.Context) ([]shared.PersistentVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPersistentVolumes", arg0)
+	ret0, _ := ret[0].([]shared.PersistentVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPersistentVolumes indicates an expected call of ListPersistentVolumes.
+func (mr *MockPersistentVolumeRepositoryMockRecorder) ListPersistentVolumes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPersistentVolumes", reflect.TypeOf((*MockPersistentVolumeRepository)(nil).ListPersistentVolumes), arg0)
+}
+
+// UpdatePersistentVolume mocks base method.
+func (m *MockPersistentVolumeRepository) UpdatePersistentVolume(arg0 context.Context, arg1 *shared.PersistentVolume) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePersistentVolume", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePersistentVolume indicates an expected call of UpdatePersistentVolume.
+func (mr *MockPersistentVolumeRepositoryMockRecorder) UpdatePersistentVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePersistentVolume", reflect.TypeOf((*MockPersistentVolumeRepository)(nil).UpdatePersistentVolume), arg0, arg1)
+}
@@ -91,3 +91,17 @@ func (mr *MockPodManagerMockRecorder) StopPod(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopPod", reflect.TypeOf((*MockPodManager)(nil).StopPod), arg0)
 }
+
+// SyncPodStatus mocks base method.
+func (m *MockPodManager) SyncPodStatus(arg0 *shared.Pod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncPodStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncPodStatus indicates an expected call of SyncPodStatus.
+func (mr *MockPodManagerMockRecorder) SyncPodStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncPodStatus", reflect.TypeOf((*MockPodManager)(nil).SyncPodStatus), arg0)
+}
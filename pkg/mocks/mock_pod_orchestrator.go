@@ -37,59 +37,97 @@ func (m *MockPodOrchestrator) EXPECT() *MockPodOrchestratorMockRecorder {
 }
 
 // GetPodLogs mocks base method.
-func (m *MockPodOrchestrator) GetPodLogs(arg0 context.Context, arg1, arg2 string, arg3 bool) (io.ReadCloser, error) {
+func (m *MockPodOrchestrator) GetPodLogs(arg0 context.Context, arg1, arg2, arg3 string, arg4 bool) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPodLogs", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "GetPodLogs", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(io.ReadCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPodLogs indicates an expected call of GetPodLogs.
-func (mr *MockPodOrchestratorMockRecorder) GetPodLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockPodOrchestratorMockRecorder) GetPodLogs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodLogs", reflect.TypeOf((*MockPodOrchestrator)(nil).GetPodLogs), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodLogs", reflect.TypeOf((*MockPodOrchestrator)(nil).GetPodLogs), arg0, arg1, arg2, arg3, arg4)
 }
 
 // OrchestrateContainerCommandExecution mocks base method.
-func (m *MockPodOrchestrator) OrchestrateContainerCommandExecution(arg0 context.Context, arg1, arg2, arg3 string) (string, error) {
+func (m *MockPodOrchestrator) OrchestrateContainerCommandExecution(arg0 context.Context, arg1, arg2, arg3, arg4 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrchestrateContainerCommandExecution", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "OrchestrateContainerCommandExecution", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // OrchestrateContainerCommandExecution indicates an expected call of OrchestrateContainerCommandExecution.
-func (mr *MockPodOrchestratorMockRecorder) OrchestrateContainerCommandExecution(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockPodOrchestratorMockRecorder) OrchestrateContainerCommandExecution(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateContainerCommandExecution", reflect.TypeOf((*MockPodOrchestrator)(nil).OrchestrateContainerCommandExecution), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateContainerCommandExecution", reflect.TypeOf((*MockPodOrchestrator)(nil).OrchestrateContainerCommandExecution), arg0, arg1, arg2, arg3, arg4)
 }
 
 // OrchestratePodCreation mocks base method.
-func (m *MockPodOrchestrator) OrchestratePodCreation(arg0 *shared.Pod) error {
+func (m *MockPodOrchestrator) OrchestratePodCreation(arg0 context.Context, arg1 *shared.Pod) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrchestratePodCreation", arg0)
+	ret := m.ctrl.Call(m, "OrchestratePodCreation", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OrchestratePodCreation indicates an expected call of OrchestratePodCreation.
-func (mr *MockPodOrchestratorMockRecorder) OrchestratePodCreation(arg0 interface{}) *gomock.Call {
+func (mr *MockPodOrchestratorMockRecorder) OrchestratePodCreation(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestratePodCreation", reflect.TypeOf((*MockPodOrchestrator)(nil).OrchestratePodCreation), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestratePodCreation", reflect.TypeOf((*MockPodOrchestrator)(nil).OrchestratePodCreation), arg0, arg1)
 }
 
 // OrchestratePodDeletion mocks base method.
-func (m *MockPodOrchestrator) OrchestratePodDeletion(arg0 *shared.Pod) error {
+func (m *MockPodOrchestrator) OrchestratePodDeletion(arg0 context.Context, arg1 *shared.Pod) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrchestratePodDeletion", arg0)
+	ret := m.ctrl.Call(m, "OrchestratePodDeletion", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OrchestratePodDeletion indicates an expected call of OrchestratePodDeletion.
-func (mr *MockPodOrchestratorMockRecorder) OrchestratePodDeletion(arg0 interface{}) *gomock.Call {
+func (mr *MockPodOrchestratorMockRecorder) OrchestratePodDeletion(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestratePodDeletion", reflect.TypeOf((*MockPodOrchestrator)(nil).OrchestratePodDeletion), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestratePodDeletion", reflect.TypeOf((*MockPodOrchestrator)(nil).OrchestratePodDeletion), arg0, arg1)
+}
+
+// RepairPodIPs mocks base method.
+func (m *MockPodOrchestrator) RepairPodIPs(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairPodIPs", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairPodIPs indicates an expected call of RepairPodIPs.
+func (mr *MockPodOrchestratorMockRecorder) RepairPodIPs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairPodIPs", reflect.TypeOf((*MockPodOrchestrator)(nil).RepairPodIPs), arg0)
+}
+
+// RunPodStatusLoop mocks base method.
+func (m *MockPodOrchestrator) RunPodStatusLoop(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunPodStatusLoop", arg0)
+}
+
+// RunPodStatusLoop indicates an expected call of RunPodStatusLoop.
+func (mr *MockPodOrchestratorMockRecorder) RunPodStatusLoop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunPodStatusLoop", reflect.TypeOf((*MockPodOrchestrator)(nil).RunPodStatusLoop), arg0)
+}
+
+// RunSchedulingLoop mocks base method.
+func (m *MockPodOrchestrator) RunSchedulingLoop(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunSchedulingLoop", arg0)
+}
+
+// RunSchedulingLoop indicates an expected call of RunSchedulingLoop.
+func (mr *MockPodOrchestratorMockRecorder) RunSchedulingLoop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSchedulingLoop", reflect.TypeOf((*MockPodOrchestrator)(nil).RunSchedulingLoop), arg0)
 }
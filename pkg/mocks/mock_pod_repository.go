@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	shared "maden/pkg/shared"
 	reflect "reflect"
 
@@ -35,88 +36,102 @@ func (m *MockPodRepository) EXPECT() *MockPodRepositoryMockRecorder {
 }
 
 // CreatePod mocks base method.
-func (m *MockPodRepository) CreatePod(arg0 *shared.Pod) error {
+func (m *MockPodRepository) CreatePod(arg0 context.Context, arg1 *shared.Pod) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePod", arg0)
+	ret := m.ctrl.Call(m, "CreatePod", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePod indicates an expected call of CreatePod.
-func (mr *MockPodRepositoryMockRecorder) CreatePod(arg0 interface{}) *gomock.Call {
+func (mr *MockPodRepositoryMockRecorder) CreatePod(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePod", reflect.TypeOf((*MockPodRepository)(nil).CreatePod), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePod", reflect.TypeOf((*MockPodRepository)(nil).CreatePod), arg0, arg1)
 }
 
 // DeletePod mocks base method.
-func (m *MockPodRepository) DeletePod(arg0 string) error {
+func (m *MockPodRepository) DeletePod(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePod", arg0)
+	ret := m.ctrl.Call(m, "DeletePod", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePod indicates an expected call of DeletePod.
-func (mr *MockPodRepositoryMockRecorder) DeletePod(arg0 interface{}) *gomock.Call {
+func (mr *MockPodRepositoryMockRecorder) DeletePod(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePod", reflect.TypeOf((*MockPodRepository)(nil).DeletePod), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePod", reflect.TypeOf((*MockPodRepository)(nil).DeletePod), arg0, arg1, arg2)
 }
 
 // GetPodByID mocks base method.
-func (m *MockPodRepository) GetPodByID(arg0 string) (*shared.Pod, error) {
+func (m *MockPodRepository) GetPodByID(arg0 context.Context, arg1, arg2 string) (*shared.Pod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPodByID", arg0)
+	ret := m.ctrl.Call(m, "GetPodByID", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*shared.Pod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPodByID indicates an expected call of GetPodByID.
-func (mr *MockPodRepositoryMockRecorder) GetPodByID(arg0 interface{}) *gomock.Call {
+func (mr *MockPodRepositoryMockRecorder) GetPodByID(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodByID", reflect.TypeOf((*MockPodRepository)(nil).GetPodByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodByID", reflect.TypeOf((*MockPodRepository)(nil).GetPodByID), arg0, arg1, arg2)
 }
 
 // GetPodsByDeploymentID mocks base method.
-func (m *MockPodRepository) GetPodsByDeploymentID(arg0 string) ([]shared.Pod, error) {
+func (m *MockPodRepository) GetPodsByDeploymentID(arg0 context.Context, arg1, arg2 string) ([]shared.Pod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPodsByDeploymentID", arg0)
+	ret := m.ctrl.Call(m, "GetPodsByDeploymentID", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]shared.Pod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPodsByDeploymentID indicates an expected call of GetPodsByDeploymentID.
-func (mr *MockPodRepositoryMockRecorder) GetPodsByDeploymentID(arg0 interface{}) *gomock.Call {
+func (mr *MockPodRepositoryMockRecorder) GetPodsByDeploymentID(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodsByDeploymentID", reflect.TypeOf((*MockPodRepository)(nil).GetPodsByDeploymentID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodsByDeploymentID", reflect.TypeOf((*MockPodRepository)(nil).GetPodsByDeploymentID), arg0, arg1, arg2)
 }
 
 // ListPods mocks base method.
-func (m *MockPodRepository) ListPods() ([]shared.Pod, error) {
+func (m *MockPodRepository) ListPods(arg0 context.Context, arg1 string) ([]shared.Pod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPods")
+	ret := m.ctrl.Call(m, "ListPods", arg0, arg1)
 	ret0, _ := ret[0].([]shared.Pod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPods indicates an expected call of ListPods.
-func (mr *MockPodRepositoryMockRecorder) ListPods() *gomock.Call {
+func (mr *MockPodRepositoryMockRecorder) ListPods(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPods", reflect.TypeOf((*MockPodRepository)(nil).ListPods))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPods", reflect.TypeOf((*MockPodRepository)(nil).ListPods), arg0, arg1)
 }
 
 // UpdatePod mocks base method.
-func (m *MockPodRepository) UpdatePod(arg0 *shared.Pod) error {
+func (m *MockPodRepository) UpdatePod(arg0 context.Context, arg1 *shared.Pod) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePod", arg0)
+	ret := m.ctrl.Call(m, "UpdatePod", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdatePod indicates an expected call of UpdatePod.
-func (mr *MockPodRepositoryMockRecorder) UpdatePod(arg0 interface{}) *gomock.Call {
+func (mr *MockPodRepositoryMockRecorder) UpdatePod(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePod", reflect.TypeOf((*MockPodRepository)(nil).UpdatePod), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePod", reflect.TypeOf((*MockPodRepository)(nil).UpdatePod), arg0, arg1)
+}
+
+// WatchPods mocks base method.
+func (m *MockPodRepository) WatchPods(arg0 context.Context) <-chan shared.WatchEvent[shared.Pod] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchPods", arg0)
+	ret0, _ := ret[0].(<-chan shared.WatchEvent[shared.Pod])
+	return ret0
+}
+
+// WatchPods indicates an expected call of WatchPods.
+func (mr *MockPodRepositoryMockRecorder) WatchPods(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchPods", reflect.TypeOf((*MockPodRepository)(nil).WatchPods), arg0)
 }
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/madelet (interfaces: Prober)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	shared "maden/pkg/shared"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProber is a mock of Prober interface.
+type MockProber struct {
+	ctrl     *gomock.Controller
+	recorder *MockProberMockRecorder
+}
+
+// MockProberMockRecorder is the mock recorder for MockProber.
+type MockProberMockRecorder struct {
+	mock *MockProber
+}
+
+// NewMockProber creates a new mock instance.
+func NewMockProber(ctrl *gomock.Controller) *MockProber {
+	mock := &MockProber{ctrl: ctrl}
+	mock.recorder = &MockProberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProber) EXPECT() *MockProberMockRecorder {
+	return m.recorder
+}
+
+// Probe mocks base method.
+func (m *MockProber) Probe(arg0 context.Context, arg1 string, arg2 *shared.Probe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Probe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Probe indicates an expected call of Probe.
+func (mr *MockProberMockRecorder) Probe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Probe", reflect.TypeOf((*MockProber)(nil).Probe), arg0, arg1, arg2)
+}
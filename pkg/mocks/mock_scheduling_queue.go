@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maden/pkg/scheduler (interfaces: SchedulingQueue)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSchedulingQueue is a mock of SchedulingQueue interface.
+type MockSchedulingQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulingQueueMockRecorder
+}
+
+// MockSchedulingQueueMockRecorder is the mock recorder for MockSchedulingQueue.
+type MockSchedulingQueueMockRecorder struct {
+	mock *MockSchedulingQueue
+}
+
+// NewMockSchedulingQueue creates a new mock instance.
+func NewMockSchedulingQueue(ctrl *gomock.Controller) *MockSchedulingQueue {
+	mock := &MockSchedulingQueue{ctrl: ctrl}
+	mock.recorder = &MockSchedulingQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulingQueue) EXPECT() *MockSchedulingQueueMockRecorder {
+	return m.recorder
+}
+
+// AddUnschedulable mocks base method.
+func (m *MockSchedulingQueue) AddUnschedulable(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUnschedulable", arg0)
+}
+
+// AddUnschedulable indicates an expected call of AddUnschedulable.
+func (mr *MockSchedulingQueueMockRecorder) AddUnschedulable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUnschedulable", reflect.TypeOf((*MockSchedulingQueue)(nil).AddUnschedulable), arg0)
+}
+
+// Len mocks base method.
+func (m *MockSchedulingQueue) Len() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Len")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Len indicates an expected call of Len.
+func (mr *MockSchedulingQueueMockRecorder) Len() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockSchedulingQueue)(nil).Len))
+}
+
+// MoveAllToActive mocks base method.
+func (m *MockSchedulingQueue) MoveAllToActive() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MoveAllToActive")
+}
+
+// MoveAllToActive indicates an expected call of MoveAllToActive.
+func (mr *MockSchedulingQueueMockRecorder) MoveAllToActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveAllToActive", reflect.TypeOf((*MockSchedulingQueue)(nil).MoveAllToActive))
+}
+
+// Pop mocks base method.
+func (m *MockSchedulingQueue) Pop(arg0 context.Context) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pop", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Pop indicates an expected call of Pop.
+func (mr *MockSchedulingQueueMockRecorder) Pop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pop", reflect.TypeOf((*MockSchedulingQueue)(nil).Pop), arg0)
+}
+
+// Remove mocks base method.
+func (m *MockSchedulingQueue) Remove(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", arg0)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockSchedulingQueueMockRecorder) Remove(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockSchedulingQueue)(nil).Remove), arg0)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	shared "maden/pkg/shared"
 	reflect "reflect"
 
@@ -35,43 +36,57 @@ func (m *MockServiceOrchestrator) EXPECT() *MockServiceOrchestratorMockRecorder
 }
 
 // OrchestrateServiceCreation mocks base method.
-func (m *MockServiceOrchestrator) OrchestrateServiceCreation(arg0 shared.ServiceSpec) error {
+func (m *MockServiceOrchestrator) OrchestrateServiceCreation(arg0 context.Context, arg1 shared.ServiceSpec) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrchestrateServiceCreation", arg0)
+	ret := m.ctrl.Call(m, "OrchestrateServiceCreation", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OrchestrateServiceCreation indicates an expected call of OrchestrateServiceCreation.
-func (mr *MockServiceOrchestratorMockRecorder) OrchestrateServiceCreation(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceOrchestratorMockRecorder) OrchestrateServiceCreation(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateServiceCreation", reflect.TypeOf((*MockServiceOrchestrator)(nil).OrchestrateServiceCreation), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateServiceCreation", reflect.TypeOf((*MockServiceOrchestrator)(nil).OrchestrateServiceCreation), arg0, arg1)
 }
 
 // OrchestrateServiceDeletion mocks base method.
-func (m *MockServiceOrchestrator) OrchestrateServiceDeletion(arg0 string) error {
+func (m *MockServiceOrchestrator) OrchestrateServiceDeletion(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrchestrateServiceDeletion", arg0)
+	ret := m.ctrl.Call(m, "OrchestrateServiceDeletion", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OrchestrateServiceDeletion indicates an expected call of OrchestrateServiceDeletion.
-func (mr *MockServiceOrchestratorMockRecorder) OrchestrateServiceDeletion(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceOrchestratorMockRecorder) OrchestrateServiceDeletion(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateServiceDeletion", reflect.TypeOf((*MockServiceOrchestrator)(nil).OrchestrateServiceDeletion), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateServiceDeletion", reflect.TypeOf((*MockServiceOrchestrator)(nil).OrchestrateServiceDeletion), arg0, arg1, arg2)
 }
 
 // OrchestrateServiceUpdate mocks base method.
-func (m *MockServiceOrchestrator) OrchestrateServiceUpdate(arg0 shared.Service, arg1 shared.ServiceSpec) error {
+func (m *MockServiceOrchestrator) OrchestrateServiceUpdate(arg0 context.Context, arg1 shared.Service, arg2 shared.ServiceSpec) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "OrchestrateServiceUpdate", arg0, arg1)
+	ret := m.ctrl.Call(m, "OrchestrateServiceUpdate", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OrchestrateServiceUpdate indicates an expected call of OrchestrateServiceUpdate.
-func (mr *MockServiceOrchestratorMockRecorder) OrchestrateServiceUpdate(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceOrchestratorMockRecorder) OrchestrateServiceUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateServiceUpdate", reflect.TypeOf((*MockServiceOrchestrator)(nil).OrchestrateServiceUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrchestrateServiceUpdate", reflect.TypeOf((*MockServiceOrchestrator)(nil).OrchestrateServiceUpdate), arg0, arg1, arg2)
+}
+
+// RepairServiceIPs mocks base method.
+func (m *MockServiceOrchestrator) RepairServiceIPs(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairServiceIPs", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairServiceIPs indicates an expected call of RepairServiceIPs.
+func (mr *MockServiceOrchestratorMockRecorder) RepairServiceIPs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairServiceIPs", reflect.TypeOf((*MockServiceOrchestrator)(nil).RepairServiceIPs), arg0)
 }
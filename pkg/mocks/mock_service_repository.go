@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	shared "maden/pkg/shared"
 	reflect "reflect"
 
@@ -35,73 +36,87 @@ func (m *MockServiceRepository) EXPECT() *MockServiceRepositoryMockRecorder {
 }
 
 // CreateService mocks base method.
-func (m *MockServiceRepository) CreateService(arg0 *shared.Service) error {
+func (m *MockServiceRepository) CreateService(arg0 context.Context, arg1 *shared.Service) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateService", arg0)
+	ret := m.ctrl.Call(m, "CreateService", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateService indicates an expected call of CreateService.
-func (mr *MockServiceRepositoryMockRecorder) CreateService(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceRepositoryMockRecorder) CreateService(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockServiceRepository)(nil).CreateService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockServiceRepository)(nil).CreateService), arg0, arg1)
 }
 
 // DeleteService mocks base method.
-func (m *MockServiceRepository) DeleteService(arg0 string) error {
+func (m *MockServiceRepository) DeleteService(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteService", arg0)
+	ret := m.ctrl.Call(m, "DeleteService", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteService indicates an expected call of DeleteService.
-func (mr *MockServiceRepositoryMockRecorder) DeleteService(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceRepositoryMockRecorder) DeleteService(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockServiceRepository)(nil).DeleteService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockServiceRepository)(nil).DeleteService), arg0, arg1, arg2)
 }
 
 // GetServiceByName mocks base method.
-func (m *MockServiceRepository) GetServiceByName(arg0 string) (*shared.Service, error) {
+func (m *MockServiceRepository) GetServiceByName(arg0 context.Context, arg1, arg2 string) (*shared.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetServiceByName", arg0)
+	ret := m.ctrl.Call(m, "GetServiceByName", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*shared.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetServiceByName indicates an expected call of GetServiceByName.
-func (mr *MockServiceRepositoryMockRecorder) GetServiceByName(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceRepositoryMockRecorder) GetServiceByName(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceByName", reflect.TypeOf((*MockServiceRepository)(nil).GetServiceByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceByName", reflect.TypeOf((*MockServiceRepository)(nil).GetServiceByName), arg0, arg1, arg2)
 }
 
 // ListServices mocks base method.
-func (m *MockServiceRepository) ListServices() ([]shared.Service, error) {
+func (m *MockServiceRepository) ListServices(arg0 context.Context, arg1 string) ([]shared.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListServices")
+	ret := m.ctrl.Call(m, "ListServices", arg0, arg1)
 	ret0, _ := ret[0].([]shared.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListServices indicates an expected call of ListServices.
-func (mr *MockServiceRepositoryMockRecorder) ListServices() *gomock.Call {
+func (mr *MockServiceRepositoryMockRecorder) ListServices(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockServiceRepository)(nil).ListServices))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockServiceRepository)(nil).ListServices), arg0, arg1)
 }
 
 // UpdateService mocks base method.
-func (m *MockServiceRepository) UpdateService(arg0 *shared.Service) error {
+func (m *MockServiceRepository) UpdateService(arg0 context.Context, arg1 *shared.Service) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateService", arg0)
+	ret := m.ctrl.Call(m, "UpdateService", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateService indicates an expected call of UpdateService.
-func (mr *MockServiceRepositoryMockRecorder) UpdateService(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceRepositoryMockRecorder) UpdateService(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockServiceRepository)(nil).UpdateService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockServiceRepository)(nil).UpdateService), arg0, arg1)
+}
+
+// WatchServices mocks base method.
+func (m *MockServiceRepository) WatchServices(arg0 context.Context) <-chan shared.WatchEvent[shared.Service] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchServices", arg0)
+	ret0, _ := ret[0].(<-chan shared.WatchEvent[shared.Service])
+	return ret0
+}
+
+// WatchServices indicates an expected call of WatchServices.
+func (mr *MockServiceRepositoryMockRecorder) WatchServices(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchServices", reflect.TypeOf((*MockServiceRepository)(nil).WatchServices), arg0)
 }
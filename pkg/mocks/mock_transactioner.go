@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 // MockTransactioner is a mock of Transactioner interface.
@@ -35,16 +36,56 @@ func (m *MockTransactioner) EXPECT() *MockTransactionerMockRecorder {
 	return m.recorder
 }
 
-// PerformTransaction mocks base method.
-func (m *MockTransactioner) PerformTransaction(arg0 context.Context, arg1, arg2 string, arg3 shared.ResourceType) error {
+// PerformDeleteTransaction mocks base method.
+func (m *MockTransactioner) PerformDeleteTransaction(arg0 context.Context, arg1 string, arg2 int64, arg3 shared.ResourceType, arg4 ...clientv3.Op) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PerformTransaction", arg0, arg1, arg2, arg3)
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PerformDeleteTransaction", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// PerformDeleteTransaction indicates an expected call of PerformDeleteTransaction.
+func (mr *MockTransactionerMockRecorder) PerformDeleteTransaction(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PerformDeleteTransaction", reflect.TypeOf((*MockTransactioner)(nil).PerformDeleteTransaction), varargs...)
+}
+
+// PerformTransaction mocks base method.
+func (m *MockTransactioner) PerformTransaction(arg0 context.Context, arg1, arg2 string, arg3 shared.ResourceType, arg4 ...clientv3.Op) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PerformTransaction", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // PerformTransaction indicates an expected call of PerformTransaction.
-func (mr *MockTransactionerMockRecorder) PerformTransaction(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockTransactionerMockRecorder) PerformTransaction(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PerformTransaction", reflect.TypeOf((*MockTransactioner)(nil).PerformTransaction), varargs...)
+}
+
+// PerformUpdateTransaction mocks base method.
+func (m *MockTransactioner) PerformUpdateTransaction(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4 shared.ResourceType) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PerformUpdateTransaction", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PerformUpdateTransaction indicates an expected call of PerformUpdateTransaction.
+func (mr *MockTransactionerMockRecorder) PerformUpdateTransaction(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PerformTransaction", reflect.TypeOf((*MockTransactioner)(nil).PerformTransaction), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PerformUpdateTransaction", reflect.TypeOf((*MockTransactioner)(nil).PerformUpdateTransaction), arg0, arg1, arg2, arg3, arg4)
 }
@@ -1,6 +1,16 @@
 package networking
 
+import "context"
+
 type IPManager interface {
-	AssignIP() (string, error)
-	ReleaseIP(ip string) error
-}
\ No newline at end of file
+	AssignIP(ctx context.Context) (string, error)
+	AssignSpecificIP(ctx context.Context, ip string) error
+	ReleaseIP(ctx context.Context, ip string) error
+	Contains(ip string) bool
+	Repair(ctx context.Context, inUse []string) error
+	Bind(ctx context.Context, owner string) error
+}
+
+type ServiceProxy interface {
+	Run(ctx context.Context)
+}
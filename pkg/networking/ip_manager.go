@@ -1,34 +1,300 @@
 package networking
 
-import "fmt"
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/shared"
 
-type SimpleIPManager struct {
-	availableIPs []string
-	usedIPs      map[string]bool
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net"
+	"os"
+	"sync"
+)
+
+const (
+	ServiceIPRange = "services"
+	PodIPRange     = "pods"
+
+	DefaultServiceCIDR = "10.96.0.0/16"
+	DefaultPodCIDR     = "10.244.0.0/16"
+
+	maxHostBits = 20 // Keeps the bitmap of a range at 128KiB, well below the size limit of etcd values
+)
+
+type IPAMConfig struct {
+	ServiceCIDR string // Virtual IPs of the services
+	PodCIDR     string // IPs of the pods, on whichever node they run
+}
+
+// Reads SERVICE_CIDR and POD_CIDR from the environment, the defaults stand in for those not set
+func NewIPAMConfig() IPAMConfig {
+	return IPAMConfig{
+		ServiceCIDR: getEnv("SERVICE_CIDR", DefaultServiceCIDR),
+		PodCIDR:     getEnv("POD_CIDR", DefaultPodCIDR),
+	}
+}
+
+func getEnv(key string, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return defaultValue
 }
 
-func NewSimpleIPManager() IPManager {
-	return &SimpleIPManager{
-		availableIPs: []string{"192.168.1.100", "192.168.1.101", "192.168.1.102", "192.168.1.103"},
-		usedIPs:      make(map[string]bool),
+// Hands out the IPv4 addresses of a CIDR, tracked in a bitmap stored in etcd so that allocations survive restarts
+// and are shared by every process allocating from the same range. Each change is a compare-and-swap of the bitmap,
+// retried when another process changed it in between. The network address, the first host, left to the gateway,
+// and the broadcast address are never handed out
+type EtcdIPManager struct {
+	Repo  etcd.IPAllocationRepository
+	Range string
+
+	network *net.IPNet
+	size    int // Addresses in the CIDR
+	mutex   sync.Mutex
+}
+
+func NewEtcdIPManager(repo etcd.IPAllocationRepository, rangeName string, cidr string) (IPManager, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	ones, size := network.Mask.Size()
+	if network.IP.To4() == nil || size != 32 {
+		return nil, fmt.Errorf("CIDR %s is not an IPv4 CIDR", cidr)
 	}
+	if hostBits := size - ones; hostBits < 2 || hostBits > maxHostBits {
+		return nil, fmt.Errorf("CIDR %s must have between 2 and %d host bits", cidr, maxHostBits)
+	}
+
+	return &EtcdIPManager{
+		Repo:    repo,
+		Range:   rangeName,
+		network: &net.IPNet{IP: network.IP.To4(), Mask: network.Mask},
+		size:    1 << (size - ones),
+	}, nil
+}
+
+func NewServiceIPManager(config IPAMConfig, repo etcd.IPAllocationRepository) (IPManager, error) {
+	return NewEtcdIPManager(repo, ServiceIPRange, config.ServiceCIDR)
 }
 
-func (manager *SimpleIPManager) AssignIP() (string, error) {
-	if len(manager.availableIPs) == 0 {
-		return "", fmt.Errorf("no available IPs")
+func NewPodIPManager(config IPAMConfig, repo etcd.IPAllocationRepository) (IPManager, error) {
+	return NewEtcdIPManager(repo, PodIPRange, config.PodCIDR)
+}
+
+// The lowest free address of the range
+func (m *EtcdIPManager) AssignIP(ctx context.Context) (string, error) {
+	var ip string
+	err := m.update(ctx, func(bitmap []byte) (bool, error) {
+		for offset := 2; offset < m.size-1; offset++ {
+			if !isAllocated(bitmap, offset) {
+				setAllocated(bitmap, offset, true)
+				ip = m.ipAt(offset)
+				return true, nil
+			}
+		}
+		return false, &shared.ErrIPRangeFull{CIDR: m.network.String()}
+	})
+	if err != nil {
+		return "", err
 	}
-	ip := manager.availableIPs[0]
-	manager.availableIPs = manager.availableIPs[1:]
-	manager.usedIPs[ip] = true
 	return ip, nil
 }
 
-func (manager *SimpleIPManager) ReleaseIP(ip string) error {
-	if !manager.usedIPs[ip] {
-		return fmt.Errorf("IP %s is not in use", ip)
+func (m *EtcdIPManager) AssignSpecificIP(ctx context.Context, ip string) error {
+	offset, err := m.offsetOf(ip)
+	if err != nil {
+		return err
+	}
+
+	return m.update(ctx, func(bitmap []byte) (bool, error) {
+		if isAllocated(bitmap, offset) {
+			return false, &shared.ErrIPInUse{IP: ip}
+		}
+		setAllocated(bitmap, offset, true)
+		return true, nil
+	})
+}
+
+// Releasing an address that is free already does nothing
+func (m *EtcdIPManager) ReleaseIP(ctx context.Context, ip string) error {
+	offset, err := m.offsetOf(ip)
+	if err != nil {
+		return err
+	}
+
+	return m.update(ctx, func(bitmap []byte) (bool, error) {
+		if !isAllocated(bitmap, offset) {
+			return false, nil
+		}
+		setAllocated(bitmap, offset, false)
+		return true, nil
+	})
+}
+
+// Whether ip is an address of the range that can be handed out
+func (m *EtcdIPManager) Contains(ip string) bool {
+	_, err := m.offsetOf(ip)
+	return err == nil
+}
+
+// Replaces the allocations with the addresses actually in use, releasing those leaked by crashes and taking over
+// the CIDR the range is configured with. Addresses outside the range are skipped
+func (m *EtcdIPManager) Repair(ctx context.Context, inUse []string) error {
+	bitmap := make([]byte, m.bitmapSize())
+	for _, ip := range inUse {
+		offset, err := m.offsetOf(ip)
+		if err != nil {
+			shared.Log.Errorf("Skipping IP %s while repairing range %s: %v", ip, m.Range, err)
+			continue
+		}
+		setAllocated(bitmap, offset, true)
 	}
-	manager.availableIPs = append(manager.availableIPs, ip)
-	delete(manager.usedIPs, ip)
-	return nil
-}
\ No newline at end of file
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return shared.RetryOnConflict(func() error {
+		allocation, err := m.Repo.GetIPAllocation(ctx, m.Range)
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			allocation = &shared.IPAllocation{Range: m.Range}
+		} else if err != nil {
+			return err
+		}
+
+		if allocation.CIDR == m.network.String() && len(allocation.Bitmap) == len(bitmap) {
+			if leaked := countAllocated(allocation.Bitmap) - countAllocated(bitmap); leaked > 0 {
+				shared.Log.Infof("Releasing %d leaked IPs of range %s", leaked, m.Range)
+			}
+		} else if allocation.CIDR != "" {
+			shared.Log.Infof("Moving range %s from %s to %s", m.Range, allocation.CIDR, m.network.String())
+		}
+
+		allocation.CIDR = m.network.String()
+		allocation.Bitmap = append([]byte(nil), bitmap...)
+		return m.save(ctx, allocation)
+	})
+}
+
+// Ties the range to owner, e.g. the Docker host whose bridge network holds the addresses. Another owner can only
+// take it over once every address of the range is free again
+func (m *EtcdIPManager) Bind(ctx context.Context, owner string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return shared.RetryOnConflict(func() error {
+		allocation, err := m.load(ctx)
+		if err != nil {
+			return err
+		}
+
+		if allocation.Owner == owner {
+			return nil
+		}
+		if allocated := countAllocated(allocation.Bitmap); allocation.Owner != "" && allocated > 0 {
+			return &shared.ErrIPRangeBound{Range: m.Range, Owner: allocation.Owner, Allocated: allocated}
+		}
+		allocation.Owner = owner
+		return m.save(ctx, allocation)
+	})
+}
+
+// Runs modify on the current bitmap of the range and stores it if modify changed it.
+// The mutex keeps the goroutines of this process from conflicting with each other
+func (m *EtcdIPManager) update(ctx context.Context, modify func(bitmap []byte) (bool, error)) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return shared.RetryOnConflict(func() error {
+		allocation, err := m.load(ctx)
+		if err != nil {
+			return err
+		}
+
+		changed, err := modify(allocation.Bitmap)
+		if err != nil || !changed {
+			return err
+		}
+		return m.save(ctx, allocation)
+	})
+}
+
+// An empty allocation if the range was never written
+func (m *EtcdIPManager) load(ctx context.Context) (*shared.IPAllocation, error) {
+	allocation, err := m.Repo.GetIPAllocation(ctx, m.Range)
+	var errNotFound *shared.ErrNotFound
+	if errors.As(err, &errNotFound) {
+		return &shared.IPAllocation{Range: m.Range, CIDR: m.network.String(), Bitmap: make([]byte, m.bitmapSize())}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if allocation.CIDR != m.network.String() || len(allocation.Bitmap) != m.bitmapSize() {
+		return nil, fmt.Errorf("range %s is allocated from %s rather than %s, it has to be repaired first", m.Range, allocation.CIDR, m.network.String())
+	}
+	return allocation, nil
+}
+
+// Creating the allocation races with other processes doing the same, losing is retried like a conflict
+func (m *EtcdIPManager) save(ctx context.Context, allocation *shared.IPAllocation) error {
+	if allocation.ResourceVersion != 0 {
+		return m.Repo.UpdateIPAllocation(ctx, allocation)
+	}
+
+	err := m.Repo.CreateIPAllocation(ctx, allocation)
+	var errDuplicate *shared.ErrDuplicateResource
+	if errors.As(err, &errDuplicate) {
+		return &shared.ErrConflict{ID: m.Range, ResourceType: shared.IPAllocationResource}
+	}
+	return err
+}
+
+func (m *EtcdIPManager) bitmapSize() int {
+	return (m.size + 7) / 8
+}
+
+func (m *EtcdIPManager) ipAt(offset int) string {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(m.network.IP)+uint32(offset))
+	return ip.String()
+}
+
+// Position of ip in the range, reserved addresses are not part of it
+func (m *EtcdIPManager) offsetOf(ip string) (int, error) {
+	parsed := net.ParseIP(ip).To4()
+	if parsed == nil || !m.network.Contains(parsed) {
+		return 0, &shared.ErrIPNotInRange{IP: ip, CIDR: m.network.String()}
+	}
+
+	offset := int(binary.BigEndian.Uint32(parsed) - binary.BigEndian.Uint32(m.network.IP))
+	if offset < 2 || offset >= m.size-1 {
+		return 0, &shared.ErrIPNotInRange{IP: ip, CIDR: m.network.String()}
+	}
+	return offset, nil
+}
+
+func isAllocated(bitmap []byte, offset int) bool {
+	return bitmap[offset/8]&(1<<(offset%8)) != 0
+}
+
+func setAllocated(bitmap []byte, offset int, allocated bool) {
+	if allocated {
+		bitmap[offset/8] |= 1 << (offset % 8)
+	} else {
+		bitmap[offset/8] &^= 1 << (offset % 8)
+	}
+}
+
+func countAllocated(bitmap []byte) int {
+	count := 0
+	for _, b := range bitmap {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
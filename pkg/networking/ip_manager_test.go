@@ -0,0 +1,243 @@
+package networking
+
+import (
+	"maden/pkg/shared"
+
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Keeps the allocations in memory and, like etcd, rejects writes based on an outdated read
+type fakeIPAllocationRepository struct {
+	mutex       sync.Mutex
+	allocations map[string]shared.IPAllocation
+	revision    int64
+	conflicts   int // Updates still to be rejected as if someone else had written first
+}
+
+func newFakeIPAllocationRepository() *fakeIPAllocationRepository {
+	return &fakeIPAllocationRepository{allocations: make(map[string]shared.IPAllocation)}
+}
+
+func (r *fakeIPAllocationRepository) GetIPAllocation(_ context.Context, rangeName string) (*shared.IPAllocation, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	allocation, ok := r.allocations[rangeName]
+	if !ok {
+		return nil, &shared.ErrNotFound{ID: rangeName, ResourceType: shared.IPAllocationResource}
+	}
+	allocation.Bitmap = append([]byte(nil), allocation.Bitmap...)
+	return &allocation, nil
+}
+
+func (r *fakeIPAllocationRepository) CreateIPAllocation(_ context.Context, allocation *shared.IPAllocation) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.allocations[allocation.Range]; ok {
+		return &shared.ErrDuplicateResource{ID: allocation.Range, ResourceType: shared.IPAllocationResource}
+	}
+	return r.store(allocation)
+}
+
+func (r *fakeIPAllocationRepository) UpdateIPAllocation(_ context.Context, allocation *shared.IPAllocation) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.conflicts > 0 || r.allocations[allocation.Range].ResourceVersion != allocation.ResourceVersion {
+		r.conflicts--
+		return &shared.ErrConflict{ID: allocation.Range, ResourceType: shared.IPAllocationResource}
+	}
+	return r.store(allocation)
+}
+
+func (r *fakeIPAllocationRepository) store(allocation *shared.IPAllocation) error {
+	r.revision++
+	allocation.ResourceVersion = r.revision
+	stored := *allocation
+	stored.Bitmap = append([]byte(nil), allocation.Bitmap...)
+	r.allocations[allocation.Range] = stored
+	return nil
+}
+
+func newTestIPManager(t *testing.T, repo *fakeIPAllocationRepository, cidr string) *EtcdIPManager {
+	manager, err := NewEtcdIPManager(repo, ServiceIPRange, cidr)
+	require.NoError(t, err)
+	return manager.(*EtcdIPManager)
+}
+
+func TestNewEtcdIPManagerRejectsUnusableCIDRs(t *testing.T) {
+	for _, cidr := range []string{"10.96.0.0", "fd00::/64", "10.96.0.0/31", "10.0.0.0/8"} {
+		_, err := NewEtcdIPManager(newFakeIPAllocationRepository(), ServiceIPRange, cidr)
+		assert.Error(t, err, cidr)
+	}
+}
+
+func TestEtcdIPManagerAssignIP(t *testing.T) {
+	// Arrange
+	repo := newFakeIPAllocationRepository()
+	manager := newTestIPManager(t, repo, "10.96.0.0/29")
+
+	// Act
+	var ips []string
+	for i := 0; i < 5; i++ {
+		ip, err := manager.AssignIP(context.Background())
+		require.NoError(t, err)
+		ips = append(ips, ip)
+	}
+	_, errFull := manager.AssignIP(context.Background())
+
+	// Assert
+	assert.Equal(t, []string{"10.96.0.2", "10.96.0.3", "10.96.0.4", "10.96.0.5", "10.96.0.6"}, ips)
+	var errRangeFull *shared.ErrIPRangeFull
+	assert.ErrorAs(t, errFull, &errRangeFull)
+
+	// A restarted manager sees the allocations of the previous one
+	_, err := newTestIPManager(t, repo, "10.96.0.0/29").AssignIP(context.Background())
+	assert.ErrorAs(t, err, &errRangeFull)
+}
+
+func TestEtcdIPManagerAssignSpecificIP(t *testing.T) {
+	// Arrange
+	manager := newTestIPManager(t, newFakeIPAllocationRepository(), "10.96.0.0/24")
+
+	// Act
+	err := manager.AssignSpecificIP(context.Background(), "10.96.0.10")
+	errInUse := manager.AssignSpecificIP(context.Background(), "10.96.0.10")
+	errGateway := manager.AssignSpecificIP(context.Background(), "10.96.0.1")
+	errOutside := manager.AssignSpecificIP(context.Background(), "192.168.1.100")
+
+	// Assert
+	assert.NoError(t, err)
+	var errIPInUse *shared.ErrIPInUse
+	assert.ErrorAs(t, errInUse, &errIPInUse)
+	var errNotInRange *shared.ErrIPNotInRange
+	assert.ErrorAs(t, errGateway, &errNotInRange)
+	assert.ErrorAs(t, errOutside, &errNotInRange)
+	assert.True(t, manager.Contains("10.96.0.254"))
+	assert.False(t, manager.Contains("10.96.0.255"))
+}
+
+func TestEtcdIPManagerReleaseIP(t *testing.T) {
+	// Arrange
+	manager := newTestIPManager(t, newFakeIPAllocationRepository(), "10.96.0.0/24")
+	first, _ := manager.AssignIP(context.Background())
+	_, _ = manager.AssignIP(context.Background())
+
+	// Act
+	err := manager.ReleaseIP(context.Background(), first)
+	errReleasedTwice := manager.ReleaseIP(context.Background(), first)
+	reassigned, _ := manager.AssignIP(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, errReleasedTwice)
+	assert.Equal(t, first, reassigned)
+}
+
+func TestEtcdIPManagerRetriesOnConflict(t *testing.T) {
+	// Arrange
+	repo := newFakeIPAllocationRepository()
+	manager := newTestIPManager(t, repo, "10.96.0.0/24")
+	_, _ = manager.AssignIP(context.Background())
+	repo.conflicts = 2
+
+	// Act
+	ip, err := manager.AssignIP(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "10.96.0.3", ip)
+}
+
+func TestEtcdIPManagerConcurrentAssignmentsAreUnique(t *testing.T) {
+	// Arrange
+	manager := newTestIPManager(t, newFakeIPAllocationRepository(), "10.96.0.0/24")
+
+	// Act
+	var wg sync.WaitGroup
+	ips := make(chan string, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ip, err := manager.AssignIP(context.Background())
+			assert.NoError(t, err)
+			ips <- ip
+		}()
+	}
+	wg.Wait()
+	close(ips)
+
+	// Assert
+	seen := make(map[string]bool)
+	for ip := range ips {
+		assert.False(t, seen[ip], ip)
+		seen[ip] = true
+	}
+	assert.Len(t, seen, 50)
+}
+
+func TestEtcdIPManagerRepair(t *testing.T) {
+	// Arrange
+	repo := newFakeIPAllocationRepository()
+	manager := newTestIPManager(t, repo, "10.96.0.0/24")
+	for i := 0; i < 3; i++ {
+		_, _ = manager.AssignIP(context.Background())
+	}
+
+	// Act
+	err := manager.Repair(context.Background(), []string{"10.96.0.3", "10.96.0.9", "192.168.1.100"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, manager.AssignSpecificIP(context.Background(), "10.96.0.2"))
+	assert.NoError(t, manager.AssignSpecificIP(context.Background(), "10.96.0.4"))
+	var errIPInUse *shared.ErrIPInUse
+	assert.ErrorAs(t, manager.AssignSpecificIP(context.Background(), "10.96.0.3"), &errIPInUse)
+	assert.ErrorAs(t, manager.AssignSpecificIP(context.Background(), "10.96.0.9"), &errIPInUse)
+}
+
+func TestEtcdIPManagerRepairMovesRangeToNewCIDR(t *testing.T) {
+	// Arrange
+	repo := newFakeIPAllocationRepository()
+	_, _ = newTestIPManager(t, repo, "10.96.0.0/24").AssignIP(context.Background())
+	manager := newTestIPManager(t, repo, "10.100.0.0/16")
+	_, errBeforeRepair := manager.AssignIP(context.Background())
+
+	// Act
+	err := manager.Repair(context.Background(), nil)
+	ip, errAfterRepair := manager.AssignIP(context.Background())
+
+	// Assert
+	assert.Error(t, errBeforeRepair)
+	assert.NoError(t, err)
+	assert.NoError(t, errAfterRepair)
+	assert.Equal(t, "10.100.0.2", ip)
+}
+
+func TestEtcdIPManagerBind(t *testing.T) {
+	// Arrange
+	manager := newTestIPManager(t, newFakeIPAllocationRepository(), "10.244.0.0/24")
+
+	// Act
+	errFirstHost := manager.Bind(context.Background(), "host-1")
+	ip, _ := manager.AssignIP(context.Background())
+	errSecondHostWhileInUse := manager.Bind(context.Background(), "host-2")
+	errFirstHostAgain := manager.Bind(context.Background(), "host-1")
+	_ = manager.ReleaseIP(context.Background(), ip)
+	errSecondHostOnceFree := manager.Bind(context.Background(), "host-2")
+
+	// Assert
+	assert.NoError(t, errFirstHost)
+	var errBound *shared.ErrIPRangeBound
+	assert.ErrorAs(t, errSecondHostWhileInUse, &errBound)
+	assert.Equal(t, "host-1", errBound.Owner)
+	assert.NoError(t, errFirstHostAgain)
+	assert.NoError(t, errSecondHostOnceFree)
+}
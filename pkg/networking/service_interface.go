@@ -0,0 +1,71 @@
+package networking
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Dummy interface of the node the IPs of the services are added to by default
+const DefaultServiceInterface = "maden-services"
+
+// Makes the IPs of the services local to the node, so that the proxy can open their ports
+type serviceAddresses interface {
+	add(ip string) error
+	remove(ip string) error
+}
+
+// Dummy interface holding the IPs of the services. Traffic the pods send to a service IP leaves through their
+// gateway, i.e. the node, which delivers it to the proxy as the IP is its own. Managed with the ip command
+type dummyInterface struct {
+	name string
+
+	mutex   sync.Mutex
+	created bool
+}
+
+func (d *dummyInterface) add(ip string) error {
+	if err := d.ensureCreated(); err != nil {
+		return err
+	}
+	return runIP("addr", "replace", ip+"/32", "dev", d.name)
+}
+
+func (d *dummyInterface) remove(ip string) error {
+	return runIP("addr", "del", ip+"/32", "dev", d.name)
+}
+
+// Left in place when the proxy stops, so that the IPs of the services survive a restart of the node agent
+func (d *dummyInterface) ensureCreated() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.created {
+		return nil
+	}
+	if err := runIP("link", "show", d.name); err != nil {
+		if err := runIP("link", "add", d.name, "type", "dummy"); err != nil {
+			return err
+		}
+	}
+	if err := runIP("link", "set", d.name, "up"); err != nil {
+		return err
+	}
+	d.created = true
+	return nil
+}
+
+func runIP(args ...string) error {
+	output, err := exec.Command("ip", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ip %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// For nodes the IPs of the services are routed to otherwise
+type noServiceAddresses struct{}
+
+func (noServiceAddresses) add(string) error    { return nil }
+func (noServiceAddresses) remove(string) error { return nil }
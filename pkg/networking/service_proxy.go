@@ -0,0 +1,451 @@
+package networking
+
+import (
+	"maden/pkg/etcd"
+	"maden/pkg/shared"
+
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	proxyResyncInterval = 10 * time.Second // Retries the ports that failed to open
+	proxyRewatchDelay   = 5 * time.Second
+	backendDialTimeout  = 5 * time.Second
+	udpSessionTimeout   = time.Minute // UDP has no connections; a client is forgotten once its backend stops answering
+	maxDatagramSize     = 65535
+)
+
+type ServiceProxyConfig struct {
+	Interface string // Dummy interface the IPs of the services are added to, none when they are routed to the node otherwise
+}
+
+// Userspace proxy: opens every port of every service at the IP of the service and forwards what it receives
+// round-robin to the ready addresses of its endpoints. The backends follow the services and endpoints as they change
+type DefaultServiceProxy struct {
+	Config        ServiceProxyConfig
+	ServiceRepo   etcd.ServiceRepository
+	EndpointsRepo etcd.EndpointsRepository
+
+	mutex      sync.Mutex
+	addresses  serviceAddresses
+	serviceIPs map[string]bool      // Added to the node by the proxy
+	proxies    map[string]portProxy // By service port and the address it is opened on, see proxyKey
+}
+
+// Forwards the traffic of one service port
+type portProxy interface {
+	setBackends(endpoints []string)
+	close() error
+}
+
+func NewDefaultServiceProxy(
+	config ServiceProxyConfig,
+	serviceRepo etcd.ServiceRepository,
+	endpointsRepo etcd.EndpointsRepository,
+) ServiceProxy {
+	var addresses serviceAddresses = noServiceAddresses{}
+	if config.Interface != "" {
+		addresses = &dummyInterface{name: config.Interface}
+	}
+	return &DefaultServiceProxy{
+		Config:        config,
+		ServiceRepo:   serviceRepo,
+		EndpointsRepo: endpointsRepo,
+		addresses:     addresses,
+		serviceIPs:    make(map[string]bool),
+		proxies:       make(map[string]portProxy),
+	}
+}
+
+// The watches are opened anew whenever they end, the ports stay open in the meantime
+func (p *DefaultServiceProxy) Run(ctx context.Context) {
+	shared.Log.Infof("Proxying services...")
+	defer p.closeAll()
+
+	for {
+		p.watch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(proxyRewatchDelay):
+		}
+	}
+}
+
+// Syncs on every change of the services and endpoints until one of the watches ends, and periodically in between
+func (p *DefaultServiceProxy) watch(ctx context.Context) {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Watched before the first sync so that no change in between is missed
+	serviceEvents := p.ServiceRepo.WatchServices(watchCtx)
+	endpointsEvents := p.EndpointsRepo.WatchEndpoints(watchCtx)
+	p.sync(ctx)
+
+	ticker := time.NewTicker(proxyResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-serviceEvents:
+			if !ok || event.Type == shared.WatchEventError {
+				logWatchError(event.Err)
+				return
+			}
+			p.sync(ctx)
+		case event, ok := <-endpointsEvents:
+			if !ok || event.Type == shared.WatchEventError {
+				logWatchError(event.Err)
+				return
+			}
+			p.sync(ctx)
+		case <-ticker.C:
+			p.sync(ctx)
+		}
+	}
+}
+
+func logWatchError(err error) {
+	if err != nil {
+		shared.Log.Errorf("Proxy watch failed, watching anew: %v", err)
+	}
+}
+
+// Opens the ports of new services, closes those of deleted ones and hands the current endpoints to the others.
+// Ports that failed to open are tried again on the next sync
+func (p *DefaultServiceProxy) sync(ctx context.Context) {
+	services, err := p.ServiceRepo.ListServices(ctx, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to list services: %v", err)
+		return
+	}
+	endpointsList, err := p.EndpointsRepo.ListEndpoints(ctx, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to list endpoints: %v", err)
+		return
+	}
+	endpointsByService := make(map[string]shared.Endpoints)
+	for _, endpoints := range endpointsList {
+		endpointsByService[endpoints.Namespace+"/"+endpoints.Name] = endpoints
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	wanted := make(map[string]bool)
+	wantedIPs := make(map[string]bool)
+	for _, service := range services {
+		// Services without a selector have no pods to forward to
+		if len(service.Selector) == 0 || service.IP == "" {
+			continue
+		}
+		wantedIPs[service.IP] = true
+		if !p.serviceIPs[service.IP] {
+			if err := p.addresses.add(service.IP); err != nil {
+				shared.Log.Errorf("Failed to add IP %s of service %s: %v", service.IP, service.Name, err)
+				continue
+			}
+			p.serviceIPs[service.IP] = true
+		}
+		endpoints := endpointsByService[service.Namespace+"/"+service.Name]
+
+		for _, port := range service.Ports {
+			address := net.JoinHostPort(service.IP, strconv.Itoa(port.Port))
+			key := proxyKey(service, port, address)
+			proxy, ok := p.proxies[key]
+			if !ok {
+				if proxy, err = newPortProxy(serviceProtocol(port), address); err != nil {
+					shared.Log.Errorf("Failed to open port %d of service %s: %v", port.Port, service.Name, err)
+					continue
+				}
+				shared.Log.Infof("Proxying %s for service %s/%s", address, service.Namespace, service.Name)
+				p.proxies[key] = proxy
+			}
+
+			proxy.setBackends(backendAddresses(endpoints, port))
+			wanted[key] = true
+		}
+	}
+
+	for key, proxy := range p.proxies {
+		if wanted[key] {
+			continue
+		}
+		if err := proxy.close(); err != nil {
+			shared.Log.Errorf("Failed to close proxy %s: %v", key, err)
+		}
+		delete(p.proxies, key)
+	}
+	for ip := range p.serviceIPs {
+		if wantedIPs[ip] {
+			continue
+		}
+		if err := p.addresses.remove(ip); err != nil {
+			shared.Log.Errorf("Failed to remove service IP %s: %v", ip, err)
+			continue
+		}
+		delete(p.serviceIPs, ip)
+	}
+}
+
+func (p *DefaultServiceProxy) closeAll() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for key, proxy := range p.proxies {
+		_ = proxy.close()
+		delete(p.proxies, key)
+	}
+}
+
+func proxyKey(service shared.Service, port shared.ServicePort, address string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", service.Namespace, service.Name, serviceProtocol(port), address)
+}
+
+func serviceProtocol(port shared.ServicePort) string {
+	if port.Protocol == "" {
+		return "tcp"
+	}
+	return strings.ToLower(port.Protocol)
+}
+
+func newPortProxy(protocol string, address string) (portProxy, error) {
+	switch protocol {
+	case "tcp":
+		return newTCPProxy(address)
+	case "udp":
+		return newUDPProxy(address)
+	default:
+		return nil, fmt.Errorf("unsupported protocol %s", protocol)
+	}
+}
+
+// Ready addresses of the endpoints at the port receiving the traffic of the service port, e.g. "172.17.0.3:8080"
+func backendAddresses(endpoints shared.Endpoints, port shared.ServicePort) []string {
+	var backends []string
+	for _, endpointPort := range endpoints.Ports {
+		if endpointPort.ServicePort != port.Port || endpointPort.Protocol != serviceProtocol(port) {
+			continue
+		}
+		for _, address := range endpoints.Addresses {
+			backends = append(backends, net.JoinHostPort(address.IP, strconv.Itoa(endpointPort.Port)))
+		}
+	}
+	return backends
+}
+
+// Round-robin over the endpoints of a service port
+type backendPool struct {
+	mutex     sync.Mutex
+	endpoints []string
+	next      int
+}
+
+func (b *backendPool) setBackends(endpoints []string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.endpoints = endpoints
+}
+
+// Every endpoint, starting with the one whose turn it is; the others are the fallbacks should it not answer
+func (b *backendPool) candidates() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.endpoints) == 0 {
+		return nil
+	}
+	start := b.next % len(b.endpoints)
+	b.next = start + 1
+	return append(append([]string{}, b.endpoints[start:]...), b.endpoints[:start]...)
+}
+
+type tcpProxy struct {
+	backendPool
+	listener net.Listener
+}
+
+func newTCPProxy(address string) (*tcpProxy, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := &tcpProxy{listener: listener}
+	go proxy.serve()
+	return proxy, nil
+}
+
+func (p *tcpProxy) serve() {
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			shared.Log.Errorf("Failed to accept connection on %s: %v", p.listener.Addr(), err)
+			continue
+		}
+		go p.forward(client)
+	}
+}
+
+// Connections already forwarded are left to finish when the proxy closes
+func (p *tcpProxy) close() error {
+	return p.listener.Close()
+}
+
+func (p *tcpProxy) forward(client net.Conn) {
+	defer client.Close()
+
+	backend, err := p.dial()
+	if err != nil {
+		shared.Log.Errorf("Failed to forward connection on %s: %v", p.listener.Addr(), err)
+		return
+	}
+	defer backend.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		copyStream(backend, client)
+	}()
+	go func() {
+		defer wg.Done()
+		copyStream(client, backend)
+	}()
+	wg.Wait()
+}
+
+func (p *tcpProxy) dial() (net.Conn, error) {
+	endpoints := p.candidates()
+	if len(endpoints) == 0 {
+		return nil, errors.New("no ready endpoints")
+	}
+
+	var err error
+	for _, endpoint := range endpoints {
+		var conn net.Conn
+		if conn, err = net.DialTimeout("tcp", endpoint, backendDialTimeout); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// Passes on the end of the stream, so that the other direction can still finish
+func copyStream(dst net.Conn, src net.Conn) {
+	_, _ = io.Copy(dst, src)
+	if conn, ok := dst.(*net.TCPConn); ok {
+		_ = conn.CloseWrite()
+		return
+	}
+	_ = dst.Close()
+}
+
+type udpProxy struct {
+	backendPool
+	conn net.PacketConn
+
+	sessionMutex sync.Mutex
+	sessions     map[string]net.Conn // Connection to the backend of every client, by client address
+}
+
+func newUDPProxy(address string) (*udpProxy, error) {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := &udpProxy{conn: conn, sessions: make(map[string]net.Conn)}
+	go proxy.serve()
+	return proxy, nil
+}
+
+func (p *udpProxy) serve() {
+	buffer := make([]byte, maxDatagramSize)
+	for {
+		n, client, err := p.conn.ReadFrom(buffer)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			shared.Log.Errorf("Failed to read datagram on %s: %v", p.conn.LocalAddr(), err)
+			continue
+		}
+
+		backend, err := p.session(client)
+		if err != nil {
+			shared.Log.Errorf("Failed to forward datagram on %s: %v", p.conn.LocalAddr(), err)
+			continue
+		}
+		if _, err := backend.Write(buffer[:n]); err != nil {
+			shared.Log.Errorf("Failed to forward datagram to %s: %v", backend.RemoteAddr(), err)
+		}
+	}
+}
+
+// Every client sticks to one backend until the session times out, so that replies come from where requests went
+func (p *udpProxy) session(client net.Addr) (net.Conn, error) {
+	p.sessionMutex.Lock()
+	defer p.sessionMutex.Unlock()
+
+	if backend, ok := p.sessions[client.String()]; ok {
+		return backend, nil
+	}
+
+	endpoints := p.candidates()
+	if len(endpoints) == 0 {
+		return nil, errors.New("no ready endpoints")
+	}
+	backend, err := net.DialTimeout("udp", endpoints[0], backendDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	p.sessions[client.String()] = backend
+	go p.reply(client, backend)
+	return backend, nil
+}
+
+// Sends the answers of the backend back to the client until the backend stays quiet for too long
+func (p *udpProxy) reply(client net.Addr, backend net.Conn) {
+	defer func() {
+		p.sessionMutex.Lock()
+		delete(p.sessions, client.String())
+		p.sessionMutex.Unlock()
+		_ = backend.Close()
+	}()
+
+	buffer := make([]byte, maxDatagramSize)
+	for {
+		_ = backend.SetReadDeadline(time.Now().Add(udpSessionTimeout))
+		n, err := backend.Read(buffer)
+		if err != nil {
+			return
+		}
+		if _, err := p.conn.WriteTo(buffer[:n], client); err != nil {
+			return
+		}
+	}
+}
+
+func (p *udpProxy) close() error {
+	err := p.conn.Close()
+
+	p.sessionMutex.Lock()
+	defer p.sessionMutex.Unlock()
+	for _, backend := range p.sessions {
+		_ = backend.Close()
+	}
+	return err
+}
@@ -0,0 +1,281 @@
+package networking
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Port that was free a moment ago on the loopback address
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestBackendAddresses(t *testing.T) {
+	endpoints := shared.Endpoints{
+		Addresses: []shared.EndpointAddress{{IP: "172.17.0.2"}, {IP: "172.17.0.3"}},
+		Ports: []shared.EndpointPort{
+			{ServicePort: 80, Port: 8080, Protocol: "tcp"},
+			{ServicePort: 53, Port: 5353, Protocol: "udp"},
+		},
+	}
+
+	assert.Equal(t, []string{"172.17.0.2:8080", "172.17.0.3:8080"}, backendAddresses(endpoints, shared.ServicePort{Port: 80, TargetPort: 8080}))
+	assert.Equal(t, []string{"172.17.0.2:5353", "172.17.0.3:5353"}, backendAddresses(endpoints, shared.ServicePort{Port: 53, Protocol: "UDP"}))
+	assert.Empty(t, backendAddresses(endpoints, shared.ServicePort{Port: 53}))
+}
+
+func TestBackendPoolRoundRobin(t *testing.T) {
+	var pool backendPool
+	assert.Nil(t, pool.candidates())
+
+	pool.setBackends([]string{"a", "b", "c"})
+	assert.Equal(t, []string{"a", "b", "c"}, pool.candidates())
+	assert.Equal(t, []string{"b", "c", "a"}, pool.candidates())
+
+	pool.setBackends([]string{"a"})
+	assert.Equal(t, []string{"a"}, pool.candidates())
+}
+
+// Records the IPs of the services instead of adding them to an interface
+type fakeServiceAddresses struct {
+	ips map[string]bool
+}
+
+func (f *fakeServiceAddresses) add(ip string) error {
+	f.ips[ip] = true
+	return nil
+}
+
+func (f *fakeServiceAddresses) remove(ip string) error {
+	delete(f.ips, ip)
+	return nil
+}
+
+func TestDefaultServiceProxyForwardsTCP(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Both pods listen on the same port, at different loopback addresses
+	targetPort := freePort(t)
+	for _, ip := range []string{"127.0.0.1", "127.0.0.2"} {
+		backend, err := net.Listen("tcp", net.JoinHostPort(ip, strconv.Itoa(targetPort)))
+		require.NoError(t, err)
+		defer backend.Close()
+		go func(name string) {
+			for {
+				conn, err := backend.Accept()
+				if err != nil {
+					return
+				}
+				conn.Write([]byte(name + "\n"))
+				conn.Close()
+			}
+		}(ip)
+	}
+
+	servicePort := freePort(t)
+	service := shared.Service{
+		ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default"},
+		IP:         "127.0.0.1",
+		Selector:   map[string]string{"app": "web"},
+		Ports:      []shared.ServicePort{{Port: servicePort, TargetPort: targetPort}},
+	}
+	endpoints := shared.Endpoints{
+		ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default"},
+		Addresses:  []shared.EndpointAddress{{IP: "127.0.0.1"}, {IP: "127.0.0.2"}},
+		Ports:      []shared.EndpointPort{{ServicePort: servicePort, Port: targetPort, Protocol: "tcp"}},
+	}
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	proxy := NewDefaultServiceProxy(ServiceProxyConfig{}, mockServiceRepo, mockEndpointsRepo).(*DefaultServiceProxy)
+	gomock.InOrder(
+		mockServiceRepo.EXPECT().ListServices(gomock.Any(), "").Return([]shared.Service{service}, nil),
+		mockServiceRepo.EXPECT().ListServices(gomock.Any(), "").Return(nil, nil),
+	)
+	mockEndpointsRepo.EXPECT().ListEndpoints(gomock.Any(), "").Times(2).Return([]shared.Endpoints{endpoints}, nil)
+
+	// Act
+	proxy.sync(context.Background())
+	var answers []string
+	for i := 0; i < 2; i++ {
+		conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(servicePort)))
+		require.NoError(t, err)
+		answer, err := bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		conn.Close()
+		answers = append(answers, answer)
+	}
+	proxy.sync(context.Background())
+
+	// Assert
+	assert.ElementsMatch(t, []string{"127.0.0.1\n", "127.0.0.2\n"}, answers)
+	assert.Empty(t, proxy.proxies)
+	_, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(servicePort)))
+	assert.Error(t, err)
+}
+
+func TestDefaultServiceProxyForwardsUDP(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	backend, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer backend.Close()
+	go func() {
+		buffer := make([]byte, 1024)
+		for {
+			n, addr, err := backend.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			backend.WriteTo(append([]byte("echo "), buffer[:n]...), addr)
+		}
+	}()
+
+	servicePort := freePort(t)
+	targetPort := backend.LocalAddr().(*net.UDPAddr).Port
+	service := shared.Service{
+		ObjectMeta: shared.ObjectMeta{Name: "dns", Namespace: "default"},
+		IP:         "127.0.0.1",
+		Selector:   map[string]string{"app": "dns"},
+		Ports:      []shared.ServicePort{{Port: servicePort, TargetPort: targetPort, Protocol: "UDP"}},
+	}
+	endpoints := shared.Endpoints{
+		ObjectMeta: shared.ObjectMeta{Name: "dns", Namespace: "default"},
+		Addresses:  []shared.EndpointAddress{{IP: "127.0.0.1"}},
+		Ports:      []shared.EndpointPort{{ServicePort: servicePort, Port: targetPort, Protocol: "udp"}},
+	}
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	proxy := NewDefaultServiceProxy(ServiceProxyConfig{}, mockServiceRepo, mockEndpointsRepo).(*DefaultServiceProxy)
+	defer proxy.closeAll()
+	mockServiceRepo.EXPECT().ListServices(gomock.Any(), "").Return([]shared.Service{service}, nil)
+	mockEndpointsRepo.EXPECT().ListEndpoints(gomock.Any(), "").Return([]shared.Endpoints{endpoints}, nil)
+
+	// Act
+	proxy.sync(context.Background())
+	conn, err := net.Dial("udp", net.JoinHostPort("127.0.0.1", strconv.Itoa(servicePort)))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	buffer := make([]byte, 1024)
+	n, err := conn.Read(buffer)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "echo hello", string(buffer[:n]))
+}
+
+func TestDefaultServiceProxyOpensSamePortAtEveryServiceIP(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	servicePort := freePort(t)
+	newService := func(name string, ip string) shared.Service {
+		return shared.Service{
+			ObjectMeta: shared.ObjectMeta{Name: name, Namespace: "default"},
+			IP:         ip,
+			Selector:   map[string]string{"app": name},
+			Ports:      []shared.ServicePort{{Port: servicePort}},
+		}
+	}
+	web, api := newService("web", "127.0.0.3"), newService("api", "127.0.0.4")
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	proxy := NewDefaultServiceProxy(ServiceProxyConfig{}, mockServiceRepo, mockEndpointsRepo).(*DefaultServiceProxy)
+	addresses := &fakeServiceAddresses{ips: make(map[string]bool)}
+	proxy.addresses = addresses
+	defer proxy.closeAll()
+	gomock.InOrder(
+		mockServiceRepo.EXPECT().ListServices(gomock.Any(), "").Return([]shared.Service{web, api}, nil),
+		mockServiceRepo.EXPECT().ListServices(gomock.Any(), "").Return([]shared.Service{web}, nil),
+	)
+	mockEndpointsRepo.EXPECT().ListEndpoints(gomock.Any(), "").Times(2).Return(nil, nil)
+
+	// Act
+	proxy.sync(context.Background())
+	openedBoth := len(proxy.proxies)
+	ipsOfBoth := len(addresses.ips)
+	proxy.sync(context.Background())
+
+	// Assert
+	assert.Equal(t, 2, openedBoth)
+	assert.Equal(t, 2, ipsOfBoth)
+	assert.Len(t, proxy.proxies, 1)
+	assert.Equal(t, map[string]bool{"127.0.0.3": true}, addresses.ips)
+}
+
+func TestDefaultServiceProxyRetriesFailedPorts(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	servicePort := freePort(t)
+	service := shared.Service{
+		ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default"},
+		IP:         "127.0.0.5",
+		Selector:   map[string]string{"app": "web"},
+		Ports:      []shared.ServicePort{{Port: servicePort}},
+	}
+	occupied, err := net.Listen("tcp", net.JoinHostPort(service.IP, strconv.Itoa(servicePort)))
+	require.NoError(t, err)
+
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	proxy := NewDefaultServiceProxy(ServiceProxyConfig{}, mockServiceRepo, mockEndpointsRepo).(*DefaultServiceProxy)
+	defer proxy.closeAll()
+	mockServiceRepo.EXPECT().ListServices(gomock.Any(), "").Times(2).Return([]shared.Service{service}, nil)
+	mockEndpointsRepo.EXPECT().ListEndpoints(gomock.Any(), "").Times(2).Return(nil, nil)
+
+	// Act
+	proxy.sync(context.Background())
+	openedWhileOccupied := len(proxy.proxies)
+	occupied.Close()
+	proxy.sync(context.Background())
+
+	// Assert
+	assert.Equal(t, 0, openedWhileOccupied)
+	assert.Len(t, proxy.proxies, 1)
+}
+
+func TestDefaultServiceProxyWatchEndsWithItsWatches(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serviceEvents := make(chan shared.WatchEvent[shared.Service])
+	close(serviceEvents)
+	mockServiceRepo := mocks.NewMockServiceRepository(ctrl)
+	mockEndpointsRepo := mocks.NewMockEndpointsRepository(ctrl)
+	proxy := NewDefaultServiceProxy(ServiceProxyConfig{}, mockServiceRepo, mockEndpointsRepo).(*DefaultServiceProxy)
+	mockServiceRepo.EXPECT().WatchServices(gomock.Any()).Return(serviceEvents)
+	mockEndpointsRepo.EXPECT().WatchEndpoints(gomock.Any()).Return(make(chan shared.WatchEvent[shared.Endpoints]))
+	mockServiceRepo.EXPECT().ListServices(gomock.Any(), "").Return(nil, nil)
+	mockEndpointsRepo.EXPECT().ListEndpoints(gomock.Any(), "").Return(nil, nil)
+
+	// Act
+	proxy.watch(context.Background())
+
+	// Assert, Run opens the watches anew instead of closing the ports
+	assert.Empty(t, proxy.proxies)
+}
@@ -7,26 +7,30 @@ import (
 )
 
 type PodOrchestrator interface {
-	OrchestratePodCreation(pod *shared.Pod) error
-	OrchestratePodDeletion(pod *shared.Pod) error
-	GetPodLogs(ctx context.Context, podID string, containerID string, follow bool) (io.ReadCloser, error)
-	OrchestrateContainerCommandExecution(ctx context.Context, podID string, containerID string, cmd string) (string, error)
+	OrchestratePodCreation(ctx context.Context, pod *shared.Pod) error
+	OrchestratePodDeletion(ctx context.Context, pod *shared.Pod) error
+	GetPodLogs(ctx context.Context, namespace string, podID string, containerID string, follow bool) (io.ReadCloser, error)
+	OrchestrateContainerCommandExecution(ctx context.Context, namespace string, podID string, containerID string, cmd string) (string, error)
+	RunSchedulingLoop(ctx context.Context)
+	RunPodStatusLoop(ctx context.Context)
+	RepairPodIPs(ctx context.Context) error
 }
 
 type ServiceOrchestrator interface {
-	OrchestrateServiceCreation(serviceSpec shared.ServiceSpec) error
-	OrchestrateServiceUpdate(existingService shared.Service, serviceSpec shared.ServiceSpec) error
-	OrchestrateServiceDeletion(serviceName string) error
+	OrchestrateServiceCreation(ctx context.Context, serviceSpec shared.ServiceSpec) error
+	OrchestrateServiceUpdate(ctx context.Context, existingService shared.Service, serviceSpec shared.ServiceSpec) error
+	OrchestrateServiceDeletion(ctx context.Context, namespace string, serviceName string) error
+	RepairServiceIPs(ctx context.Context) error
 }
 
 type PersistentVolumeOrchestrator interface {
-	OrchestratePersistentVolumeCreation(volumeSpec *shared.PersistentVolumeSpec) error
-	OrchestratePersistentVolumeUpdate(existingVolume *shared.PersistentVolume, volumeSpec *shared.PersistentVolumeSpec) error
-	OrchestratePersistentVolumeDeletion(volumeName string) error
+	OrchestratePersistentVolumeCreation(ctx context.Context, volumeSpec *shared.PersistentVolumeSpec) error
+	OrchestratePersistentVolumeUpdate(ctx context.Context, existingVolume *shared.PersistentVolume, volumeSpec *shared.PersistentVolumeSpec) error
+	OrchestratePersistentVolumeDeletion(ctx context.Context, volumeName string) error
 }
 
 type PersistentVolumeClaimOrchestrator interface {
-	OrchestratePersistentVolumeClaimCreation(volumeClaimSpec *shared.PersistentVolumeClaimSpec) error
-	OrchestratePersistentVolumeClaimUpdate(existingVolumeClaim *shared.PersistentVolumeClaim, volumeClaimSpec *shared.PersistentVolumeClaimSpec) error
-	OrchestratePersistentVolumeClaimDeletion(volumeClaimName string) error
+	OrchestratePersistentVolumeClaimCreation(ctx context.Context, volumeClaimSpec *shared.PersistentVolumeClaimSpec) error
+	OrchestratePersistentVolumeClaimUpdate(ctx context.Context, existingVolumeClaim *shared.PersistentVolumeClaim, volumeClaimSpec *shared.PersistentVolumeClaimSpec) error
+	OrchestratePersistentVolumeClaimDeletion(ctx context.Context, namespace string, volumeClaimID string) error
 }
@@ -1,46 +1,83 @@
 package orchestrator
 
 import (
+	"context"
 	"maden/pkg/etcd"
 	"maden/pkg/shared"
 )
 
 type DefaultPersistentVolumeClaimOrchestrator struct {
-	Repo etcd.PersistentVolumeClaimRepository
+	Repo       etcd.PersistentVolumeClaimRepository
+	VolumeRepo etcd.PersistentVolumeRepository
 }
 
-func NewDefaultPersistentVolumeClaimOrchestrator(repo etcd.PersistentVolumeClaimRepository) PersistentVolumeClaimOrchestrator {
-	return &DefaultPersistentVolumeClaimOrchestrator{Repo: repo}
+func NewDefaultPersistentVolumeClaimOrchestrator(
+	repo etcd.PersistentVolumeClaimRepository,
+	volumeRepo etcd.PersistentVolumeRepository,
+) PersistentVolumeClaimOrchestrator {
+	return &DefaultPersistentVolumeClaimOrchestrator{Repo: repo, VolumeRepo: volumeRepo}
 }
 
-func (po *DefaultPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimCreation(volumeClaimSpec *shared.PersistentVolumeClaimSpec) error {
+func (po *DefaultPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimCreation(ctx context.Context, volumeClaimSpec *shared.PersistentVolumeClaimSpec) error {
 	var volumeClaimID = volumeClaimSpec.Name + shared.GenerateRandomString(10)
 
 	var volumeClaim = &shared.PersistentVolumeClaim{
 		ID: volumeClaimID,
-		Name: volumeClaimSpec.Name,
+		ObjectMeta: shared.ObjectMeta{Name: volumeClaimSpec.Name, Namespace: shared.NamespaceOrDefault(volumeClaimSpec.Namespace), Labels: volumeClaimSpec.Labels, Annotations: volumeClaimSpec.Annotations},
 		AccessModes: volumeClaimSpec.AccessModes,
 		Resources: volumeClaimSpec.Resources,
 		VolumeName: volumeClaimSpec.VolumeName,
 	};
 
-	return po.Repo.CreatePersistentVolumeClaim(volumeClaim)
-}
+	ownerReferences, err := po.volumeOwnerReferences(ctx, volumeClaimSpec.VolumeName)
+	if err != nil {
+		return err
+	}
+	volumeClaim.OwnerReferences = ownerReferences
 
-func (po *DefaultPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimUpdate(existingVolumeClaim *shared.PersistentVolumeClaim, volumeClaimSpec *shared.PersistentVolumeClaimSpec) error {
-	var volumeClaimID = volumeClaimSpec.Name + shared.GenerateRandomString(10)
+	return po.Repo.CreatePersistentVolumeClaim(ctx, volumeClaim)
+}
 
+func (po *DefaultPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimUpdate(ctx context.Context, existingVolumeClaim *shared.PersistentVolumeClaim, volumeClaimSpec *shared.PersistentVolumeClaimSpec) error {
 	var volumeClaim = &shared.PersistentVolumeClaim{
-		ID: volumeClaimID,
-		Name: volumeClaimSpec.Name,
+		ID: existingVolumeClaim.ID,
+		ObjectMeta: existingVolumeClaim.ObjectMeta,
+		ResourceVersion: existingVolumeClaim.ResourceVersion, // Rejects the update if the claim changed since it was read
 		AccessModes: volumeClaimSpec.AccessModes,
 		Resources: volumeClaimSpec.Resources,
 		VolumeName: volumeClaimSpec.VolumeName,
 	};
+	shared.UpdateMetadata(&volumeClaim.ObjectMeta, volumeClaimSpec.ObjectMeta)
+
+	ownerReferences, err := po.volumeOwnerReferences(ctx, volumeClaimSpec.VolumeName)
+	if err != nil {
+		return err
+	}
+	volumeClaim.OwnerReferences = ownerReferences
 
-	return po.Repo.UpdatePersistentVolumeClaim(volumeClaim)
+	return po.Repo.UpdatePersistentVolumeClaim(ctx, volumeClaim)
 }
 
-func (po *DefaultPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimDeletion(pvID string) error {
-	return po.Repo.DeletePersistentVolumeClaim(pvID)
+func (po *DefaultPersistentVolumeClaimOrchestrator) OrchestratePersistentVolumeClaimDeletion(ctx context.Context, namespace string, volumeClaimID string) error {
+	return po.Repo.DeletePersistentVolumeClaim(ctx, namespace, volumeClaimID)
+}
+
+// A claim bound to a volume is owned by it, so that deleting the volume takes the binding with it.
+// Claims naming a volume that does not exist yet are left without an owner
+func (po *DefaultPersistentVolumeClaimOrchestrator) volumeOwnerReferences(ctx context.Context, volumeName string) ([]shared.OwnerReference, error) {
+	if volumeName == "" {
+		return nil, nil
+	}
+
+	volumes, err := po.VolumeRepo.ListPersistentVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, volume := range volumes {
+		if volume.Name == volumeName {
+			return []shared.OwnerReference{shared.NewOwnerReference(shared.PersistentVolumeResource, volume.ObjectMeta, false)}, nil
+		}
+	}
+	return nil, nil
 }
@@ -0,0 +1,45 @@
+package orchestrator
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrchestratePersistentVolumeClaimUpdateConflict(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPersistentVolumeClaimRepository(ctrl)
+	mockVolumeRepo := mocks.NewMockPersistentVolumeRepository(ctrl)
+	orchestrator := NewDefaultPersistentVolumeClaimOrchestrator(mockRepo, mockVolumeRepo)
+
+	existingVolumeClaim := &shared.PersistentVolumeClaim{
+		ID:              "claim1",
+		ObjectMeta:      shared.ObjectMeta{Name: "data", Namespace: "default"},
+		ResourceVersion: 7,
+	}
+	volumeClaimSpec := &shared.PersistentVolumeClaimSpec{
+		ObjectMeta:  shared.ObjectMeta{Name: "data"},
+		AccessModes: []string{"ReadWriteOnce"},
+	}
+
+	// Changed by someone else since it was read at version 7
+	mockRepo.EXPECT().UpdatePersistentVolumeClaim(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, volumeClaim *shared.PersistentVolumeClaim) error {
+		assert.Equal(t, int64(7), volumeClaim.ResourceVersion)
+		return &shared.ErrConflict{ID: volumeClaim.ID, ResourceType: shared.PersistentVolumeClaimResource}
+	})
+
+	// Act
+	err := orchestrator.OrchestratePersistentVolumeClaimUpdate(context.Background(), existingVolumeClaim, volumeClaimSpec)
+
+	// Assert
+	var errConflict *shared.ErrConflict
+	assert.ErrorAs(t, err, &errConflict)
+}
@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"context"
 	"maden/pkg/etcd"
 	"maden/pkg/shared"
 )
@@ -13,12 +14,12 @@ func NewDefaultPersistentVolumeOrchestrator(repo etcd.PersistentVolumeRepository
 	return &DefaultPersistentVolumeOrchestrator{Repo: repo}
 }
 
-func (po *DefaultPersistentVolumeOrchestrator) OrchestratePersistentVolumeCreation(volumeSpec *shared.PersistentVolumeSpec) error {
+func (po *DefaultPersistentVolumeOrchestrator) OrchestratePersistentVolumeCreation(ctx context.Context, volumeSpec *shared.PersistentVolumeSpec) error {
 	var volumeID = volumeSpec.Name + shared.GenerateRandomString(10)
 
 	var volume = &shared.PersistentVolume{
 		ID:                            volumeID,
-		Name:                          volumeSpec.Name,
+		ObjectMeta:                    shared.ObjectMeta{Name: volumeSpec.Name, Labels: volumeSpec.Labels, Annotations: volumeSpec.Annotations},
 		Capacity:                      volumeSpec.Capacity,
 		AccessModes:                   volumeSpec.AccessModes,
 		PersistentVolumeReclaimPolicy: volumeSpec.PersistentVolumeReclaimPolicy,
@@ -26,25 +27,25 @@ func (po *DefaultPersistentVolumeOrchestrator) OrchestratePersistentVolumeCreati
 		MountOptions:                  volumeSpec.MountOptions,
 	}
 
-	return po.Repo.CreatePersistentVolume(volume)
+	return po.Repo.CreatePersistentVolume(ctx, volume)
 }
 
-func (po *DefaultPersistentVolumeOrchestrator) OrchestratePersistentVolumeUpdate(existingVolume *shared.PersistentVolume, volumeSpec *shared.PersistentVolumeSpec) error {
-	var volumeID = volumeSpec.Name + shared.GenerateRandomString(10)
-
+func (po *DefaultPersistentVolumeOrchestrator) OrchestratePersistentVolumeUpdate(ctx context.Context, existingVolume *shared.PersistentVolume, volumeSpec *shared.PersistentVolumeSpec) error {
 	var volume = &shared.PersistentVolume{
-		ID:                            volumeID,
-		Name:                          volumeSpec.Name,
+		ID:                            existingVolume.ID,
+		ObjectMeta:                    existingVolume.ObjectMeta,
+		ResourceVersion:               existingVolume.ResourceVersion, // Rejects the update if the volume changed since it was read
 		Capacity:                      volumeSpec.Capacity,
 		AccessModes:                   volumeSpec.AccessModes,
 		PersistentVolumeReclaimPolicy: volumeSpec.PersistentVolumeReclaimPolicy,
 		StorageClassName:              volumeSpec.StorageClassName,
 		MountOptions:                  volumeSpec.MountOptions,
 	}
+	shared.UpdateMetadata(&volume.ObjectMeta, volumeSpec.ObjectMeta)
 
-	return po.Repo.UpdatePersistentVolume(volume)
+	return po.Repo.UpdatePersistentVolume(ctx, volume)
 }
 
-func (po *DefaultPersistentVolumeOrchestrator) OrchestratePersistentVolumeDeletion(pvID string) error {
-	return po.Repo.DeletePersistentVolume(pvID)
+func (po *DefaultPersistentVolumeOrchestrator) OrchestratePersistentVolumeDeletion(ctx context.Context, pvID string) error {
+	return po.Repo.DeletePersistentVolume(ctx, pvID)
 }
@@ -0,0 +1,44 @@
+package orchestrator
+
+import (
+	"maden/pkg/mocks"
+	"maden/pkg/shared"
+
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrchestratePersistentVolumeUpdateConflict(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPersistentVolumeRepository(ctrl)
+	orchestrator := NewDefaultPersistentVolumeOrchestrator(mockRepo)
+
+	existingVolume := &shared.PersistentVolume{
+		ID:              "volume1",
+		ObjectMeta:      shared.ObjectMeta{Name: "data"},
+		ResourceVersion: 4,
+	}
+	volumeSpec := &shared.PersistentVolumeSpec{
+		ObjectMeta: shared.ObjectMeta{Name: "data"},
+		Capacity:   map[string]string{"storage": "1Gi"},
+	}
+
+	// Changed by someone else since it was read at version 4
+	mockRepo.EXPECT().UpdatePersistentVolume(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, volume *shared.PersistentVolume) error {
+		assert.Equal(t, int64(4), volume.ResourceVersion)
+		return &shared.ErrConflict{ID: volume.ID, ResourceType: shared.PersistentVolumeResource}
+	})
+
+	// Act
+	err := orchestrator.OrchestratePersistentVolumeUpdate(context.Background(), existingVolume, volumeSpec)
+
+	// Assert
+	var errConflict *shared.ErrConflict
+	assert.ErrorAs(t, err, &errConflict)
+}
@@ -7,54 +7,258 @@ import (
 	"maden/pkg/shared" 
 
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 )
 
 // Orchestrator of pod-related components
 type DefaultPodOrchestrator struct {
 	Repo etcd.PodRepository
+	NodeRepo etcd.NodeRepository
 	Scheduler scheduler.Scheduler
+	SchedulingQueue scheduler.SchedulingQueue
 	PodManager madelet.PodManager
+	Network madelet.PodNetwork
+
+	spawn func(run func()) // Runs pods in the background
 }
 
 func NewDefaultPodOrchestrator(
 	repo etcd.PodRepository,
+	nodeRepo etcd.NodeRepository,
 	scheduler scheduler.Scheduler,
+	schedulingQueue scheduler.SchedulingQueue,
 	podManager madelet.PodManager,
+	network madelet.PodNetwork,
 ) PodOrchestrator {
-	return &DefaultPodOrchestrator{Repo: repo, Scheduler: scheduler, PodManager: podManager}
+	return &DefaultPodOrchestrator{
+		Repo: repo,
+		NodeRepo: nodeRepo,
+		Scheduler: scheduler,
+		SchedulingQueue: schedulingQueue,
+		PodManager: podManager,
+		Network: network,
+		spawn: func(run func()) { go run() },
+	}
 }
 
-func (po *DefaultPodOrchestrator) OrchestratePodCreation(pod *shared.Pod) error {
+func (po *DefaultPodOrchestrator) OrchestratePodCreation(ctx context.Context, pod *shared.Pod) error {
+	pod.Namespace = shared.NamespaceOrDefault(pod.Namespace)
+
 	err := po.Scheduler.SchedulePod(pod)
 	if err != nil {
 		return err
 	}
 
-	if err := po.Repo.CreatePod(pod); err != nil {
+	if err := po.Repo.CreatePod(ctx, pod); err != nil {
+		po.releaseReservation(ctx, pod)
 		return err
 	}
 
-	go po.PodManager.RunPod(pod)
+	if pod.Status.Phase == shared.PodPending {
+		shared.Log.Infof("Pod %s does not fit onto any node, queueing it for retry", pod.ID)
+		po.SchedulingQueue.AddUnschedulable(getPodQueueKey(pod))
+		return nil
+	}
+
+	po.startPod(ctx, pod)
 
 	return nil
 }
 
-func (po *DefaultPodOrchestrator) OrchestratePodDeletion(pod *shared.Pod) error {
-	if err := po.PodManager.StopPod(pod); err != nil {
+func (po *DefaultPodOrchestrator) startPod(ctx context.Context, pod *shared.Pod) {
+	// The agent of the node picks the pod up from etcd on its own
+	if po.isAgentManaged(ctx, pod.NodeID) {
+		return
+	}
+
+	po.spawn(func() { po.PodManager.RunPod(pod) })
+}
+
+// Retries pods that did not fit onto any node until they do, or until ctx is done
+func (po *DefaultPodOrchestrator) RunSchedulingLoop(ctx context.Context) {
+	shared.Log.Infof("Starting pod scheduling loop...")
+
+	for {
+		podKey, ok := po.SchedulingQueue.Pop(ctx)
+		if !ok {
+			return
+		}
+
+		if err := po.retryPodScheduling(ctx, podKey); err != nil {
+			shared.Log.Errorf("Failed to schedule pod %s: %v", podKey, err)
+			po.SchedulingQueue.AddUnschedulable(podKey)
+		}
+	}
+}
+
+// Pods on nodes without an agent are run by this process, so it also keeps their status up to date and restarts
+// their crashed containers, as the agent does for the pods of its node
+func (po *DefaultPodOrchestrator) RunPodStatusLoop(ctx context.Context) {
+	ticker := time.NewTicker(madelet.DefaultPodStatusInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			po.syncPodStatuses(ctx)
+		}
+	}
+}
+
+func (po *DefaultPodOrchestrator) syncPodStatuses(ctx context.Context) {
+	pods, err := po.Repo.ListPods(ctx, "")
+	if err != nil {
+		shared.Log.Errorf("Failed to list pods: %v", err)
+		return
+	}
+	nodes, err := po.NodeRepo.ListNodes(ctx)
+	if err != nil {
+		shared.Log.Errorf("Failed to list nodes: %v", err)
+		return
+	}
+
+	agentManaged := make(map[string]bool)
+	for _, node := range nodes {
+		agentManaged[node.ID] = node.AgentManaged
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != shared.PodRunning || agentManaged[pod.NodeID] {
+			continue
+		}
+		if err := po.PodManager.SyncPodStatus(&pod); err != nil {
+			shared.Log.Errorf("Failed to sync status of pod %s: %v", pod.ID, err)
+		}
+	}
+}
+
+// Brings the allocations of the pod range in line with the IPs of the pods stored in etcd, releasing those leaked
+// by crashed nodes and taking over a changed pod CIDR. Pods whose sandbox is being created right now may not have
+// stored their IP yet, which is why this only runs when the API server starts
+func (po *DefaultPodOrchestrator) RepairPodIPs(ctx context.Context) error {
+	if po.Network.IPManager == nil {
+		return nil
+	}
+
+	pods, err := po.Repo.ListPods(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var inUse []string
+	for _, pod := range pods {
+		if pod.Status.PodIP != "" && po.Network.IPManager.Contains(pod.Status.PodIP) {
+			inUse = append(inUse, pod.Status.PodIP)
+		}
+	}
+	return po.Network.IPManager.Repair(ctx, inUse)
+}
+
+func (po *DefaultPodOrchestrator) retryPodScheduling(ctx context.Context, podKey string) error {
+	namespace, podID := splitPodQueueKey(podKey)
+	pod, err := po.Repo.GetPodByID(ctx, namespace, podID)
+	if err != nil {
+		var errNotFound *shared.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			po.SchedulingQueue.Remove(podKey) // Deleted in the meantime
+			return nil
+		}
+		return err
+	}
+	if pod.Status.Phase != shared.PodPending || pod.NodeID != "" {
+		po.SchedulingQueue.Remove(podKey)
+		return nil
+	}
+
+	if err := po.Scheduler.SchedulePod(pod); err != nil {
 		return err
 	}
 
-	if err := po.Repo.DeletePod(pod.ID); err != nil {
+	// Also records why the pod still does not fit, if it does not
+	if err := po.Repo.UpdatePod(ctx, pod); err != nil {
+		po.releaseReservation(ctx, pod)
 		return err
 	}
 
+	if pod.Status.Phase == shared.PodPending {
+		po.SchedulingQueue.AddUnschedulable(podKey)
+		return nil
+	}
+
+	shared.Log.Infof("Pod %s scheduled onto node %s after retry", pod.ID, pod.NodeID)
+	po.SchedulingQueue.Remove(podKey)
+	po.startPod(ctx, pod)
 	return nil
 }
 
-func (po *DefaultPodOrchestrator) GetPodLogs(ctx context.Context, podID string, containerID string, follow bool) (io.ReadCloser, error) {
-	pod, err := po.Repo.GetPodByID(podID)
+func (po *DefaultPodOrchestrator) OrchestratePodDeletion(ctx context.Context, pod *shared.Pod) error {
+	po.SchedulingQueue.Remove(getPodQueueKey(pod))
+
+	// The agent of the node stops the containers once the pod is gone from etcd, the IP is given back here as
+	// the agent may be down for good, e.g. when the pod is evicted from an offline node
+	agentManaged := po.isAgentManaged(ctx, pod.NodeID)
+	if !agentManaged {
+		if err := po.PodManager.StopPod(pod); err != nil {
+			return err
+		}
+	}
+
+	if err := po.Repo.DeletePod(ctx, pod.Namespace, pod.ID); err != nil {
+		return err
+	}
+
+	if agentManaged {
+		po.releasePodIP(ctx, pod)
+	}
+	return po.releasePodResources(ctx, pod)
+}
+
+// Pods that got their IP from Docker, or from a former pod CIDR, have nothing to release
+func (po *DefaultPodOrchestrator) releasePodIP(ctx context.Context, pod *shared.Pod) {
+	if po.Network.IPManager == nil || !po.Network.IPManager.Contains(pod.Status.PodIP) {
+		return
+	}
+	if err := po.Network.IPManager.ReleaseIP(ctx, pod.Status.PodIP); err != nil {
+		shared.Log.Errorf("Failed to release IP %s of pod %s: %v", pod.Status.PodIP, pod.ID, err)
+	}
+}
+
+// The scheduler reserves the resources of the pod on its node before the pod is stored, storing it failed
+func (po *DefaultPodOrchestrator) releaseReservation(ctx context.Context, pod *shared.Pod) {
+	if err := po.releasePodResources(ctx, pod); err != nil {
+		shared.Log.Errorf("Failed to release resources of pod %s on node %s: %v", pod.ID, pod.NodeID, err)
+	}
+}
+
+// Gives the resources reserved by the scheduler back to the node of the pod
+func (po *DefaultPodOrchestrator) releasePodResources(ctx context.Context, pod *shared.Pod) error {
+	if pod.NodeID == "" {
+		return nil
+	}
+
+	return shared.RetryOnConflict(func() error {
+		node, err := po.NodeRepo.GetNodeByID(ctx, pod.NodeID)
+		if err != nil {
+			var errNotFound *shared.ErrNotFound
+			if errors.As(err, &errNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		node.Used.CPU = max(node.Used.CPU-pod.Resources.CPU, 0)
+		node.Used.Memory = max(node.Used.Memory-pod.Resources.Memory, 0)
+		return po.NodeRepo.UpdateNode(ctx, node)
+	})
+}
+
+func (po *DefaultPodOrchestrator) GetPodLogs(ctx context.Context, namespace string, podID string, containerID string, follow bool) (io.ReadCloser, error) {
+	pod, err := po.Repo.GetPodByID(ctx, namespace, podID)
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +271,8 @@ func (po *DefaultPodOrchestrator) GetPodLogs(ctx context.Context, podID string,
 	return po.PodManager.GetContainerLogs(ctx, actualContainerID, follow)
 }
 
-func (po *DefaultPodOrchestrator) OrchestrateContainerCommandExecution(ctx context.Context, podID string, containerID string, cmd string) (string, error) {
-	pod, err := po.Repo.GetPodByID(podID)
+func (po *DefaultPodOrchestrator) OrchestrateContainerCommandExecution(ctx context.Context, namespace string, podID string, containerID string, cmd string) (string, error) {
+	pod, err := po.Repo.GetPodByID(ctx, namespace, podID)
 	if err != nil {
 		return "", err
 	}
@@ -81,6 +285,32 @@ func (po *DefaultPodOrchestrator) OrchestrateContainerCommandExecution(ctx conte
 	return po.PodManager.ExecuteCommandInContainer(ctx, actualContainerID, cmd)
 }
 
+// Pods are queued under their namespace and ID
+func getPodQueueKey(pod *shared.Pod) string {
+	return etcd.ObjectKey(pod.Namespace, pod.ID)
+}
+
+func splitPodQueueKey(podKey string) (string, string) {
+	namespace, podID, found := strings.Cut(podKey, "/")
+	if !found {
+		return "", podKey
+	}
+	return namespace, podID
+}
+
+func (po *DefaultPodOrchestrator) isAgentManaged(ctx context.Context, nodeID string) bool {
+	if nodeID == "" {
+		return false
+	}
+
+	node, err := po.NodeRepo.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		shared.Log.Errorf("Failed to get node %s: %v", nodeID, err)
+		return false
+	}
+	return node.AgentManaged
+}
+
 func determineContainerID(pod *shared.Pod, containerID string) (string, error) {
 	if len(pod.Containers) > 1 {
 		if containerID == "" {
@@ -1,7 +1,9 @@
 package orchestrator
 
 import (
+	"context"
 	"errors"
+	"maden/pkg/madelet"
 	"maden/pkg/mocks"
 	"maden/pkg/shared"
 	"testing"
@@ -20,17 +22,21 @@ func TestOrchestratePodCreation(t *testing.T) {
     mockScheduler := mocks.NewMockScheduler(ctrl)
 
     mockPodManager := mocks.NewMockPodManager(ctrl)
-    orchestrator := NewDefaultPodOrchestrator(mockRepo, mockScheduler, mockPodManager)
+    orchestrator := NewDefaultPodOrchestrator(mockRepo, nil, mockScheduler, nil, mockPodManager, madelet.PodNetwork{}).(*DefaultPodOrchestrator)
+    orchestrator.spawn = func(run func()) { run() }
 	
-    pod := &shared.Pod{ID: "pod1", Name: "test-pod"}
+    pod := &shared.Pod{ID: "pod1", ObjectMeta: shared.ObjectMeta{Name: "test-pod"}}
 
     // Success scenario
-    mockScheduler.EXPECT().SchedulePod(pod).Return(nil)
-    mockRepo.EXPECT().CreatePod(pod).Return(nil)
+    mockScheduler.EXPECT().SchedulePod(pod).DoAndReturn(func(pod *shared.Pod) error {
+        pod.Status.Phase = shared.PodScheduled
+        return nil
+    })
+    mockRepo.EXPECT().CreatePod(gomock.Any(), pod).Return(nil)
     mockPodManager.EXPECT().RunPod(gomock.Any()).Times(1)
 
 	// Act
-    err := orchestrator.OrchestratePodCreation(pod)
+    err := orchestrator.OrchestratePodCreation(context.Background(), pod)
 
 	// Assert
     assert.NoError(t, err)
@@ -39,17 +45,17 @@ func TestOrchestratePodCreation(t *testing.T) {
     mockScheduler.EXPECT().SchedulePod(pod).Return(errors.New("scheduling failed"))
     
 	// Act
-	err = orchestrator.OrchestratePodCreation(pod)
+	err = orchestrator.OrchestratePodCreation(context.Background(), pod)
     
 	// Assert
 	assert.Error(t, err)
 
     // Error in creating pod
     mockScheduler.EXPECT().SchedulePod(pod).Return(nil)
-    mockRepo.EXPECT().CreatePod(pod).Return(errors.New("creation failed"))
+    mockRepo.EXPECT().CreatePod(gomock.Any(), pod).Return(errors.New("creation failed"))
     
 	// Act
-	err = orchestrator.OrchestratePodCreation(pod)
+	err = orchestrator.OrchestratePodCreation(context.Background(), pod)
     
 	// Assert
 	assert.Error(t, err)
@@ -61,16 +67,18 @@ func TestOrchestratePodDeletion(t *testing.T) {
 
     mockRepo := mocks.NewMockPodRepository(ctrl)
     mockPodManager := mocks.NewMockPodManager(ctrl)
-    orchestrator := NewDefaultPodOrchestrator(mockRepo, nil, mockPodManager)
+    mockQueue := mocks.NewMockSchedulingQueue(ctrl)
+    orchestrator := NewDefaultPodOrchestrator(mockRepo, nil, nil, mockQueue, mockPodManager, madelet.PodNetwork{})
+    mockQueue.EXPECT().Remove(gomock.Any()).AnyTimes()
 
     pod := &shared.Pod{ID: "pod1"}
 
     // Success scenario
     mockPodManager.EXPECT().StopPod(pod).Return(nil)
-    mockRepo.EXPECT().DeletePod(pod.ID).Return(nil)
+    mockRepo.EXPECT().DeletePod(gomock.Any(), pod.Namespace, pod.ID).Return(nil)
 
 	// Act
-    err := orchestrator.OrchestratePodDeletion(pod)
+    err := orchestrator.OrchestratePodDeletion(context.Background(), pod)
 
 	// Assert
     assert.NoError(t, err)
@@ -79,18 +87,233 @@ func TestOrchestratePodDeletion(t *testing.T) {
     mockPodManager.EXPECT().StopPod(pod).Return(errors.New("stopping failed"))
     
 	// Act
-	err = orchestrator.OrchestratePodDeletion(pod)
+	err = orchestrator.OrchestratePodDeletion(context.Background(), pod)
 
 	// Assert
     assert.Error(t, err)
 
     // Error in deleting pod
     mockPodManager.EXPECT().StopPod(pod).Return(nil)
-    mockRepo.EXPECT().DeletePod(pod.ID).Return(errors.New("deletion failed"))
+    mockRepo.EXPECT().DeletePod(gomock.Any(), pod.Namespace, pod.ID).Return(errors.New("deletion failed"))
     
 	// Act
-	err = orchestrator.OrchestratePodDeletion(pod)
+	err = orchestrator.OrchestratePodDeletion(context.Background(), pod)
 
 	// Assert
     assert.Error(t, err)
 }
+
+func TestOrchestratePodOnAgentManagedNode(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockNodeRepo := mocks.NewMockNodeRepository(ctrl)
+	mockScheduler := mocks.NewMockScheduler(ctrl)
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	mockQueue := mocks.NewMockSchedulingQueue(ctrl)
+	orchestrator := NewDefaultPodOrchestrator(mockRepo, mockNodeRepo, mockScheduler, mockQueue, mockPodManager, madelet.PodNetwork{})
+
+	pod := &shared.Pod{ID: "pod1", ObjectMeta: shared.ObjectMeta{Name: "test-pod"}}
+
+	mockScheduler.EXPECT().SchedulePod(pod).DoAndReturn(func(pod *shared.Pod) error {
+		pod.NodeID = "node1"
+		pod.Status.Phase = shared.PodScheduled
+		return nil
+	})
+	mockQueue.EXPECT().Remove("default/pod1")
+	mockRepo.EXPECT().CreatePod(gomock.Any(), pod).Return(nil)
+	mockRepo.EXPECT().DeletePod(gomock.Any(), "default", pod.ID).Return(nil)
+	mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node1").Return(&shared.Node{ID: "node1", AgentManaged: true}, nil).Times(3)
+	mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Return(nil)
+	mockPodManager.EXPECT().RunPod(gomock.Any()).Times(0)
+	mockPodManager.EXPECT().StopPod(gomock.Any()).Times(0)
+
+	// Act
+	errCreate := orchestrator.OrchestratePodCreation(context.Background(), pod)
+	errDelete := orchestrator.OrchestratePodDeletion(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, errCreate)
+	assert.NoError(t, errDelete)
+}
+
+func TestOrchestratePodDeletionReleasesNodeResources(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockNodeRepo := mocks.NewMockNodeRepository(ctrl)
+	mockQueue := mocks.NewMockSchedulingQueue(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+	orchestrator := NewDefaultPodOrchestrator(mockRepo, mockNodeRepo, nil, mockQueue, nil, madelet.PodNetwork{IPManager: mockIPManager})
+
+	pod := &shared.Pod{ID: "pod1", ObjectMeta: shared.ObjectMeta{Namespace: "default"}, NodeID: "node1", Resources: shared.Resources{CPU: 2, Memory: 512}}
+	pod.Status.PodIP = "10.244.0.2"
+	node := shared.Node{ID: "node1", AgentManaged: true, Used: shared.Resources{CPU: 3, Memory: 768}}
+
+	mockQueue.EXPECT().Remove("default/pod1")
+	mockRepo.EXPECT().DeletePod(gomock.Any(), "default", "pod1").Return(nil)
+	mockIPManager.EXPECT().Contains("10.244.0.2").Return(true)
+	mockIPManager.EXPECT().ReleaseIP(gomock.Any(), "10.244.0.2").Return(nil) // The agent of the node does not release it
+	mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node1").DoAndReturn(func(_ context.Context, nodeID string) (*shared.Node, error) {
+		nodeCopy := node
+		return &nodeCopy, nil
+	}).Times(3)
+	gomock.InOrder(
+		mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Return(&shared.ErrConflict{ID: "node1", ResourceType: shared.NodeResource}),
+		mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+			assert.Equal(t, shared.Resources{CPU: 1, Memory: 256}, node.Used)
+			return nil
+		}),
+	)
+
+	// Act
+	err := orchestrator.OrchestratePodDeletion(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestOrchestratePodCreationQueuesUnschedulablePod(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockScheduler := mocks.NewMockScheduler(ctrl)
+	mockQueue := mocks.NewMockSchedulingQueue(ctrl)
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	orchestrator := NewDefaultPodOrchestrator(mockRepo, nil, mockScheduler, mockQueue, mockPodManager, madelet.PodNetwork{})
+
+	pod := &shared.Pod{ID: "pod1", ObjectMeta: shared.ObjectMeta{Name: "test-pod"}}
+
+	mockScheduler.EXPECT().SchedulePod(pod).Return(nil) // Leaves the pod pending
+	mockRepo.EXPECT().CreatePod(gomock.Any(), pod).Return(nil)
+	mockQueue.EXPECT().AddUnschedulable("default/pod1")
+	mockPodManager.EXPECT().RunPod(gomock.Any()).Times(0)
+
+	// Act
+	err := orchestrator.OrchestratePodCreation(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestOrchestratePodCreationReleasesReservationWhenStoringFails(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockNodeRepo := mocks.NewMockNodeRepository(ctrl)
+	mockScheduler := mocks.NewMockScheduler(ctrl)
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	orchestrator := NewDefaultPodOrchestrator(mockRepo, mockNodeRepo, mockScheduler, nil, mockPodManager, madelet.PodNetwork{})
+
+	pod := &shared.Pod{ID: "pod1", ObjectMeta: shared.ObjectMeta{Name: "test-pod"}, Resources: shared.Resources{CPU: 2, Memory: 512}}
+
+	mockScheduler.EXPECT().SchedulePod(pod).DoAndReturn(func(pod *shared.Pod) error {
+		pod.NodeID = "node1"
+		pod.Status.Phase = shared.PodScheduled
+		return nil
+	})
+	mockRepo.EXPECT().CreatePod(gomock.Any(), pod).Return(errors.New("creation failed"))
+	mockNodeRepo.EXPECT().GetNodeByID(gomock.Any(), "node1").Return(&shared.Node{ID: "node1", Used: shared.Resources{CPU: 3, Memory: 768}}, nil)
+	mockNodeRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+		assert.Equal(t, shared.Resources{CPU: 1, Memory: 256}, node.Used)
+		return nil
+	})
+	mockPodManager.EXPECT().RunPod(gomock.Any()).Times(0)
+
+	// Act
+	err := orchestrator.OrchestratePodCreation(context.Background(), pod)
+
+	// Assert
+	assert.EqualError(t, err, "creation failed")
+}
+
+func TestRetryPodScheduling(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockScheduler := mocks.NewMockScheduler(ctrl)
+	mockQueue := mocks.NewMockSchedulingQueue(ctrl)
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	orchestrator := NewDefaultPodOrchestrator(mockRepo, nil, mockScheduler, mockQueue, mockPodManager, madelet.PodNetwork{}).(*DefaultPodOrchestrator)
+
+	// Still does not fit
+	mockRepo.EXPECT().GetPodByID(gomock.Any(), "default", "pending-pod").Return(&shared.Pod{ID: "pending-pod", ObjectMeta: shared.ObjectMeta{Namespace: "default"}}, nil)
+	mockScheduler.EXPECT().SchedulePod(gomock.Any()).Return(nil)
+	mockRepo.EXPECT().UpdatePod(gomock.Any(), gomock.Any()).Return(nil)
+	mockQueue.EXPECT().AddUnschedulable("default/pending-pod")
+
+	// Deleted in the meantime
+	mockRepo.EXPECT().GetPodByID(gomock.Any(), "default", "deleted-pod").Return(nil, &shared.ErrNotFound{})
+	mockQueue.EXPECT().Remove("default/deleted-pod")
+
+	// Act
+	errPending := orchestrator.retryPodScheduling(context.Background(), "default/pending-pod")
+	errDeleted := orchestrator.retryPodScheduling(context.Background(), "default/deleted-pod")
+
+	// Assert
+	assert.NoError(t, errPending)
+	assert.NoError(t, errDeleted)
+}
+
+func TestSyncPodStatusesSkipsAgentManagedNodes(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockNodeRepo := mocks.NewMockNodeRepository(ctrl)
+	mockPodManager := mocks.NewMockPodManager(ctrl)
+	orchestrator := NewDefaultPodOrchestrator(mockRepo, mockNodeRepo, nil, nil, mockPodManager, madelet.PodNetwork{}).(*DefaultPodOrchestrator)
+
+	mockRepo.EXPECT().ListPods(gomock.Any(), "").Return([]shared.Pod{
+		{ID: "local-pod", NodeID: "local-node", Status: shared.PodStatus{Phase: shared.PodRunning}},
+		{ID: "agent-pod", NodeID: "agent-node", Status: shared.PodStatus{Phase: shared.PodRunning}},
+		{ID: "failed-pod", NodeID: "local-node", Status: shared.PodStatus{Phase: shared.PodFailed}},
+	}, nil)
+	mockNodeRepo.EXPECT().ListNodes(gomock.Any()).Return([]shared.Node{
+		{ID: "local-node"},
+		{ID: "agent-node", AgentManaged: true},
+	}, nil)
+	mockPodManager.EXPECT().SyncPodStatus(gomock.Any()).DoAndReturn(func(pod *shared.Pod) error {
+		assert.Equal(t, "local-pod", pod.ID)
+		return nil
+	})
+
+	// Act
+	orchestrator.syncPodStatuses(context.Background())
+}
+
+func TestRepairPodIPs(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPodRepository(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+	orchestrator := NewDefaultPodOrchestrator(mockRepo, nil, nil, nil, nil, madelet.PodNetwork{IPManager: mockIPManager})
+
+	mockRepo.EXPECT().ListPods(gomock.Any(), "").Return([]shared.Pod{
+		{ID: "running-pod", Status: shared.PodStatus{PodIP: "10.244.0.2"}},
+		{ID: "pending-pod"},
+		{ID: "old-range-pod", Status: shared.PodStatus{PodIP: "10.1.0.2"}},
+	}, nil)
+	mockIPManager.EXPECT().Contains("10.244.0.2").Return(true)
+	mockIPManager.EXPECT().Contains("10.1.0.2").Return(false)
+	mockIPManager.EXPECT().Repair(gomock.Any(), []string{"10.244.0.2"}).Return(nil)
+
+	// Act
+	err := orchestrator.RepairPodIPs(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
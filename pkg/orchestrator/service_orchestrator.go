@@ -1,9 +1,12 @@
 package orchestrator
 
 import (
+	"context"
+	"fmt"
 	"maden/pkg/etcd"
 	"maden/pkg/networking"
 	"maden/pkg/shared"
+	"sort"
 )
 
 type DefaultServiceOrchestrator struct {
@@ -20,20 +23,30 @@ func NewDefaultServiceOrchestrator(
 	return &DefaultServiceOrchestrator{Repo: repo, DNSRepo: dnsRepo, IPManager: ipManager}
 }
 
-func (o *DefaultServiceOrchestrator) OrchestrateServiceCreation(serviceSpec shared.ServiceSpec) error {
+func (o *DefaultServiceOrchestrator) OrchestrateServiceCreation(ctx context.Context, serviceSpec shared.ServiceSpec) error {
 	service := transformToService(serviceSpec)
 
-	ip, err := o.IPManager.AssignIP()
-	if err != nil {
-		return err
+	if serviceSpec.IP != "" {
+		if err := o.IPManager.AssignSpecificIP(ctx, serviceSpec.IP); err != nil {
+			return err
+		}
+		service.IP = serviceSpec.IP
+	} else {
+		ip, err := o.IPManager.AssignIP(ctx)
+		if err != nil {
+			return err
+		}
+		service.IP = ip
 	}
-	service.IP = ip
 
-	if err := o.Repo.CreateService(&service); err != nil {
+	if err := o.Repo.CreateService(ctx, &service); err != nil {
+		if err := o.IPManager.ReleaseIP(ctx, service.IP); err != nil {
+			shared.Log.Errorf("failed to release IP %s: %v", service.IP, err)
+		}
 		return err
 	}
 
-	if err := o.DNSRepo.RegisterService(service.Name, service.IP); err != nil {
+	if err := o.DNSRepo.RegisterService(ctx, service); err != nil {
 		return err
 	}
 
@@ -43,44 +56,96 @@ func (o *DefaultServiceOrchestrator) OrchestrateServiceCreation(serviceSpec shar
 func transformToService(spec shared.ServiceSpec) shared.Service {
 	id := shared.GenerateRandomString(10)
 	service := shared.Service{
-		ID:       id,
-		Name:     spec.Name,
-		Selector: spec.Selector,
-		Ports:    spec.Ports,
+		ID:         id,
+		ObjectMeta: shared.ObjectMeta{Name: spec.Name, Namespace: shared.NamespaceOrDefault(spec.Namespace), Labels: spec.Labels, Annotations: spec.Annotations},
+		Selector:   spec.Selector,
+		Ports:      spec.Ports,
 	}
 	return service
 }
 
-func (o *DefaultServiceOrchestrator) OrchestrateServiceUpdate(existingService shared.Service, serviceSpec shared.ServiceSpec) error {
+func (o *DefaultServiceOrchestrator) OrchestrateServiceUpdate(ctx context.Context, existingService shared.Service, serviceSpec shared.ServiceSpec) error {
 	shared.Log.Infof("Updating service...")
+	if serviceSpec.IP != "" && serviceSpec.IP != existingService.IP {
+		return fmt.Errorf("the IP of service %s cannot be changed from %s to %s", existingService.Name, existingService.IP, serviceSpec.IP)
+	}
 	updatedService := updateExistingService(serviceSpec, &existingService)
-	err := o.Repo.UpdateService(&updatedService)
+	err := o.Repo.UpdateService(ctx, &updatedService)
 	if err != nil {
 		return err
 	}
 
-	return o.DNSRepo.RegisterService(updatedService.Name, updatedService.IP)
+	return o.DNSRepo.RegisterService(ctx, updatedService)
 }
 
 func updateExistingService(spec shared.ServiceSpec, existing *shared.Service) shared.Service {
 	(*existing).Selector = spec.Selector
 	(*existing).Ports = spec.Ports
+	shared.UpdateMetadata(&existing.ObjectMeta, spec.ObjectMeta)
 	return *existing
 }
 
-func (o *DefaultServiceOrchestrator) OrchestrateServiceDeletion(serviceName string) error {
-	service, err := o.Repo.GetServiceByName(serviceName)
+func (o *DefaultServiceOrchestrator) OrchestrateServiceDeletion(ctx context.Context, namespace string, serviceName string) error {
+	service, err := o.Repo.GetServiceByName(ctx, namespace, serviceName)
 	if err != nil {
 		return err
 	}
 
-	if err := o.DNSRepo.DeregisterService(service.Name); err != nil {
+	if err := o.DNSRepo.DeregisterService(ctx, service.Namespace, service.Name); err != nil {
 		shared.Log.Errorf("failed to deregister service %s: %v", service.Name, err)
 	}
 
-	if err := o.IPManager.ReleaseIP(service.IP); err != nil {
+	if err := o.IPManager.ReleaseIP(ctx, service.IP); err != nil {
 		shared.Log.Errorf("failed to release IP %s: %v", service.IP, err)
 	}
 
-	return o.Repo.DeleteService(service.Name)
+	return o.Repo.DeleteService(ctx, service.Namespace, service.Name)
+}
+
+// Brings the allocations of the service range in line with the services stored in etcd, to be run before any
+// service is created. Services without a usable IP, left over from another range or sharing theirs with an older
+// service, get a new one
+func (o *DefaultServiceOrchestrator) RepairServiceIPs(ctx context.Context) error {
+	services, err := o.Repo.ListServices(ctx, "")
+	if err != nil {
+		return err
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].CreationTimestamp.Before(services[j].CreationTimestamp)
+	})
+
+	var inUse []string
+	var misplaced []shared.Service
+	allocated := make(map[string]bool)
+	for _, service := range services {
+		if !o.IPManager.Contains(service.IP) || allocated[service.IP] {
+			misplaced = append(misplaced, service)
+			continue
+		}
+		allocated[service.IP] = true
+		inUse = append(inUse, service.IP)
+	}
+
+	if err := o.IPManager.Repair(ctx, inUse); err != nil {
+		return err
+	}
+
+	for _, service := range misplaced {
+		ip, err := o.IPManager.AssignIP(ctx)
+		if err != nil {
+			return err
+		}
+		shared.Log.Infof("Moving service %s/%s from IP %q to %s", service.Namespace, service.Name, service.IP, ip)
+		service.IP = ip
+		if err := o.Repo.UpdateService(ctx, &service); err != nil {
+			if err := o.IPManager.ReleaseIP(ctx, ip); err != nil {
+				shared.Log.Errorf("failed to release IP %s: %v", ip, err)
+			}
+			return err
+		}
+		if err := o.DNSRepo.RegisterService(ctx, service); err != nil {
+			shared.Log.Errorf("failed to register service %s: %v", service.Name, err)
+		}
+	}
+	return nil
 }
@@ -4,12 +4,35 @@ import (
 	"maden/pkg/shared"
 	"maden/pkg/mocks"
 
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
+// Matches the service of a namespace with the name and IP
+type serviceIPMatcher struct {
+	namespace string
+	name string
+	ip string
+}
+
+func serviceWithIP(namespace string, name string, ip string) gomock.Matcher {
+	return serviceIPMatcher{namespace: namespace, name: name, ip: ip}
+}
+
+func (m serviceIPMatcher) Matches(x interface{}) bool {
+	service, ok := x.(shared.Service)
+	return ok && service.Namespace == m.namespace && service.Name == m.name && service.IP == m.ip
+}
+
+func (m serviceIPMatcher) String() string {
+	return fmt.Sprintf("is service %s/%s with IP %s", m.namespace, m.name, m.ip)
+}
+
 func TestDefaultServiceOrchestratorOrchestrateServiceCreation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -21,17 +44,17 @@ func TestDefaultServiceOrchestratorOrchestrateServiceCreation(t *testing.T) {
 	orchestrator := NewDefaultServiceOrchestrator(mockRepo, mockDNSRepo, mockIPManager)
 
 	serviceSpec := shared.ServiceSpec{
-		Name: "test-service",
+		ObjectMeta: shared.ObjectMeta{Name: "test-service"},
 		Selector: map[string]string{"app": "myapp"},
 		Ports: []shared.ServicePort{{Port: 80, TargetPort: 8080}},
 	}
 
 	// Setting up the test scenario
-	mockIPManager.EXPECT().AssignIP().Return("192.168.1.100", nil)
-	mockRepo.EXPECT().CreateService(gomock.Any()).Return(nil)
-	mockDNSRepo.EXPECT().RegisterService("test-service", "192.168.1.100").Return(nil)
+	mockIPManager.EXPECT().AssignIP(gomock.Any()).Return("192.168.1.100", nil)
+	mockRepo.EXPECT().CreateService(gomock.Any(), gomock.Any()).Return(nil)
+	mockDNSRepo.EXPECT().RegisterService(gomock.Any(), serviceWithIP("default", "test-service", "192.168.1.100")).Return(nil)
 
-	err := orchestrator.OrchestrateServiceCreation(serviceSpec)
+	err := orchestrator.OrchestrateServiceCreation(context.Background(), serviceSpec)
 	assert.NoError(t, err)
 }
 
@@ -48,17 +71,17 @@ func TestDefaultServiceOrchestratorOrchestrateServiceDeletion(t *testing.T) {
 	serviceName := "test-service"
 	service := shared.Service{
 		ID: "1",
-		Name: serviceName,
+		ObjectMeta: shared.ObjectMeta{Name: serviceName, Namespace: "default"},
 		IP: "192.168.1.100",
 	}
 
 	// Setting up the test scenario
-	mockRepo.EXPECT().GetServiceByName(serviceName).Return(&service, nil)
-	mockDNSRepo.EXPECT().DeregisterService(serviceName).Return(nil)
-	mockIPManager.EXPECT().ReleaseIP("192.168.1.100").Return(nil)
-	mockRepo.EXPECT().DeleteService(serviceName).Return(nil)
+	mockRepo.EXPECT().GetServiceByName(gomock.Any(), "default", serviceName).Return(&service, nil)
+	mockDNSRepo.EXPECT().DeregisterService(gomock.Any(), "default", serviceName).Return(nil)
+	mockIPManager.EXPECT().ReleaseIP(gomock.Any(), "192.168.1.100").Return(nil)
+	mockRepo.EXPECT().DeleteService(gomock.Any(), "default", serviceName).Return(nil)
 
-	err := orchestrator.OrchestrateServiceDeletion(serviceName)
+	err := orchestrator.OrchestrateServiceDeletion(context.Background(), "default", serviceName)
 	assert.NoError(t, err)
 }
 
@@ -74,21 +97,108 @@ func TestDefaultServiceOrchestratorOrchestrateServiceUpdate(t *testing.T) {
 
 	existingService := shared.Service{
 		ID: "1",
-		Name: "test-service",
+		ObjectMeta: shared.ObjectMeta{Name: "test-service", Namespace: "default"},
 		Selector: map[string]string{"app": "old"},
 		Ports: []shared.ServicePort{{Port: 80, TargetPort: 8080}},
 		IP: "192.168.1.100",
 	}
 	serviceSpec := shared.ServiceSpec{
-		Name: "test-service",
+		ObjectMeta: shared.ObjectMeta{Name: "test-service"},
 		Selector: map[string]string{"app": "new"},
 		Ports: []shared.ServicePort{{Port: 80, TargetPort: 8080}},
 	}
 
 	// Setting up the test scenario
-	mockRepo.EXPECT().UpdateService(gomock.Any()).Return(nil)
-	mockDNSRepo.EXPECT().RegisterService("test-service", "192.168.1.100").Return(nil)
+	mockRepo.EXPECT().UpdateService(gomock.Any(), gomock.Any()).Return(nil)
+	mockDNSRepo.EXPECT().RegisterService(gomock.Any(), serviceWithIP("default", "test-service", "192.168.1.100")).Return(nil)
+
+	err := orchestrator.OrchestrateServiceUpdate(context.Background(), existingService, serviceSpec)
+	assert.NoError(t, err)
+}
+
+func TestDefaultServiceOrchestratorOrchestrateServiceUpdateRejectsIPChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockServiceRepository(ctrl)
+	mockDNSRepo := mocks.NewMockDNSRepository(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+
+	orchestrator := NewDefaultServiceOrchestrator(mockRepo, mockDNSRepo, mockIPManager)
+
+	existingService := shared.Service{
+		ID: "1",
+		ObjectMeta: shared.ObjectMeta{Name: "test-service", Namespace: "default"},
+		Ports: []shared.ServicePort{{Port: 80}},
+		IP: "10.96.0.10",
+	}
+	serviceSpec := shared.ServiceSpec{
+		ObjectMeta: shared.ObjectMeta{Name: "test-service"},
+		Ports: []shared.ServicePort{{Port: 80}},
+		IP: "10.96.0.20",
+	}
+
+	// Neither the service nor its DNS record change
+	mockRepo.EXPECT().UpdateService(gomock.Any(), gomock.Any()).Times(0)
+	mockDNSRepo.EXPECT().RegisterService(gomock.Any(), gomock.Any()).Times(0)
+
+	err := orchestrator.OrchestrateServiceUpdate(context.Background(), existingService, serviceSpec)
+	assert.Error(t, err)
+}
+
+func TestDefaultServiceOrchestratorOrchestrateServiceCreationWithRequestedIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockServiceRepository(ctrl)
+	mockDNSRepo := mocks.NewMockDNSRepository(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+
+	orchestrator := NewDefaultServiceOrchestrator(mockRepo, mockDNSRepo, mockIPManager)
+
+	serviceSpec := shared.ServiceSpec{
+		ObjectMeta: shared.ObjectMeta{Name: "test-service"},
+		Ports: []shared.ServicePort{{Port: 80}},
+		IP: "10.96.0.10",
+	}
+
+	// The IP is given back when the service cannot be stored
+	mockIPManager.EXPECT().AssignSpecificIP(gomock.Any(), "10.96.0.10").Return(nil)
+	mockRepo.EXPECT().CreateService(gomock.Any(), gomock.Any()).Return(&shared.ErrDuplicateResource{ID: "test-service", ResourceType: shared.ServiceResource})
+	mockIPManager.EXPECT().ReleaseIP(gomock.Any(), "10.96.0.10").Return(nil)
+
+	err := orchestrator.OrchestrateServiceCreation(context.Background(), serviceSpec)
+	assert.Error(t, err)
+}
+
+func TestDefaultServiceOrchestratorRepairServiceIPs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockServiceRepository(ctrl)
+	mockDNSRepo := mocks.NewMockDNSRepository(ctrl)
+	mockIPManager := mocks.NewMockIPManager(ctrl)
+
+	orchestrator := NewDefaultServiceOrchestrator(mockRepo, mockDNSRepo, mockIPManager)
+
+	now := time.Now()
+	services := []shared.Service{
+		{ObjectMeta: shared.ObjectMeta{Name: "web", Namespace: "default", CreationTimestamp: now}, IP: "10.96.0.2"},
+		{ObjectMeta: shared.ObjectMeta{Name: "db", Namespace: "default", CreationTimestamp: now.Add(time.Minute)}, IP: "10.96.0.2"},
+		{ObjectMeta: shared.ObjectMeta{Name: "legacy", Namespace: "default", CreationTimestamp: now}, IP: "192.168.1.100"},
+	}
 
-	err := orchestrator.OrchestrateServiceUpdate(existingService, serviceSpec)
+	// The older of the services sharing an IP keeps it, the others are moved
+	mockRepo.EXPECT().ListServices(gomock.Any(), "").Return(services, nil)
+	mockIPManager.EXPECT().Contains("10.96.0.2").Return(true).AnyTimes()
+	mockIPManager.EXPECT().Contains("192.168.1.100").Return(false)
+	mockIPManager.EXPECT().Repair(gomock.Any(), []string{"10.96.0.2"}).Return(nil)
+	mockIPManager.EXPECT().AssignIP(gomock.Any()).Return("10.96.0.3", nil)
+	mockIPManager.EXPECT().AssignIP(gomock.Any()).Return("10.96.0.4", nil)
+	mockRepo.EXPECT().UpdateService(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockDNSRepo.EXPECT().RegisterService(gomock.Any(), serviceWithIP("default", "legacy", "10.96.0.3")).Return(nil)
+	mockDNSRepo.EXPECT().RegisterService(gomock.Any(), serviceWithIP("default", "db", "10.96.0.4")).Return(nil)
+
+	err := orchestrator.RepairServiceIPs(context.Background())
 	assert.NoError(t, err)
 }
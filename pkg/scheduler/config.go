@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"maden/pkg/shared"
+
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const schedulerConfigPathEnv = "SCHEDULER_CONFIG_PATH"
+
+type SchedulerConfig struct {
+	Scores          []ScorePluginConfig `yaml:"scores"`
+	PreferredLabels map[string]string   `yaml:"preferredLabels"` // Used by the LabelPreference score plugin
+}
+
+type ScorePluginConfig struct {
+	Name   string `yaml:"name"`
+	Weight int    `yaml:"weight"`
+}
+
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		Scores: []ScorePluginConfig{
+			{Name: "LeastAllocated", Weight: 1},
+			{Name: "DeploymentSpread", Weight: 1},
+			{Name: "InterPodAffinity", Weight: 1},
+		},
+	}
+}
+
+// Loads the config file named by SCHEDULER_CONFIG_PATH, falling back to the defaults if it is unset or invalid
+func NewSchedulerConfig() SchedulerConfig {
+	path, ok := os.LookupEnv(schedulerConfigPathEnv)
+	if !ok {
+		return DefaultSchedulerConfig()
+	}
+
+	config, err := LoadSchedulerConfig(path)
+	if err != nil {
+		shared.Log.Errorf("Failed to load scheduler config, using defaults: %v", err)
+		return DefaultSchedulerConfig()
+	}
+	return config
+}
+
+func LoadSchedulerConfig(path string) (SchedulerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SchedulerConfig{}, err
+	}
+
+	var config SchedulerConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return SchedulerConfig{}, err
+	}
+
+	for _, scoreConfig := range config.Scores {
+		if _, ok := scorePluginFactories[scoreConfig.Name]; !ok {
+			return SchedulerConfig{}, fmt.Errorf("unknown score plugin: %s", scoreConfig.Name)
+		}
+		if scoreConfig.Weight < 0 {
+			return SchedulerConfig{}, fmt.Errorf("negative weight for score plugin %s", scoreConfig.Name)
+		}
+	}
+	return config, nil
+}
+
+var scorePluginFactories = map[string]func(config SchedulerConfig) ScorePlugin{
+	"LeastAllocated": func(config SchedulerConfig) ScorePlugin {
+		return &LeastAllocatedScore{}
+	},
+	"MostAllocated": func(config SchedulerConfig) ScorePlugin {
+		return &MostAllocatedScore{}
+	},
+	"LabelPreference": func(config SchedulerConfig) ScorePlugin {
+		return &LabelPreferenceScore{PreferredLabels: config.PreferredLabels}
+	},
+	"DeploymentSpread": func(config SchedulerConfig) ScorePlugin {
+		return &DeploymentSpreadScore{}
+	},
+	"InterPodAffinity": func(config SchedulerConfig) ScorePlugin {
+		return &InterPodAffinityScore{}
+	},
+}
+
+func buildFilterPlugins() []FilterPlugin {
+	return []FilterPlugin{
+		&NodeReadyFilter{},
+		&ResourcesFilter{},
+		&NodeAffinityFilter{},
+		&NodeAntiAffinityFilter{},
+		&TaintTolerationFilter{},
+		&InterPodAffinityFilter{},
+	}
+}
+
+// Plugins with a weight of 0 are disabled
+func buildScorePlugins(config SchedulerConfig) []weightedScorePlugin {
+	plugins := make([]weightedScorePlugin, 0)
+	for _, scoreConfig := range config.Scores {
+		factory, ok := scorePluginFactories[scoreConfig.Name]
+		if !ok || scoreConfig.Weight <= 0 {
+			continue
+		}
+		plugins = append(plugins, weightedScorePlugin{Plugin: factory(config), Weight: scoreConfig.Weight})
+	}
+	return plugins
+}
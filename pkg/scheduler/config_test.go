@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSchedulerConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler_config.yaml")
+	configData := `
+scores:
+  - name: MostAllocated
+    weight: 2
+  - name: LabelPreference
+    weight: 1
+  - name: LeastAllocated
+    weight: 0
+preferredLabels:
+  disk: ssd
+`
+	assert.NoError(t, os.WriteFile(path, []byte(configData), 0644))
+
+	config, err := LoadSchedulerConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "ssd", config.PreferredLabels["disk"])
+
+	plugins := buildScorePlugins(config)
+	assert.Len(t, plugins, 2) // LeastAllocated is disabled by its weight
+	assert.Equal(t, "MostAllocated", plugins[0].Plugin.Name())
+	assert.Equal(t, 2, plugins[0].Weight)
+}
+
+func TestLoadSchedulerConfigUnknownPlugin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler_config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("scores:\n  - name: Random\n    weight: 1\n"), 0644))
+
+	_, err := LoadSchedulerConfig(path)
+
+	assert.Error(t, err)
+}
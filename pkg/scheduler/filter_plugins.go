@@ -0,0 +1,218 @@
+package scheduler
+
+import "maden/pkg/shared"
+
+// Nodes that are NotReady or Offline do not get new pods
+type NodeReadyFilter struct{}
+
+func (f *NodeReadyFilter) Name() string {
+	return "NodeReady"
+}
+
+func (f *NodeReadyFilter) Filter(state *SchedulingState, pod *shared.Pod, node *shared.Node) string {
+	if node.Status != shared.NodeReady {
+		return "node not ready"
+	}
+	return ""
+}
+
+type ResourcesFilter struct{}
+
+func (f *ResourcesFilter) Name() string {
+	return "Resources"
+}
+
+func (f *ResourcesFilter) Filter(state *SchedulingState, pod *shared.Pod, node *shared.Node) string {
+	if !hasSufficientCPU(node, &pod.Resources) {
+		return "insufficient CPU"
+	}
+	if !hasSufficientMemory(node, &pod.Resources) {
+		return "insufficient memory"
+	}
+	return ""
+}
+
+type NodeAffinityFilter struct{}
+
+func (f *NodeAffinityFilter) Name() string {
+	return "NodeAffinity"
+}
+
+func (f *NodeAffinityFilter) Filter(state *SchedulingState, pod *shared.Pod, node *shared.Node) string {
+	if !matchesAffinity(node, pod) {
+		return "affinity mismatch"
+	}
+	return ""
+}
+
+type NodeAntiAffinityFilter struct{}
+
+func (f *NodeAntiAffinityFilter) Name() string {
+	return "NodeAntiAffinity"
+}
+
+func (f *NodeAntiAffinityFilter) Filter(state *SchedulingState, pod *shared.Pod, node *shared.Node) string {
+	if !matchesAntiAffinity(node, pod) {
+		return "anti-affinity mismatch"
+	}
+	return ""
+}
+
+type TaintTolerationFilter struct{}
+
+func (f *TaintTolerationFilter) Name() string {
+	return "TaintToleration"
+}
+
+func (f *TaintTolerationFilter) Filter(state *SchedulingState, pod *shared.Pod, node *shared.Node) string {
+	if !matchesTolerations(node, pod) {
+		return "taint not tolerated"
+	}
+	return ""
+}
+
+// Enforces the required pod affinity and anti-affinity terms, both those of the pod being scheduled
+// and those of the pods already running, which must not end up next to a pod they repel
+type InterPodAffinityFilter struct{}
+
+func (f *InterPodAffinityFilter) Name() string {
+	return "InterPodAffinity"
+}
+
+func (f *InterPodAffinityFilter) Filter(state *SchedulingState, pod *shared.Pod, node *shared.Node) string {
+	if !matchesPodAffinity(state, pod, node) {
+		return "pod affinity mismatch"
+	}
+	if !matchesPodAntiAffinity(state, pod, node) || !matchesExistingPodsAntiAffinity(state, pod, node) {
+		return "pod anti-affinity conflict"
+	}
+	return ""
+}
+
+// Matchers
+func hasSufficientResources(node *shared.Node, req *shared.Resources) bool {
+	return hasSufficientCPU(node, req) && hasSufficientMemory(node, req)
+}
+
+func hasSufficientCPU(node *shared.Node, req *shared.Resources) bool {
+	return node.Capacity.CPU-node.Used.CPU >= req.CPU
+}
+
+func hasSufficientMemory(node *shared.Node, req *shared.Resources) bool {
+	return node.Capacity.Memory-node.Used.Memory >= req.Memory
+}
+
+func matchesAffinity(node *shared.Node, pod *shared.Pod) bool {
+	for key, val := range pod.Affinity {
+		if nodeVal, ok := node.Labels[key]; !ok || nodeVal != val {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAntiAffinity(node *shared.Node, pod *shared.Pod) bool {
+	for key, val := range pod.AntiAffinity {
+		if nodeVal, ok := node.Labels[key]; ok && nodeVal == val {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesTolerations(node *shared.Node, pod *shared.Pod) bool {
+	for key, val := range node.Taints {
+		if toVal, ok := pod.Tolerations[key]; !ok || toVal != val {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesPodAffinity(state *SchedulingState, pod *shared.Pod, node *shared.Node) bool {
+	if pod.PodAffinity == nil {
+		return true
+	}
+
+	for _, term := range pod.PodAffinity.Required {
+		if hasMatchingPodInTopology(state, pod, node, term) {
+			continue
+		}
+		// The first pod of a group that attracts itself would otherwise never be scheduled
+		if hasMatchingPod(state, pod, term) || !shared.MatchesLabelSelector(term.LabelSelector, pod.Labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesPodAntiAffinity(state *SchedulingState, pod *shared.Pod, node *shared.Node) bool {
+	if pod.PodAntiAffinity == nil {
+		return true
+	}
+
+	for _, term := range pod.PodAntiAffinity.Required {
+		if hasMatchingPodInTopology(state, pod, node, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesExistingPodsAntiAffinity(state *SchedulingState, pod *shared.Pod, node *shared.Node) bool {
+	for _, existingPod := range state.Pods {
+		if existingPod.ID == pod.ID || existingPod.Namespace != pod.Namespace || existingPod.PodAntiAffinity == nil {
+			continue
+		}
+		existingNode, ok := state.Nodes[existingPod.NodeID]
+		if !ok {
+			continue
+		}
+
+		for _, term := range existingPod.PodAntiAffinity.Required {
+			if shared.MatchesLabelSelector(term.LabelSelector, pod.Labels) && isSameTopology(&existingNode, node, term.TopologyKey) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Whether one of the assigned pods selected by the term runs in the same topology domain as the node
+func hasMatchingPodInTopology(state *SchedulingState, pod *shared.Pod, node *shared.Node, term shared.PodAffinityTerm) bool {
+	for _, existingPod := range state.Pods {
+		if !isSelectedByTerm(pod, &existingPod, term) {
+			continue
+		}
+		existingNode, ok := state.Nodes[existingPod.NodeID]
+		if ok && isSameTopology(&existingNode, node, term.TopologyKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMatchingPod(state *SchedulingState, pod *shared.Pod, term shared.PodAffinityTerm) bool {
+	for _, existingPod := range state.Pods {
+		if isSelectedByTerm(pod, &existingPod, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// Terms only select the other pods of the namespace of the pod they belong to
+func isSelectedByTerm(pod *shared.Pod, existingPod *shared.Pod, term shared.PodAffinityTerm) bool {
+	return existingPod.ID != pod.ID && existingPod.Namespace == pod.Namespace &&
+		shared.MatchesLabelSelector(term.LabelSelector, existingPod.Labels)
+}
+
+// Nodes without the topology label do not belong to any domain
+func isSameTopology(a *shared.Node, b *shared.Node, topologyKey string) bool {
+	aVal, ok := a.Labels[topologyKey]
+	if !ok {
+		return false
+	}
+	bVal, ok := b.Labels[topologyKey]
+	return ok && aVal == bVal
+}
@@ -0,0 +1,274 @@
+package scheduler
+
+import (
+	"maden/pkg/shared"
+
+	"testing"
+)
+
+func TestResourcesFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     shared.Node
+		req      shared.Resources
+		expected bool
+	}{
+		{
+			name: "sufficient resources",
+			node: shared.Node{
+				Capacity: shared.Resources{CPU: 10, Memory: 1000},
+				Used:     shared.Resources{CPU: 3, Memory: 500},
+			},
+			req:      shared.Resources{CPU: 6, Memory: 400},
+			expected: true,
+		},
+		{
+			name: "insufficient CPU",
+			node: shared.Node{
+				Capacity: shared.Resources{CPU: 10, Memory: 1000},
+				Used:     shared.Resources{CPU: 7, Memory: 200},
+			},
+			req:      shared.Resources{CPU: 4, Memory: 100},
+			expected: false,
+		},
+		{
+			name: "insufficient Memory",
+			node: shared.Node{
+				Capacity: shared.Resources{CPU: 10, Memory: 1000},
+				Used:     shared.Resources{CPU: 2, Memory: 800},
+			},
+			req:      shared.Resources{CPU: 2, Memory: 300},
+			expected: false,
+		},
+	}
+
+	filter := &ResourcesFilter{}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := shared.Pod{Resources: test.req}
+			if got := filter.Filter(&SchedulingState{}, &pod, &test.node) == ""; got != test.expected {
+				t.Errorf("ResourcesFilter.Filter() fits = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestNodeAffinityFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     shared.Node
+		pod      shared.Pod
+		expected bool
+	}{
+		{
+			name: "affinity matched",
+			node: shared.Node{
+				ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"region": "us-west"}},
+			},
+			pod: shared.Pod{
+				Affinity: map[string]string{"region": "us-west"},
+			},
+			expected: true,
+		},
+
+		{
+			name: "affinity not matched",
+			node: shared.Node{
+				ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"region": "us-east"}},
+			},
+			pod: shared.Pod{
+				Affinity: map[string]string{"region": "us-west"},
+			},
+			expected: false,
+		},
+	}
+
+	filter := &NodeAffinityFilter{}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := filter.Filter(&SchedulingState{}, &test.pod, &test.node) == ""; got != test.expected {
+				t.Errorf("NodeAffinityFilter.Filter() fits = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestNodeAntiAffinityFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     shared.Node
+		pod      shared.Pod
+		expected bool
+	}{
+		{
+			name: "anti-affinity matched",
+			node: shared.Node{
+				ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"zone": "zone1"}},
+			},
+			pod: shared.Pod{
+				AntiAffinity: map[string]string{"zone": "zone1"},
+			},
+			expected: false,
+		},
+		{
+			name: "anti-affinity not matched",
+			node: shared.Node{
+				ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"zone": "zone2"}},
+			},
+			pod: shared.Pod{
+				AntiAffinity: map[string]string{"zone": "zone1"},
+			},
+			expected: true,
+		},
+	}
+
+	filter := &NodeAntiAffinityFilter{}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := filter.Filter(&SchedulingState{}, &test.pod, &test.node) == ""; got != test.expected {
+				t.Errorf("NodeAntiAffinityFilter.Filter() fits = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestTaintTolerationFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     shared.Node
+		pod      shared.Pod
+		expected bool
+	}{
+		{
+			name: "tolerations matched",
+			node: shared.Node{
+				Taints: map[string]string{"key1": "value1"},
+			},
+			pod: shared.Pod{
+				Tolerations: map[string]string{"key1": "value1"},
+			},
+			expected: true,
+		},
+		{
+			name: "tolerations not matched",
+			node: shared.Node{
+				Taints: map[string]string{"key1": "value1"},
+			},
+			pod: shared.Pod{
+				Tolerations: map[string]string{"key1": "value2"},
+			},
+			expected: false,
+		},
+		{
+			name: "no tolerations for taints",
+			node: shared.Node{
+				Taints: map[string]string{"key1": "value1"},
+			},
+			pod: shared.Pod{
+				Tolerations: map[string]string{},
+			},
+			expected: false,
+		},
+	}
+
+	filter := &TaintTolerationFilter{}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := filter.Filter(&SchedulingState{}, &test.pod, &test.node) == ""; got != test.expected {
+				t.Errorf("TaintTolerationFilter.Filter() fits = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestInterPodAffinityFilter(t *testing.T) {
+	zoneA1 := shared.Node{ID: "node-1", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"zone": "a"}}}
+	zoneA2 := shared.Node{ID: "node-2", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"zone": "a"}}}
+	zoneB := shared.Node{ID: "node-3", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"zone": "b"}}}
+	cacheTerm := shared.PodAffinityTerm{
+		LabelSelector: shared.LabelSelector{MatchLabels: map[string]string{"app": "cache"}},
+		TopologyKey:   "zone",
+	}
+	state := &SchedulingState{
+		Pods: []shared.Pod{
+			{ID: "cache-1", NodeID: "node-1", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"app": "cache"}}},
+		},
+		Nodes: map[string]shared.Node{"node-1": zoneA1, "node-2": zoneA2, "node-3": zoneB},
+	}
+
+	tests := []struct {
+		name     string
+		state    *SchedulingState
+		node     shared.Node
+		pod      shared.Pod
+		expected string
+	}{
+		{
+			name:     "affinity satisfied in the same zone",
+			state:    state,
+			node:     zoneA2,
+			pod:      shared.Pod{ID: "web-1", PodAffinity: &shared.PodAffinity{Required: []shared.PodAffinityTerm{cacheTerm}}},
+			expected: "",
+		},
+		{
+			name:     "affinity not satisfied in another zone",
+			state:    state,
+			node:     zoneB,
+			pod:      shared.Pod{ID: "web-1", PodAffinity: &shared.PodAffinity{Required: []shared.PodAffinityTerm{cacheTerm}}},
+			expected: "pod affinity mismatch",
+		},
+		{
+			name:  "first pod of a group attracting itself",
+			state: &SchedulingState{Nodes: state.Nodes},
+			node:  zoneB,
+			pod: shared.Pod{
+				ID:          "cache-1",
+				ObjectMeta:  shared.ObjectMeta{Labels: map[string]string{"app": "cache"}},
+				PodAffinity: &shared.PodAffinity{Required: []shared.PodAffinityTerm{cacheTerm}},
+			},
+			expected: "",
+		},
+		{
+			name:     "anti-affinity conflict in the same zone",
+			state:    state,
+			node:     zoneA2,
+			pod:      shared.Pod{ID: "cache-2", PodAntiAffinity: &shared.PodAffinity{Required: []shared.PodAffinityTerm{cacheTerm}}},
+			expected: "pod anti-affinity conflict",
+		},
+		{
+			name:     "anti-affinity satisfied in another zone",
+			state:    state,
+			node:     zoneB,
+			pod:      shared.Pod{ID: "cache-2", PodAntiAffinity: &shared.PodAffinity{Required: []shared.PodAffinityTerm{cacheTerm}}},
+			expected: "",
+		},
+		{
+			name: "existing pod repels the incoming one",
+			state: &SchedulingState{
+				Pods: []shared.Pod{{
+					ID:              "cache-1",
+					NodeID:          "node-1",
+					PodAntiAffinity: &shared.PodAffinity{Required: []shared.PodAffinityTerm{cacheTerm}},
+				}},
+				Nodes: state.Nodes,
+			},
+			node:     zoneA2,
+			pod:      shared.Pod{ID: "cache-2", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"app": "cache"}}},
+			expected: "pod anti-affinity conflict",
+		},
+	}
+
+	filter := &InterPodAffinityFilter{}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := filter.Filter(test.state, &test.pod, &test.node); got != test.expected {
+				t.Errorf("InterPodAffinityFilter.Filter() = %q, want %q", got, test.expected)
+			}
+		})
+	}
+}
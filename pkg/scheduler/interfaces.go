@@ -1,7 +1,30 @@
 package scheduler
 
-import "maden/pkg/shared"
+import (
+	"context"
+	"maden/pkg/shared"
+)
 
 type Scheduler interface {
 	SchedulePod(pod *shared.Pod) error
-}
\ No newline at end of file
+}
+
+type SchedulingQueue interface {
+	AddUnschedulable(podID string)
+	Remove(podID string)
+	MoveAllToActive()
+	Len() int
+	Pop(ctx context.Context) (string, bool)
+}
+
+// Rules out the nodes a pod cannot run on. Filter returns why the pod does not fit, or an empty string if it does
+type FilterPlugin interface {
+	Name() string
+	Filter(state *SchedulingState, pod *shared.Pod, node *shared.Node) string
+}
+
+// Ranks the nodes that passed the filters. Score returns a value between 0 and MaxNodeScore
+type ScorePlugin interface {
+	Name() string
+	Score(state *SchedulingState, pod *shared.Pod, node *shared.Node) int
+}
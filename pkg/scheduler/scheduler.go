@@ -1,83 +1,161 @@
 package scheduler
 
 import (
+	"context"
 	"maden/pkg/etcd"
 	"maden/pkg/shared"
+
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 )
 
+// Cluster state the plugins can look at during a single scheduling attempt
+type SchedulingState struct {
+	Pods  []shared.Pod           // Pods already assigned to a node
+	Nodes map[string]shared.Node // By ID, to resolve the topology of the assigned pods
+}
+
+type weightedScorePlugin struct {
+	Plugin ScorePlugin
+	Weight int
+}
+
+// Places pods in two phases: filter plugins rule out the nodes a pod cannot run on,
+// then score plugins rank the remaining ones and the node with the highest weighted score wins
 type PodScheduler struct {
-	Repo etcd.NodeRepository
+	Repo    etcd.NodeRepository
+	PodRepo etcd.PodRepository
+	Filters []FilterPlugin
+	Scores  []weightedScorePlugin
 }
 
-func NewPodScheduler(repo etcd.NodeRepository) Scheduler {
-	return &PodScheduler{Repo: repo}
+func NewPodScheduler(repo etcd.NodeRepository, podRepo etcd.PodRepository, config SchedulerConfig) Scheduler {
+	return &PodScheduler{
+		Repo:    repo,
+		PodRepo: podRepo,
+		Filters: buildFilterPlugins(),
+		Scores:  buildScorePlugins(config),
+	}
 }
 
+// Concurrent schedulings can pick the same node; the one losing the race on its update starts over
+// with fresh node state, so the node is never overbooked
 func (s *PodScheduler) SchedulePod(pod *shared.Pod) error {
-	nodes, err := s.Repo.ListNodes()
+	return shared.RetryOnConflict(func() error {
+		return s.schedulePod(pod)
+	})
+}
+
+func (s *PodScheduler) schedulePod(pod *shared.Pod) error {
+	nodes, err := s.Repo.ListNodes(context.Background())
 	if err != nil {
 		return err
 	}
 
-	scheduled := false
-	for i, node := range nodes {
-		if shouldSchedulePod(&node, pod) {
-			pod.NodeID = node.ID
-			pod.Status = shared.PodScheduled
-			nodes[i].Used.CPU += pod.Resources.CPU
-			nodes[i].Used.Memory += pod.Resources.Memory
-
-			if err := s.Repo.UpdateNode(&nodes[i]); err != nil {
-				return err
-			}
+	state, err := s.getSchedulingState(nodes)
+	if err != nil {
+		return err
+	}
 
-			scheduled = true
-			break
+	feasibleNodes := make([]*shared.Node, 0)
+	unfitReasons := make(map[string]int)
+	for i := range nodes {
+		if reason := s.runFilters(state, pod, &nodes[i]); reason != "" {
+			unfitReasons[reason]++
+			continue
 		}
+		feasibleNodes = append(feasibleNodes, &nodes[i])
 	}
 
-	if !scheduled {
-		pod.Status = shared.PodPending
+	if len(feasibleNodes) == 0 {
+		pod.Status.Phase = shared.PodPending
+		shared.SetPodCondition(pod, shared.PodCondition{
+			Type:               shared.PodScheduledCondition,
+			Status:             false,
+			Reason:             "Unschedulable",
+			Message:            getUnschedulableMessage(len(nodes), unfitReasons),
+			LastTransitionTime: time.Now(),
+		})
+		return nil
 	}
 
+	node := s.selectNode(state, pod, feasibleNodes)
+	node.Used.CPU += pod.Resources.CPU
+	node.Used.Memory += pod.Resources.Memory
+	if err := s.Repo.UpdateNode(context.Background(), node); err != nil {
+		return err
+	}
+
+	pod.NodeID = node.ID
+	pod.Status.Phase = shared.PodScheduled
+	shared.SetPodCondition(pod, shared.PodCondition{
+		Type:               shared.PodScheduledCondition,
+		Status:             true,
+		LastTransitionTime: time.Now(),
+	})
 	return nil
 }
 
-func shouldSchedulePod(node *shared.Node, pod *shared.Pod) bool {
-	return node.Status == shared.NodeReady && hasSufficientResources(node, &pod.Resources) &&
-		matchesAffinity(node, pod) && matchesAntiAffinity(node, pod) &&
-		matchesTolerations(node, pod)
-}
+func (s *PodScheduler) getSchedulingState(nodes []shared.Node) (*SchedulingState, error) {
+	pods, err := s.PodRepo.ListPods(context.Background(), "")
+	if err != nil {
+		return nil, err
+	}
 
-func hasSufficientResources(node *shared.Node, req *shared.Resources) bool {
-	availableCPU := node.Capacity.CPU - node.Used.CPU
-	availableMemory := node.Capacity.Memory - node.Used.Memory
-	return availableCPU >= req.CPU && availableMemory >= req.Memory
+	assignedPods := make([]shared.Pod, 0)
+	for _, pod := range pods {
+		if pod.NodeID != "" {
+			assignedPods = append(assignedPods, pod)
+		}
+	}
+	nodesByID := make(map[string]shared.Node)
+	for _, node := range nodes {
+		nodesByID[node.ID] = node
+	}
+	return &SchedulingState{Pods: assignedPods, Nodes: nodesByID}, nil
 }
 
-func matchesAffinity(node *shared.Node, pod *shared.Pod) bool {
-	for key, val := range pod.Affinity {
-		if nodeVal, ok := node.Labels[key]; !ok || nodeVal != val {
-			return false
+// Returns the reason of the first filter that rejects the node, or an empty string if none does
+func (s *PodScheduler) runFilters(state *SchedulingState, pod *shared.Pod, node *shared.Node) string {
+	for _, filter := range s.Filters {
+		if reason := filter.Filter(state, pod, node); reason != "" {
+			return reason
 		}
 	}
-	return true
+	return ""
 }
 
-func matchesAntiAffinity(node *shared.Node, pod *shared.Pod) bool {
-	for key, val := range pod.AntiAffinity {
-		if nodeVal, ok := node.Labels[key]; ok && nodeVal == val {
-			return false
+// Picks the node with the highest weighted score; ties go to the node listed first
+func (s *PodScheduler) selectNode(state *SchedulingState, pod *shared.Pod, nodes []*shared.Node) *shared.Node {
+	bestNode := nodes[0]
+	bestScore := -1
+	for _, node := range nodes {
+		score := 0
+		for _, weightedPlugin := range s.Scores {
+			score += weightedPlugin.Weight * weightedPlugin.Plugin.Score(state, pod, node)
+		}
+
+		if score > bestScore {
+			bestNode = node
+			bestScore = score
 		}
 	}
-	return true
+	return bestNode
 }
 
-func matchesTolerations(node *shared.Node, pod *shared.Pod) bool {
-	for key, val := range node.Taints {
-		if toVal, ok := pod.Tolerations[key]; !ok || toVal != val {
-			return false
-		}
+// Summarizes the reasons in the form "0/3 nodes are available: 2 insufficient CPU, 1 taint not tolerated"
+func getUnschedulableMessage(nodeCount int, unfitReasons map[string]int) string {
+	reasons := make([]string, 0, len(unfitReasons))
+	for reason, count := range unfitReasons {
+		reasons = append(reasons, fmt.Sprintf("%d %s", count, reason))
 	}
-	return true
-}
\ No newline at end of file
+	sort.Strings(reasons)
+
+	message := fmt.Sprintf("0/%d nodes are available", nodeCount)
+	if len(reasons) > 0 {
+		message += ": " + strings.Join(reasons, ", ")
+	}
+	return message
+}
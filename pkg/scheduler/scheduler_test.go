@@ -1,177 +1,132 @@
 package scheduler
 
 import (
+	"maden/pkg/mocks"
 	"maden/pkg/shared"
 
+	"context"
 	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
-func TestHasSufficientResources(t *testing.T) {
-	tests := []struct {
-		name     string
-		node     shared.Node
-		req      shared.Resources
-		expected bool
-	}{
-		{
-			name: "sufficient resources",
-			node: shared.Node{
-				Capacity: shared.Resources{CPU: 10, Memory: 1000},
-				Used:     shared.Resources{CPU: 3, Memory: 500},
-			},
-			req:      shared.Resources{CPU: 6, Memory: 400},
-			expected: true,
-		},
-		{
-			name: "insufficient CPU",
-			node: shared.Node{
-				Capacity: shared.Resources{CPU: 10, Memory: 1000},
-				Used:     shared.Resources{CPU: 7, Memory: 200},
-			},
-			req:      shared.Resources{CPU: 4, Memory: 100},
-			expected: false,
-		},
-		{
-			name: "insufficient Memory",
-			node: shared.Node{
-				Capacity: shared.Resources{CPU: 10, Memory: 1000},
-				Used:     shared.Resources{CPU: 2, Memory: 800},
-			},
-			req:      shared.Resources{CPU: 2, Memory: 300},
-			expected: false,
-		},
-	}
+func TestSchedulePodRecordsUnschedulableReasons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNodeRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	scheduler := NewPodScheduler(mockRepo, mockPodRepo, DefaultSchedulerConfig())
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if got := hasSufficientResources(&test.node, &test.req); got != test.expected {
-				t.Errorf("hasSufficientResources() = %v, want %v", got, test.expected)
-			}
-		})
+	nodes := []shared.Node{
+		{ID: "node-1", Status: shared.NodeReady, Capacity: shared.Resources{CPU: 1, Memory: 1000}},
+		{ID: "node-2", Status: shared.NodeReady, Capacity: shared.Resources{CPU: 1, Memory: 1000}},
+		{ID: "node-3", Status: shared.NodeReady, Capacity: shared.Resources{CPU: 8, Memory: 1000}, Taints: map[string]string{"gpu": "true"}},
 	}
+	pod := &shared.Pod{ID: "pod-1", Resources: shared.Resources{CPU: 2, Memory: 100}}
+
+	mockRepo.EXPECT().ListNodes(gomock.Any()).Return(nodes, nil)
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "").Return([]shared.Pod{}, nil)
+	mockRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Times(0)
+
+	err := scheduler.SchedulePod(pod)
+
+	assert.NoError(t, err)
+	assert.Equal(t, shared.PodPending, pod.Status.Phase)
+	assert.Len(t, pod.Status.Conditions, 1)
+	assert.False(t, pod.Status.Conditions[0].Status)
+	assert.Equal(t, "Unschedulable", pod.Status.Conditions[0].Reason)
+	assert.Equal(t, "0/3 nodes are available: 1 taint not tolerated, 2 insufficient CPU", pod.Status.Conditions[0].Message)
 }
 
-func TestMatchesAffinity(t *testing.T) {
-	tests := []struct {
-		name     string
-		node     shared.Node
-		pod      shared.Pod
-		expected bool
-	}{
-		{
-			name: "affinity matched",
-			node: shared.Node{
-				Labels: map[string]string{"region": "us-west"},
-			},
-			pod: shared.Pod{
-				Affinity: map[string]string{"region": "us-west"},
-			},
-			expected: true,
-		},
-
-		{
-			name: "affinity not matched",
-			node: shared.Node{
-				Labels: map[string]string{"region": "us-east"},
-			},
-			pod: shared.Pod{
-				Affinity: map[string]string{"region": "us-west"},
-			},
-			expected: false,
-		},
-	}
+func TestSchedulePodSpreadsReplicasAcrossNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNodeRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	scheduler := NewPodScheduler(mockRepo, mockPodRepo, DefaultSchedulerConfig())
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if got := matchesAffinity(&test.node, &test.pod); got != test.expected {
-				t.Errorf("matchesAffinity() = %v, want %v", got, test.expected)
-			}
-		})
+	capacity := shared.Resources{CPU: 4, Memory: 4096}
+	nodes := []shared.Node{
+		{ID: "node-1", Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 1, Memory: 512}},
+		{ID: "node-2", Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 1, Memory: 512}},
 	}
+	existingPods := []shared.Pod{{ID: "web-1", DeploymentID: "dep-1", NodeID: "node-1"}}
+	pod := &shared.Pod{ID: "web-2", DeploymentID: "dep-1", Resources: shared.Resources{CPU: 1, Memory: 256}}
+
+	mockRepo.EXPECT().ListNodes(gomock.Any()).Return(nodes, nil)
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "").Return(existingPods, nil)
+	mockRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+		assert.Equal(t, "node-2", node.ID)
+		assert.Equal(t, shared.Resources{CPU: 2, Memory: 768}, node.Used)
+		return nil
+	})
+
+	err := scheduler.SchedulePod(pod)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "node-2", pod.NodeID)
+	assert.Equal(t, shared.PodScheduled, pod.Status.Phase)
 }
 
-func TestMatchesAntiAffinity(t *testing.T) {
-	tests := []struct {
-		name     string
-		node     shared.Node
-		pod      shared.Pod
-		expected bool
-	}{
-		{
-			name: "anti-affinity matched",
-			node: shared.Node{
-				Labels: map[string]string{"zone": "zone1"},
-			},
-			pod: shared.Pod{
-				AntiAffinity: map[string]string{"zone": "zone1"},
-			},
-			expected: false,
-		},
-		{
-			name: "anti-affinity not matched",
-			node: shared.Node{
-				Labels: map[string]string{"zone": "zone2"},
-			},
-			pod: shared.Pod{
-				AntiAffinity: map[string]string{"zone": "zone1"},
-			},
-			expected: true,
-		},
-	}
+func TestSchedulePodBinPacking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNodeRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	config := SchedulerConfig{Scores: []ScorePluginConfig{{Name: "MostAllocated", Weight: 1}}}
+	scheduler := NewPodScheduler(mockRepo, mockPodRepo, config)
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if got := matchesAntiAffinity(&test.node, &test.pod); got != test.expected {
-				t.Errorf("matchesAntiAffinity() = %v, want %v", got, test.expected)
-			}
-		})
+	capacity := shared.Resources{CPU: 4, Memory: 4096}
+	nodes := []shared.Node{
+		{ID: "empty-node", Status: shared.NodeReady, Capacity: capacity},
+		{ID: "busy-node", Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 2, Memory: 2048}},
 	}
+	pod := &shared.Pod{ID: "pod-1", Resources: shared.Resources{CPU: 1, Memory: 256}}
+
+	mockRepo.EXPECT().ListNodes(gomock.Any()).Return(nodes, nil)
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "").Return([]shared.Pod{}, nil)
+	mockRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := scheduler.SchedulePod(pod)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "busy-node", pod.NodeID)
 }
 
-func TestMatchesTolerations(t *testing.T) {
-	tests := []struct {
-		name     string
-		node     shared.Node
-		pod      shared.Pod
-		expected bool
-	}{
-		{
-			name: "tolerations matched",
-			node: shared.Node{
-				Taints: map[string]string{"key1": "value1"},
-			},
-			pod: shared.Pod{
-				Tolerations: map[string]string{"key1": "value1"},
-			},
-			expected: true,
-		},
-		{
-			name: "tolerations not matched",
-			node: shared.Node{
-				Taints: map[string]string{"key1": "value1"},
-			},
-			pod: shared.Pod{
-				Tolerations: map[string]string{"key1": "value2"},
-			},
-			expected: false,
-		},
-		{
-			name: "no tolerations for taints",
-			node: shared.Node{
-				Taints: map[string]string{"key1": "value1"},
-			},
-			pod: shared.Pod{
-				Tolerations: map[string]string{},
-			},
-			expected: false,
-		},
-	}
+func TestSchedulePodRetriesOnConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			if got := matchesTolerations(&test.node, &test.pod); got != test.expected {
-				t.Errorf("matchesTolerations() = %v, want %v", got, test.expected)
-			}
-		})
-	}
+	mockRepo := mocks.NewMockNodeRepository(ctrl)
+	mockPodRepo := mocks.NewMockPodRepository(ctrl)
+	scheduler := NewPodScheduler(mockRepo, mockPodRepo, DefaultSchedulerConfig())
+
+	capacity := shared.Resources{CPU: 2, Memory: 2048}
+	pod := &shared.Pod{ID: "pod-1", Resources: shared.Resources{CPU: 2, Memory: 256}}
+
+	// Another pod took node-1 between the first read and the update
+	gomock.InOrder(
+		mockRepo.EXPECT().ListNodes(gomock.Any()).Return([]shared.Node{
+			{ID: "node-1", Status: shared.NodeReady, Capacity: capacity, ResourceVersion: 3},
+		}, nil),
+		mockRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).Return(&shared.ErrConflict{ID: "node-1", ResourceType: shared.NodeResource}),
+		mockRepo.EXPECT().ListNodes(gomock.Any()).Return([]shared.Node{
+			{ID: "node-1", Status: shared.NodeReady, Capacity: capacity, Used: shared.Resources{CPU: 2, Memory: 256}, ResourceVersion: 4},
+			{ID: "node-2", Status: shared.NodeReady, Capacity: capacity, ResourceVersion: 5},
+		}, nil),
+		mockRepo.EXPECT().UpdateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, node *shared.Node) error {
+			assert.Equal(t, "node-2", node.ID)
+			return nil
+		}),
+	)
+	mockPodRepo.EXPECT().ListPods(gomock.Any(), "").Return([]shared.Pod{}, nil).Times(2)
+
+	err := scheduler.SchedulePod(pod)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "node-2", pod.NodeID)
 }
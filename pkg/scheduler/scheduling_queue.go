@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	initialSchedulingBackoff = 1 * time.Second
+	maxSchedulingBackoff     = 1 * time.Minute
+)
+
+// Holds the pods that did not fit onto any node. Each pod is retried after an exponential backoff,
+// or right away once the cluster gains capacity (a node is added or frees resources).
+type PodSchedulingQueue struct {
+	mutex       sync.Mutex
+	nextAttempt map[string]time.Time // Pods waiting for their next attempt
+	attempts    map[string]int       // Kept while a pod is being retried, so the backoff keeps growing
+	wakeUp      chan struct{}
+	now         func() time.Time
+}
+
+func NewPodSchedulingQueue() SchedulingQueue {
+	return &PodSchedulingQueue{
+		nextAttempt: make(map[string]time.Time),
+		attempts:    make(map[string]int),
+		wakeUp:      make(chan struct{}, 1),
+		now:         time.Now,
+	}
+}
+
+func (q *PodSchedulingQueue) AddUnschedulable(podID string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.attempts[podID]++
+	q.nextAttempt[podID] = q.now().Add(getSchedulingBackoff(q.attempts[podID]))
+	q.signal()
+}
+
+func (q *PodSchedulingQueue) Remove(podID string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delete(q.nextAttempt, podID)
+	delete(q.attempts, podID)
+}
+
+// Makes every waiting pod due immediately, skipping the rest of its backoff
+func (q *PodSchedulingQueue) MoveAllToActive() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.nextAttempt) == 0 {
+		return
+	}
+
+	now := q.now()
+	for podID := range q.nextAttempt {
+		q.nextAttempt[podID] = now
+	}
+	q.signal()
+}
+
+func (q *PodSchedulingQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.nextAttempt)
+}
+
+// Blocks until a pod is due for another attempt. The second return value is false once ctx is done
+func (q *PodSchedulingQueue) Pop(ctx context.Context) (string, bool) {
+	for {
+		podID, waitTime, found := q.popDue()
+		if found {
+			return podID, true
+		}
+
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		if waitTime > 0 {
+			timer = time.NewTimer(waitTime)
+			timerC = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", false
+		case <-q.wakeUp:
+		case <-timerC:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// Returns the most overdue pod, or how long to wait for the next one (0 if there is none)
+func (q *PodSchedulingQueue) popDue() (string, time.Duration, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := q.now()
+	dueID := ""
+	var earliest time.Time
+	for podID, nextAttempt := range q.nextAttempt {
+		if dueID == "" || nextAttempt.Before(earliest) {
+			dueID = podID
+			earliest = nextAttempt
+		}
+	}
+
+	if dueID == "" {
+		return "", 0, false
+	}
+	if earliest.After(now) {
+		return "", earliest.Sub(now), false
+	}
+
+	delete(q.nextAttempt, dueID)
+	return dueID, 0, true
+}
+
+func (q *PodSchedulingQueue) signal() {
+	select {
+	case q.wakeUp <- struct{}{}:
+	default:
+	}
+}
+
+func getSchedulingBackoff(attempts int) time.Duration {
+	backoff := initialSchedulingBackoff
+	for i := 1; i < attempts && backoff < maxSchedulingBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxSchedulingBackoff)
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulingBackoff(t *testing.T) {
+	assert.Equal(t, 1*time.Second, getSchedulingBackoff(1))
+	assert.Equal(t, 4*time.Second, getSchedulingBackoff(3))
+	assert.Equal(t, maxSchedulingBackoff, getSchedulingBackoff(20))
+}
+
+func TestPodSchedulingQueueWaitsForBackoff(t *testing.T) {
+	queue := NewPodSchedulingQueue().(*PodSchedulingQueue)
+	now := time.Now()
+	queue.now = func() time.Time { return now }
+
+	queue.AddUnschedulable("pod-1")
+	_, waitTime, found := queue.popDue()
+	assert.False(t, found)
+	assert.Equal(t, initialSchedulingBackoff, waitTime)
+
+	now = now.Add(initialSchedulingBackoff)
+	podID, _, found := queue.popDue()
+	assert.True(t, found)
+	assert.Equal(t, "pod-1", podID)
+	assert.Equal(t, 0, queue.Len())
+
+	// The backoff keeps growing until the pod is removed
+	queue.AddUnschedulable("pod-1")
+	_, waitTime, _ = queue.popDue()
+	assert.Equal(t, 2*initialSchedulingBackoff, waitTime)
+
+	queue.Remove("pod-1")
+	queue.AddUnschedulable("pod-1")
+	_, waitTime, _ = queue.popDue()
+	assert.Equal(t, initialSchedulingBackoff, waitTime)
+}
+
+func TestPodSchedulingQueueMoveAllToActive(t *testing.T) {
+	queue := NewPodSchedulingQueue()
+	queue.AddUnschedulable("pod-1")
+	queue.AddUnschedulable("pod-1") // Pushes the next attempt further out
+
+	queue.MoveAllToActive()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	podID, ok := queue.Pop(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "pod-1", podID)
+
+	// Nothing left, so Pop gives up once the context is done
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer shortCancel()
+	podID, ok = queue.Pop(shortCtx)
+	assert.False(t, ok)
+	assert.Equal(t, "", podID)
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import "maden/pkg/shared"
+
+const MaxNodeScore = 100
+
+// Favors the nodes with the most free resources left after placing the pod, spreading the load
+type LeastAllocatedScore struct{}
+
+func (s *LeastAllocatedScore) Name() string {
+	return "LeastAllocated"
+}
+
+func (s *LeastAllocatedScore) Score(state *SchedulingState, pod *shared.Pod, node *shared.Node) int {
+	cpuScore := getFreeShare(node.Capacity.CPU, node.Used.CPU+pod.Resources.CPU)
+	memoryScore := getFreeShare(node.Capacity.Memory, node.Used.Memory+pod.Resources.Memory)
+	return (cpuScore + memoryScore) / 2
+}
+
+// Favors the nodes with the least free resources left after placing the pod, packing pods tightly
+type MostAllocatedScore struct{}
+
+func (s *MostAllocatedScore) Name() string {
+	return "MostAllocated"
+}
+
+func (s *MostAllocatedScore) Score(state *SchedulingState, pod *shared.Pod, node *shared.Node) int {
+	cpuScore := MaxNodeScore - getFreeShare(node.Capacity.CPU, node.Used.CPU+pod.Resources.CPU)
+	memoryScore := MaxNodeScore - getFreeShare(node.Capacity.Memory, node.Used.Memory+pod.Resources.Memory)
+	return (cpuScore + memoryScore) / 2
+}
+
+// Favors the nodes carrying the labels preferred in the scheduler config
+type LabelPreferenceScore struct {
+	PreferredLabels map[string]string
+}
+
+func (s *LabelPreferenceScore) Name() string {
+	return "LabelPreference"
+}
+
+func (s *LabelPreferenceScore) Score(state *SchedulingState, pod *shared.Pod, node *shared.Node) int {
+	if len(s.PreferredLabels) == 0 {
+		return 0
+	}
+
+	matches := 0
+	for key, val := range s.PreferredLabels {
+		if nodeVal, ok := node.Labels[key]; ok && nodeVal == val {
+			matches++
+		}
+	}
+	return MaxNodeScore * matches / len(s.PreferredLabels)
+}
+
+// Favors the nodes running the fewest pods of the same deployment, so replicas do not share a node
+type DeploymentSpreadScore struct{}
+
+func (s *DeploymentSpreadScore) Name() string {
+	return "DeploymentSpread"
+}
+
+func (s *DeploymentSpreadScore) Score(state *SchedulingState, pod *shared.Pod, node *shared.Node) int {
+	if pod.DeploymentID == "" {
+		return MaxNodeScore
+	}
+
+	replicasOnNode := 0
+	for _, existingPod := range state.Pods {
+		if existingPod.NodeID == node.ID && existingPod.DeploymentID == pod.DeploymentID && existingPod.ID != pod.ID {
+			replicasOnNode++
+		}
+	}
+	return MaxNodeScore / (1 + replicasOnNode)
+}
+
+// Favors the nodes satisfying the most preferred pod affinity terms, weighted, and the fewest preferred anti-affinity ones
+type InterPodAffinityScore struct{}
+
+func (s *InterPodAffinityScore) Name() string {
+	return "InterPodAffinity"
+}
+
+func (s *InterPodAffinityScore) Score(state *SchedulingState, pod *shared.Pod, node *shared.Node) int {
+	score := 0
+	totalWeight := 0
+	if pod.PodAffinity != nil {
+		for _, weightedTerm := range pod.PodAffinity.Preferred {
+			totalWeight += weightedTerm.Weight
+			if hasMatchingPodInTopology(state, pod, node, weightedTerm.Term) {
+				score += weightedTerm.Weight
+			}
+		}
+	}
+	if pod.PodAntiAffinity != nil {
+		for _, weightedTerm := range pod.PodAntiAffinity.Preferred {
+			totalWeight += weightedTerm.Weight
+			if !hasMatchingPodInTopology(state, pod, node, weightedTerm.Term) {
+				score += weightedTerm.Weight
+			}
+		}
+	}
+
+	if totalWeight <= 0 {
+		return 0
+	}
+	return MaxNodeScore * max(score, 0) / totalWeight
+}
+
+// Share of the capacity that remains free, scaled to MaxNodeScore
+func getFreeShare(capacity int, requested int) int {
+	if capacity <= 0 {
+		return 0
+	}
+	free := max(capacity-requested, 0)
+	return MaxNodeScore * free / capacity
+}
@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"maden/pkg/shared"
+
+	"testing"
+)
+
+func TestScorePlugins(t *testing.T) {
+	capacity := shared.Resources{CPU: 4, Memory: 1000}
+	pod := shared.Pod{ID: "pod-1", DeploymentID: "dep-1", Resources: shared.Resources{CPU: 1, Memory: 250}}
+	state := &SchedulingState{Pods: []shared.Pod{
+		{ID: "pod-2", DeploymentID: "dep-1", NodeID: "node-1"},
+		{ID: "pod-3", DeploymentID: "dep-2", NodeID: "node-1"},
+	}}
+
+	tests := []struct {
+		name     string
+		plugin   ScorePlugin
+		node     shared.Node
+		expected int
+	}{
+		{
+			name:     "least allocated on empty node",
+			plugin:   &LeastAllocatedScore{},
+			node:     shared.Node{ID: "node-1", Capacity: capacity},
+			expected: 75,
+		},
+		{
+			name:     "most allocated on half full node",
+			plugin:   &MostAllocatedScore{},
+			node:     shared.Node{ID: "node-1", Capacity: capacity, Used: shared.Resources{CPU: 1, Memory: 250}},
+			expected: 50,
+		},
+		{
+			name:     "label preference partially matched",
+			plugin:   &LabelPreferenceScore{PreferredLabels: map[string]string{"disk": "ssd", "zone": "eu"}},
+			node:     shared.Node{ID: "node-1", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"disk": "ssd", "zone": "us"}}},
+			expected: 50,
+		},
+		{
+			name:     "deployment spread with a replica on the node",
+			plugin:   &DeploymentSpreadScore{},
+			node:     shared.Node{ID: "node-1"},
+			expected: 50,
+		},
+		{
+			name:     "deployment spread without replicas on the node",
+			plugin:   &DeploymentSpreadScore{},
+			node:     shared.Node{ID: "node-2"},
+			expected: MaxNodeScore,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.plugin.Score(state, &pod, &test.node); got != test.expected {
+				t.Errorf("%s.Score() = %v, want %v", test.plugin.Name(), got, test.expected)
+			}
+		})
+	}
+}
+
+func TestInterPodAffinityScore(t *testing.T) {
+	nodes := map[string]shared.Node{
+		"node-1": {ID: "node-1", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"hostname": "node-1"}}},
+		"node-2": {ID: "node-2", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"hostname": "node-2"}}},
+	}
+	state := &SchedulingState{
+		Pods: []shared.Pod{
+			{ID: "cache-1", NodeID: "node-1", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"app": "cache"}}},
+			{ID: "web-1", NodeID: "node-2", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"app": "web"}}},
+		},
+		Nodes: nodes,
+	}
+	pod := shared.Pod{
+		ID: "web-2",
+		PodAffinity: &shared.PodAffinity{Preferred: []shared.WeightedPodAffinityTerm{{
+			Weight: 30,
+			Term: shared.PodAffinityTerm{
+				LabelSelector: shared.LabelSelector{MatchLabels: map[string]string{"app": "cache"}},
+				TopologyKey:   "hostname",
+			},
+		}}},
+		PodAntiAffinity: &shared.PodAffinity{Preferred: []shared.WeightedPodAffinityTerm{{
+			Weight: 10,
+			Term: shared.PodAffinityTerm{
+				LabelSelector: shared.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				TopologyKey:   "hostname",
+			},
+		}}},
+	}
+
+	tests := []struct {
+		name     string
+		node     shared.Node
+		expected int
+	}{
+		{name: "next to the cache and away from the web replica", node: nodes["node-1"], expected: MaxNodeScore},
+		{name: "away from the cache and next to the web replica", node: nodes["node-2"], expected: 0},
+		{name: "away from both", node: shared.Node{ID: "node-3", ObjectMeta: shared.ObjectMeta{Labels: map[string]string{"hostname": "node-3"}}}, expected: 25},
+	}
+
+	plugin := &InterPodAffinityScore{}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := plugin.Score(state, &pod, &test.node); got != test.expected {
+				t.Errorf("InterPodAffinityScore.Score() = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
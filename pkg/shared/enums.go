@@ -39,18 +39,19 @@ func (n NodeStatus) String() string {
 	return [...]string{"Ready", "NotReady", "Offline"}[n]
 }
 
-type PodStatus int
+type PodPhase int
 
 const (
-	PodPending PodStatus = iota
+	PodPending PodPhase = iota
 	PodScheduled
 	PodContainerCreating
 	PodRunning
 	PodFailed
 	PodRestarted
+	PodSucceeded
 )
 
-func (p *PodStatus) UnmarshalJSON(data []byte) error {
+func (p *PodPhase) UnmarshalJSON(data []byte) error {
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
@@ -68,18 +69,57 @@ func (p *PodStatus) UnmarshalJSON(data []byte) error {
 		*p = PodFailed
 	case "Restarted":
 		*p = PodRestarted
+	case "Succeeded":
+		*p = PodSucceeded
 	default:
-		return fmt.Errorf("unknown pod status: %s", s)
+		return fmt.Errorf("unknown pod phase: %s", s)
 	}
 	return nil
 }
 
-func (p PodStatus) MarshalJSON() ([]byte, error) {
+func (p PodPhase) MarshalJSON() ([]byte, error) {
 	return json.Marshal(p.String())
 }
 
-func (p PodStatus) String() string {
-	return [...]string{"Pending", "Scheduled", "ContainerCreating", "Running", "Failed"}[p]
+func (p PodPhase) String() string {
+	return [...]string{"Pending", "Scheduled", "ContainerCreating", "Running", "Failed", "Restarted", "Succeeded"}[p]
+}
+
+type PodConditionType int
+
+const (
+	PodScheduledCondition    PodConditionType = iota
+	PodInitializedCondition                   // Every init container of the pod completed
+	ContainersReadyCondition                  // Every container of the pod is running
+	PodReadyCondition                         // The pod can serve requests
+)
+
+func (p *PodConditionType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "PodScheduled":
+		*p = PodScheduledCondition
+	case "Initialized":
+		*p = PodInitializedCondition
+	case "ContainersReady":
+		*p = ContainersReadyCondition
+	case "Ready":
+		*p = PodReadyCondition
+	default:
+		return fmt.Errorf("unknown pod condition type: %s", s)
+	}
+	return nil
+}
+
+func (p PodConditionType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p PodConditionType) String() string {
+	return [...]string{"PodScheduled", "Initialized", "ContainersReady", "Ready"}[p]
 }
 
 type ResourceType int
@@ -92,10 +132,61 @@ const (
 	PersistentVolumeResource
 	PersistentVolumeClaimResource
 	DNSResource
+	DeploymentRevisionResource
+	NodeLeaseResource
+	NamespaceResource
+	EndpointsResource
+	IPAllocationResource
 )
 
 func (r ResourceType) String() string {
-	return [...]string{"Pod", "Node", "Deployment", "Service", "PersistentVolumeResource", "PersistentVolumeClaimResource", "DNSResource"}[r]
+	return [...]string{"Pod", "Node", "Deployment", "Service", "PersistentVolume", "PersistentVolumeClaim", "DNS", "DeploymentRevision", "NodeLease", "Namespace", "Endpoints", "IPAllocation"}[r]
+}
+
+// How the dependents of a deleted object are handled: Foreground deletes them before the owner,
+// Background deletes the owner right away and leaves the dependents to the garbage collector,
+// Orphan keeps the dependents and only removes their owner references
+type DeletionPropagation int
+
+const (
+	DeletePropagationBackground DeletionPropagation = iota
+	DeletePropagationForeground
+	DeletePropagationOrphan
+)
+
+// An empty string stands for the default, Background
+func ParseDeletionPropagation(s string) (DeletionPropagation, error) {
+	switch s {
+	case "Background", "":
+		return DeletePropagationBackground, nil
+	case "Foreground":
+		return DeletePropagationForeground, nil
+	case "Orphan":
+		return DeletePropagationOrphan, nil
+	default:
+		return DeletePropagationBackground, fmt.Errorf("unknown propagation policy: %s", s)
+	}
+}
+
+func (d *DeletionPropagation) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	policy, err := ParseDeletionPropagation(s)
+	if err != nil {
+		return err
+	}
+	*d = policy
+	return nil
+}
+
+func (d DeletionPropagation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d DeletionPropagation) String() string {
+	return [...]string{"Background", "Foreground", "Orphan"}[d]
 }
 
 type RestartPolicy int
@@ -132,10 +223,107 @@ func (r RestartPolicy) String() string {
 	return [...]string{"Always", "OnFailure", "Never"}[r]
 }
 
-type ContainerStatus int
+type PodEventType int
+
+const (
+	PodEventNormal PodEventType = iota
+	PodEventWarning
+)
+
+func (e *PodEventType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "Normal":
+		*e = PodEventNormal
+	case "Warning":
+		*e = PodEventWarning
+	default:
+		return fmt.Errorf("unknown pod event type: %s", s)
+	}
+	return nil
+}
+
+func (e PodEventType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+func (e PodEventType) String() string {
+	return [...]string{"Normal", "Warning"}[e]
+}
+
+type ImagePullPolicy int
+
+const (
+	PullIfNotPresent ImagePullPolicy = iota
+	PullAlways
+	PullNever
+)
+
+func (i *ImagePullPolicy) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "IfNotPresent", "":
+		*i = PullIfNotPresent
+	case "Always":
+		*i = PullAlways
+	case "Never":
+		*i = PullNever
+	default:
+		return fmt.Errorf("unknown image pull policy: %s", s)
+	}
+	return nil
+}
+
+func (i ImagePullPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+func (i ImagePullPolicy) String() string {
+	return [...]string{"IfNotPresent", "Always", "Never"}[i]
+}
+
+type DeploymentStrategyType int
 
 const (
-	Created ContainerStatus = iota
+	RollingUpdateStrategy DeploymentStrategyType = iota
+	RecreateStrategy
+)
+
+func (d *DeploymentStrategyType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "RollingUpdate", "":
+		*d = RollingUpdateStrategy
+	case "Recreate":
+		*d = RecreateStrategy
+	default:
+		return fmt.Errorf("unknown deployment strategy: %s", s)
+	}
+	return nil
+}
+
+func (d DeploymentStrategyType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d DeploymentStrategyType) String() string {
+	return [...]string{"RollingUpdate", "Recreate"}[d]
+}
+
+// Status of a container as reported by the container runtime
+type ContainerRuntimeStatus int
+
+const (
+	Created ContainerRuntimeStatus = iota
 	Running
 	Paused
 	Restarting
@@ -144,7 +332,7 @@ const (
 	Dead
 )
 
-func (c *ContainerStatus) UnmarshalJSON(data []byte) error {
+func (c *ContainerRuntimeStatus) UnmarshalJSON(data []byte) error {
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
@@ -156,8 +344,8 @@ func (c *ContainerStatus) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func GetStatusFromString(s string) (*ContainerStatus, error) {
-	var c ContainerStatus
+func GetStatusFromString(s string) (*ContainerRuntimeStatus, error) {
+	var c ContainerRuntimeStatus
 	switch s {
 	case "created":
 		c = Created
@@ -179,10 +367,41 @@ func GetStatusFromString(s string) (*ContainerStatus, error) {
 	return &c, nil
 }
 
-func (c ContainerStatus) MarshalJSON() ([]byte, error) {
+func (c ContainerRuntimeStatus) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.String())
 }
 
-func (c ContainerStatus) String() string {
+func (c ContainerRuntimeStatus) String() string {
 	return [...]string{"created", "running", "paused", "restarting", "removing", "exited", "dead"}[c]
 }
+
+type NamespacePhase int
+
+const (
+	NamespaceActive      NamespacePhase = iota
+	NamespaceTerminating                // Its objects are being deleted, no new ones can be created in it
+)
+
+func (n *NamespacePhase) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "Active", "":
+		*n = NamespaceActive
+	case "Terminating":
+		*n = NamespaceTerminating
+	default:
+		return fmt.Errorf("unknown namespace phase: %s", s)
+	}
+	return nil
+}
+
+func (n NamespacePhase) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.String())
+}
+
+func (n NamespacePhase) String() string {
+	return [...]string{"Active", "Terminating"}[n]
+}
@@ -26,4 +26,81 @@ type ErrDuplicateResource struct {
 
 func (e *ErrDuplicateResource) Error() string {
 	return fmt.Sprintf("a %s with ID %s already exists", e.ResourceType.String(), e.ID)
-}
\ No newline at end of file
+}
+
+// Returned when an object was modified by someone else between reading and updating it
+type ErrConflict struct {
+	ID string
+	ResourceType ResourceType
+}
+
+func (e *ErrConflict) Error() string {
+	return fmt.Sprintf("the %s with ID %s was modified concurrently", e.ResourceType.String(), e.ID)
+}
+
+// Returned when a label selector given in a query cannot be parsed
+type ErrInvalidSelector struct {
+	Selector string
+	Reason string
+}
+
+func (e *ErrInvalidSelector) Error() string {
+	return fmt.Sprintf("invalid label selector %q: %s", e.Selector, e.Reason)
+}
+
+// Returned when an object is created in a namespace that is being deleted
+type ErrNamespaceTerminating struct {
+	Namespace string
+}
+
+func (e *ErrNamespaceTerminating) Error() string {
+	return fmt.Sprintf("namespace %s is being terminated, no new objects can be created in it", e.Namespace)
+}
+
+// Returned when a specific IP is requested that is allocated already
+type ErrIPInUse struct {
+	IP string
+}
+
+func (e *ErrIPInUse) Error() string {
+	return fmt.Sprintf("IP %s is already allocated", e.IP)
+}
+
+// Returned when an IP is not a usable address of the CIDR it is requested from
+type ErrIPNotInRange struct {
+	IP string
+	CIDR string
+}
+
+func (e *ErrIPNotInRange) Error() string {
+	return fmt.Sprintf("IP %s is not a usable address of %s", e.IP, e.CIDR)
+}
+
+// Returned when every address of a CIDR is allocated
+type ErrIPRangeFull struct {
+	CIDR string
+}
+
+func (e *ErrIPRangeFull) Error() string {
+	return fmt.Sprintf("no IP left in %s", e.CIDR)
+}
+
+// Returned when a range of IPs is used by another owner, e.g. the pod network of another Docker host
+type ErrIPRangeBound struct {
+	Range string
+	Owner string
+	Allocated int
+}
+
+func (e *ErrIPRangeBound) Error() string {
+	return fmt.Sprintf("IP range %s is bound to %s, which holds %d of its IPs", e.Range, e.Owner, e.Allocated)
+}
+
+// Returned when a manifest holds a resource of a kind that cannot be applied
+type ErrUnsupportedKind struct {
+	Kind string
+}
+
+func (e *ErrUnsupportedKind) Error() string {
+	return fmt.Sprintf("unsupported kind: %s", e.Kind)
+}
@@ -1,62 +1,227 @@
 package shared
 
+import "time"
+
+// Object metadata
+// Embedded in every object, and in the specs they are created from
+type ObjectMeta struct {
+	Name              string            `json:"name" yaml:"name"`
+	Namespace         string            `json:"namespace,omitempty" yaml:"namespace"` // Empty for cluster-wide objects such as nodes
+	Labels            map[string]string `json:"labels,omitempty" yaml:"labels"`       // Identifying, matched by label selectors
+	Annotations       map[string]string `json:"annotations,omitempty" yaml:"annotations"`
+	CreationTimestamp time.Time         `json:"creationTimestamp" yaml:"creationTimestamp"` // Set by the store on creation
+	UID               string            `json:"uid" yaml:"uid"`                             // Set by the store on creation, never reused unlike names
+	OwnerReferences   []OwnerReference  `json:"ownerReferences,omitempty" yaml:"ownerReferences"`
+	DeletionTimestamp *time.Time        `json:"deletionTimestamp,omitempty" yaml:"deletionTimestamp"` // Set once the deletion started but waits on finalizers
+	Finalizers        []string          `json:"finalizers,omitempty" yaml:"finalizers"`
+}
+
+// Finalizers that hold back the deletion of an owner until its dependents are dealt with
+const (
+	ForegroundDeletionFinalizer = "foregroundDeletion"
+	OrphanFinalizer             = "orphan"
+)
+
+// Points a dependent at the object that owns it, such as a pod at its deployment.
+// Dependents whose owners are all gone are deleted by the garbage collector
+type OwnerReference struct {
+	Kind       string `json:"kind" yaml:"kind"`
+	Name       string `json:"name" yaml:"name"`
+	UID        string `json:"uid" yaml:"uid"`                         // Tells the owner apart from a later object of the same name
+	Controller bool   `json:"controller,omitempty" yaml:"controller"` // The owner manages the dependent
+}
+
+// Implemented by every type embedding ObjectMeta
+type Object interface {
+	GetObjectMeta() *ObjectMeta
+}
+
+func (m *ObjectMeta) GetObjectMeta() *ObjectMeta {
+	return m
+}
+
+// Namespaces
+// Objects that are not cluster-wide, such as pods, deployments, services and volume claims, live in a namespace.
+// Names only have to be unique within their namespace
+const DefaultNamespace = "default"
+
+type NamespaceSpec struct {
+	ObjectMeta `yaml:",inline"`
+}
+
+type Namespace struct {
+	ID              string `json:"id" yaml:"id"`
+	ObjectMeta      `yaml:",inline"`
+	ResourceVersion int64          `json:"resourceVersion" yaml:"resourceVersion"`
+	Phase           NamespacePhase `json:"phase" yaml:"phase"`
+}
+
 // Nodes
 type Node struct {
-	ID string `json:"id"`
-	Name string `json:"name"`
-	Status NodeStatus `json:"status"`
-	Capacity Resources `json:"capacity"`
-	Used Resources `json:"used"`
-	Labels map[string]string `json:"labels"`
-	Taints map[string]string `json:"taints"`
+	ID              string `json:"id"`
+	ObjectMeta      `yaml:",inline"`
+	ResourceVersion int64             `json:"resourceVersion"` // Etcd revision of the last write, updates based on an older one are rejected
+	Status          NodeStatus        `json:"status"`
+	Capacity        Resources         `json:"capacity"`
+	Used            Resources         `json:"used"`
+	Taints          map[string]string `json:"taints"`
+	AgentManaged    bool              `json:"agentManaged"` // Set when a madelet agent runs the pods of this node
+}
+
+// Heartbeat of a node agent, renewed periodically under its own key
+type NodeLease struct {
+	NodeID          string    `json:"nodeId"`
+	ResourceVersion int64     `json:"resourceVersion"`
+	RenewTime       time.Time `json:"renewTime"`
+	DurationSeconds int       `json:"durationSeconds"`
 }
 
 type NodeCapacity struct {
-	CPU int `json:"cpu"`
+	CPU    int `json:"cpu"`
 	Memory int `json:"memory"`
 }
 
 // Pods
 type Pod struct {
-	ID string `json:"id"`
-	Name string `json:"name"`
-	DeploymentID string `json:"deploymentId"`
-	Status PodStatus `json:"status"`
-	NodeID string `json:"nodeId"`
-	Containers []Container `json:"containers"`
-	Resources Resources `json:"resources"`
-	Affinity map[string]string `json:"affinity"`
-	AntiAffinity map[string]string `json:"antiAffinity"`
-	Tolerations map[string]string `json:"tolerations"`
-	RestartPolicy RestartPolicy `json:"restartPolicy" yaml:"restartPolicy"`
+	ID              string `json:"id"`
+	ObjectMeta      `yaml:",inline"`
+	ResourceVersion int64             `json:"resourceVersion"`
+	DeploymentID    string            `json:"deploymentId"`
+	TemplateHash    string            `json:"templateHash"`
+	Status          PodStatus         `json:"status"`
+	NodeID          string            `json:"nodeId"`
+	SandboxID       string            `json:"sandboxId,omitempty"`      // Container holding the network namespace the containers share
+	InitContainers  []Container       `json:"initContainers,omitempty"` // Run to completion one after the other before the containers start
+	Containers      []Container       `json:"containers"`
+	Resources       Resources         `json:"resources"`
+	Affinity        map[string]string `json:"affinity"`
+	AntiAffinity    map[string]string `json:"antiAffinity"`
+	Tolerations     map[string]string `json:"tolerations"`
+	PodAffinity     *PodAffinity      `json:"podAffinity,omitempty"`
+	PodAntiAffinity *PodAffinity      `json:"podAntiAffinity,omitempty"`
+	RestartPolicy   RestartPolicy     `json:"restartPolicy" yaml:"restartPolicy"`
+}
+
+// Observed state of a pod, written by the scheduler and by the node agent running it
+type PodStatus struct {
+	Phase                 PodPhase          `json:"phase"`
+	Reason                string            `json:"reason,omitempty"` // Why the pod is in its phase, e.g. ContainerCreateFailed
+	Message               string            `json:"message,omitempty"`
+	StartTime             *time.Time        `json:"startTime,omitempty"` // When the node agent started working on the pod
+	PodIP                 string            `json:"podIP,omitempty"`     // Shared by the containers of the pod
+	Conditions            []PodCondition    `json:"conditions"`
+	ContainerStatuses     []ContainerStatus `json:"containerStatuses,omitempty"` // In the order of the containers of the pod
+	InitContainerStatuses []ContainerStatus `json:"initContainerStatuses,omitempty"`
+	Events                []PodEvent        `json:"events,omitempty"` // Most recent last, see MaxPodEvents
+}
+
+// Explains the state of a pod, e.g. why it could not be scheduled
+type PodCondition struct {
+	Type               PodConditionType `json:"type"`
+	Status             bool             `json:"status"`
+	Reason             string           `json:"reason"`
+	Message            string           `json:"message"`
+	LastTransitionTime time.Time        `json:"lastTransitionTime"`
+}
+
+// Something the node agent did with the pod, e.g. pulling the image of a container
+type PodEvent struct {
+	Type      PodEventType `json:"type"`
+	Reason    string       `json:"reason"` // E.g. Pulling, Pulled or BackOff
+	Message   string       `json:"message"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// Status of a container of a pod, as last inspected by the node agent
+type ContainerStatus struct {
+	ContainerID  string         `json:"containerId"`
+	Image        string         `json:"image"`
+	State        ContainerState `json:"state"`
+	LastState    ContainerState `json:"lastState"` // Last termination of a restarted container
+	Started      bool           `json:"started"`   // Running and past its startup probe
+	Ready        bool           `json:"ready"`     // Started and passing its readiness probe
+	RestartCount int            `json:"restartCount"`
+}
+
+// Only one of the states is set at a time
+type ContainerState struct {
+	Waiting    *ContainerStateWaiting    `json:"waiting,omitempty"`
+	Running    *ContainerStateRunning    `json:"running,omitempty"`
+	Terminated *ContainerStateTerminated `json:"terminated,omitempty"`
+}
+
+type ContainerStateWaiting struct {
+	Reason  string `json:"reason"` // e.g. ContainerCreating
+	Message string `json:"message,omitempty"`
+}
+
+type ContainerStateRunning struct {
+	StartedAt time.Time `json:"startedAt"`
+}
+
+type ContainerStateTerminated struct {
+	ExitCode   int       `json:"exitCode"`
+	Reason     string    `json:"reason"` // e.g. Completed, Error or OOMKilled
+	Message    string    `json:"message,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
 }
 
 type Resources struct {
-	CPU int `json:"cpu"`
+	CPU    int `json:"cpu"`
 	Memory int `json:"memory"` // in MB
 }
 
 // Deployments & Services
 type MadenResource struct {
-	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
-	Kind string `json:"kind" yaml:"kind"`
-	Spec interface{} `json:"spec" yaml:"spec"`
+	APIVersion string      `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string      `json:"kind" yaml:"kind"`
+	Spec       interface{} `json:"spec" yaml:"spec"`
 }
 
 // - Deployments
 type DeploymentSpec struct {
-	Name string `json:"name" yaml:"name"`
-    Replicas int `json:"replicas" yaml:"replicas"`
-    Selector LabelSelector `json:"selector" yaml:"selector"`
-    Template PodTemplate `json:"template" yaml:"template"`
+	ObjectMeta           `yaml:",inline"`
+	Replicas             int                `json:"replicas" yaml:"replicas"`
+	Selector             LabelSelector      `json:"selector" yaml:"selector"`
+	Template             PodTemplate        `json:"template" yaml:"template"`
+	Strategy             DeploymentStrategy `json:"strategy" yaml:"strategy"`
+	RevisionHistoryLimit int                `json:"revisionHistoryLimit" yaml:"revisionHistoryLimit"`
 }
 
 type Deployment struct {
-	ID string `json:"id" yaml:"id"`
-	Name string `json:"name" yaml:"name"`
-	Replicas int `json:"replicas" yaml:"replicas"`
-    Selector LabelSelector `json:"selector" yaml:"selector"`
-	Template PodTemplate `json:"template" yaml:"template"`
+	ID                   string `json:"id" yaml:"id"`
+	ObjectMeta           `yaml:",inline"`
+	ResourceVersion      int64              `json:"resourceVersion" yaml:"resourceVersion"`
+	Replicas             int                `json:"replicas" yaml:"replicas"`
+	Selector             LabelSelector      `json:"selector" yaml:"selector"`
+	Template             PodTemplate        `json:"template" yaml:"template"`
+	Strategy             DeploymentStrategy `json:"strategy" yaml:"strategy"`
+	RevisionHistoryLimit int                `json:"revisionHistoryLimit" yaml:"revisionHistoryLimit"`
+	Paused               bool               `json:"paused" yaml:"paused"`
+}
+
+// Snapshot of a deployment's pod template, recorded every time the template changes
+type DeploymentRevision struct {
+	ID              string      `json:"id" yaml:"id"`
+	ResourceVersion int64       `json:"resourceVersion" yaml:"resourceVersion"`
+	DeploymentID    string      `json:"deploymentId" yaml:"deploymentId"`
+	DeploymentName  string      `json:"deploymentName" yaml:"deploymentName"`
+	Namespace       string      `json:"namespace" yaml:"namespace"`
+	Revision        int         `json:"revision" yaml:"revision"`
+	TemplateHash    string      `json:"templateHash" yaml:"templateHash"`
+	Template        PodTemplate `json:"template" yaml:"template"`
+	CreatedAt       time.Time   `json:"createdAt" yaml:"createdAt"`
+}
+
+type DeploymentStrategy struct {
+	Type          DeploymentStrategyType   `json:"type" yaml:"type"`
+	RollingUpdate *RollingUpdateDeployment `json:"rollingUpdate,omitempty" yaml:"rollingUpdate"`
+}
+
+type RollingUpdateDeployment struct {
+	MaxSurge       int `json:"maxSurge" yaml:"maxSurge"`
+	MaxUnavailable int `json:"maxUnavailable" yaml:"maxUnavailable"`
 }
 
 type LabelSelector struct {
@@ -65,88 +230,238 @@ type LabelSelector struct {
 
 type PodTemplate struct {
 	Metadata Metadata `json:"metadata" yaml:"metadata"`
-	Spec PodSpec `json:"spec" yaml:"spec"`
+	Spec     PodSpec  `json:"spec" yaml:"spec"`
 }
 
 type PodSpec struct {
-	Containers []Container `json:"containers" yaml:"containers"`
-	Resources Resources `json:"resources" yaml:"resources"`
-	Affinity map[string]string `json:"affinity" yaml:"affinity"`
-	AntiAffinity map[string]string `json:"antiAffinity" yaml:"antiAffinity"`
-	Tolerations map[string]string `json:"tolerations" yaml:"tolerations"`
-	RestartPolicy RestartPolicy `json:"restartPolicy" yaml:"restartPolicy"`
+	InitContainers  []Container       `json:"initContainers,omitempty" yaml:"initContainers"`
+	Containers      []Container       `json:"containers" yaml:"containers"`
+	Resources       Resources         `json:"resources" yaml:"resources"`
+	Affinity        map[string]string `json:"affinity" yaml:"affinity"`
+	AntiAffinity    map[string]string `json:"antiAffinity" yaml:"antiAffinity"`
+	Tolerations     map[string]string `json:"tolerations" yaml:"tolerations"`
+	PodAffinity     *PodAffinity      `json:"podAffinity,omitempty" yaml:"podAffinity"`
+	PodAntiAffinity *PodAffinity      `json:"podAntiAffinity,omitempty" yaml:"podAntiAffinity"`
+	RestartPolicy   RestartPolicy     `json:"restartPolicy" yaml:"restartPolicy"`
+}
+
+// Rules placing a pod relative to other pods rather than to node labels. Required terms must hold
+// for the pod to be scheduled; preferred ones only weigh in on the choice between the nodes that fit
+type PodAffinity struct {
+	Required  []PodAffinityTerm         `json:"required" yaml:"required"`
+	Preferred []WeightedPodAffinityTerm `json:"preferred" yaml:"preferred"`
+}
+
+// Matches the pods of the same namespace selected by LabelSelector that run on nodes sharing the value of the TopologyKey label,
+// e.g. "hostname" for the same node or "zone" for the same zone
+type PodAffinityTerm struct {
+	LabelSelector LabelSelector `json:"labelSelector" yaml:"labelSelector"`
+	TopologyKey   string        `json:"topologyKey" yaml:"topologyKey"`
+}
+
+type WeightedPodAffinityTerm struct {
+	Weight int             `json:"weight" yaml:"weight"` // 1-100
+	Term   PodAffinityTerm `json:"podAffinityTerm" yaml:"podAffinityTerm"`
 }
 
+// Copied onto the pods created from a template
 type Metadata struct {
-	Labels map[string]string `json:"labels" yaml:"labels"`
+	Labels      map[string]string `json:"labels" yaml:"labels"`
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations"`
 }
 
 type Container struct {
-	ID string `json:"containerId"`
-	Image string `json:"image" yaml:"image"`
-	Ports []Port `json:"ports" yaml:"ports"`
+	ID              string               `json:"containerId"`
+	Name            string               `json:"name" yaml:"name"`
+	Image           string               `json:"image" yaml:"image"`
+	ImagePullPolicy ImagePullPolicy      `json:"imagePullPolicy" yaml:"imagePullPolicy"`
+	Command         []string             `json:"command,omitempty" yaml:"command"` // Replaces the entrypoint of the image
+	Args            []string             `json:"args,omitempty" yaml:"args"`       // Replaces the cmd of the image
+	Env             []EnvVar             `json:"env,omitempty" yaml:"env"`
+	WorkingDir      string               `json:"workingDir,omitempty" yaml:"workingDir"`
+	Ports           []Port               `json:"ports" yaml:"ports"`
+	Resources       ResourceRequirements `json:"resources" yaml:"resources"`
+	LivenessProbe   *Probe               `json:"livenessProbe,omitempty" yaml:"livenessProbe"`   // Restarts the container once it fails
+	ReadinessProbe  *Probe               `json:"readinessProbe,omitempty" yaml:"readinessProbe"` // Marks the container as ready while it succeeds
+	StartupProbe    *Probe               `json:"startupProbe,omitempty" yaml:"startupProbe"`     // Holds back the other probes until it succeeds
+}
+
+type EnvVar struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// Requests count towards the resources reserved on the node, limits cap what the container may use
+type ResourceRequirements struct {
+	Requests Resources `json:"requests" yaml:"requests"`
+	Limits   Resources `json:"limits" yaml:"limits"`
+}
+
+// Health check of a container, exactly one of its actions is set.
+// Zero values of the thresholds and periods fall back to their defaults. Probes are checked whenever the node
+// syncs the status of its pods, so periods shorter than that interval (5s by default) take effect as the interval
+type Probe struct {
+	Exec                *ExecAction      `json:"exec,omitempty" yaml:"exec"`
+	HTTPGet             *HTTPGetAction   `json:"httpGet,omitempty" yaml:"httpGet"`
+	TCPSocket           *TCPSocketAction `json:"tcpSocket,omitempty" yaml:"tcpSocket"`
+	InitialDelaySeconds int              `json:"initialDelaySeconds" yaml:"initialDelaySeconds"`
+	PeriodSeconds       int              `json:"periodSeconds" yaml:"periodSeconds"`
+	TimeoutSeconds      int              `json:"timeoutSeconds" yaml:"timeoutSeconds"`
+	SuccessThreshold    int              `json:"successThreshold" yaml:"successThreshold"`
+	FailureThreshold    int              `json:"failureThreshold" yaml:"failureThreshold"`
+}
+
+// Succeeds when the command exits with code 0
+type ExecAction struct {
+	Command []string `json:"command" yaml:"command"`
+}
+
+// Succeeds on a status code between 200 and 399
+type HTTPGetAction struct {
+	Path   string `json:"path" yaml:"path"`
+	Port   int    `json:"port" yaml:"port"`
+	Host   string `json:"host,omitempty" yaml:"host"` // Defaults to the IP of the container
+	Scheme string `json:"scheme,omitempty" yaml:"scheme"`
+}
+
+// Succeeds when a connection can be opened
+type TCPSocketAction struct {
+	Port int    `json:"port" yaml:"port"`
+	Host string `json:"host,omitempty" yaml:"host"`
 }
 
 type Port struct {
-	ContainerPort int `json:"containerPort" yaml:"containerPort"`
+	ContainerPort int    `json:"containerPort" yaml:"containerPort"`
+	HostPort      int    `json:"hostPort,omitempty" yaml:"hostPort"` // Published on the node when set
+	Protocol      string `json:"protocol,omitempty" yaml:"protocol"` // tcp or udp, defaults to tcp
 }
 
 // - Services
 type ServiceSpec struct {
-	Name string `json:"name" yaml:"name"`
-	Selector map[string]string `json:"selector" yaml:"selector"`
-	Ports []ServicePort `json:"ports" yaml:"ports"`
+	ObjectMeta `yaml:",inline"`
+	Selector   map[string]string `json:"selector" yaml:"selector"`
+	Ports      []ServicePort     `json:"ports" yaml:"ports"`
+	IP         string            `json:"ip,omitempty" yaml:"ip"` // Requested from the service CIDR, one is picked when empty
 }
 
 type Service struct {
-	ID string `json:"id" yaml:"id"`
-	Name string `json:"name" yaml:"name"`
-	Selector map[string]string `json:"selector" yaml:"selector"`
-	Ports []ServicePort `json:"ports" yaml:"ports"`
-	IP string `json:"ip" yaml:"ip"`
+	ID              string `json:"id" yaml:"id"`
+	ObjectMeta      `yaml:",inline"`
+	ResourceVersion int64             `json:"resourceVersion" yaml:"resourceVersion"`
+	Selector        map[string]string `json:"selector" yaml:"selector"`
+	Ports           []ServicePort     `json:"ports" yaml:"ports"`
+	IP              string            `json:"ip" yaml:"ip"`
 }
 
 type ServicePort struct {
-	Port int `json:"port" yaml:"port"`
-	TargetPort int `json:"targetPort" yaml:"targetPort"`
+	Name       string `json:"name,omitempty" yaml:"name"` // Names the SRV record of the port, _<name>._<protocol>.<service>.<namespace>.svc.cluster.local
+	Port       int    `json:"port" yaml:"port"`
+	TargetPort int    `json:"targetPort" yaml:"targetPort"` // Port of the containers the traffic is forwarded to, Port when unset
+	Protocol   string `json:"protocol,omitempty" yaml:"protocol"` // tcp or udp, defaults to tcp
+}
+
+// DNS
+// What the cluster DNS server answers for a service, stored under its namespace and name
+type DNSRecord struct {
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	IP        string        `json:"ip"`
+	Ports     []ServicePort `json:"ports,omitempty"`
+}
+
+// Endpoints
+// Pods backing a service, kept up to date by the endpoints controller under the namespace and name of the service
+type Endpoints struct {
+	ObjectMeta        `yaml:",inline"`
+	ResourceVersion   int64             `json:"resourceVersion" yaml:"resourceVersion"`
+	Addresses         []EndpointAddress `json:"addresses" yaml:"addresses"`                             // Ready pods, the ones traffic is sent to
+	NotReadyAddresses []EndpointAddress `json:"notReadyAddresses,omitempty" yaml:"notReadyAddresses"` // Running pods that did not pass their readiness checks
+	Ports             []EndpointPort    `json:"ports" yaml:"ports"`
+}
+
+type EndpointAddress struct {
+	IP     string `json:"ip" yaml:"ip"`
+	PodID  string `json:"podId" yaml:"podId"`
+	NodeID string `json:"nodeId,omitempty" yaml:"nodeId"`
+}
+
+// Where the pods receive the traffic of one port of the service
+type EndpointPort struct {
+	ServicePort int    `json:"servicePort" yaml:"servicePort"`
+	Port        int    `json:"port" yaml:"port"`
+	Protocol    string `json:"protocol" yaml:"protocol"`
+}
+
+// IP Allocations
+// Addresses handed out from one CIDR, one bit per address, set while the address is in use
+type IPAllocation struct {
+	Range           string `json:"range" yaml:"range"` // What the addresses are for, e.g. "services"
+	CIDR            string `json:"cidr" yaml:"cidr"`
+	Bitmap          []byte `json:"bitmap" yaml:"bitmap"`
+	Owner           string `json:"owner,omitempty" yaml:"owner"` // Where the addresses are usable, e.g. the Docker host of the pod network
+	ResourceVersion int64  `json:"resourceVersion" yaml:"resourceVersion"`
 }
 
 // Persistent Volumes
 type PersistentVolumeSpec struct {
-	Name string `json:"name" yaml:"name"`
-    Capacity map[string]string `json:"capacity" yaml:"capacity"`
-    AccessModes []string `json:"accessModes" yaml:"accessModes"`
-    PersistentVolumeReclaimPolicy string `json:"reclaimPolicy" yaml:"reclaimPolicy"`
-    StorageClassName string `json:"storageClassName" yaml:"storageClassName"`
-    MountOptions []string `json:"mountOptions" yaml:"mountOptions"`
+	ObjectMeta                    `yaml:",inline"`
+	Capacity                      map[string]string `json:"capacity" yaml:"capacity"`
+	AccessModes                   []string          `json:"accessModes" yaml:"accessModes"`
+	PersistentVolumeReclaimPolicy string            `json:"reclaimPolicy" yaml:"reclaimPolicy"`
+	StorageClassName              string            `json:"storageClassName" yaml:"storageClassName"`
+	MountOptions                  []string          `json:"mountOptions" yaml:"mountOptions"`
 }
 
 type PersistentVolume struct {
-	ID string `json:"id" yaml:"id"`
-	Name string `json:"name" yaml:"name"`
-    Capacity map[string]string `json:"capacity" yaml:"capacity"`
-    AccessModes []string `json:"accessModes" yaml:"accessModes"`
-    PersistentVolumeReclaimPolicy string `json:"reclaimPolicy" yaml:"reclaimPolicy"`
-    StorageClassName string `json:"storageClassName" yaml:"storageClassName"`
-    MountOptions []string `json:"mountOptions" yaml:"mountOptions"`
+	ID                            string `json:"id" yaml:"id"`
+	ObjectMeta                    `yaml:",inline"`
+	ResourceVersion               int64             `json:"resourceVersion" yaml:"resourceVersion"`
+	Capacity                      map[string]string `json:"capacity" yaml:"capacity"`
+	AccessModes                   []string          `json:"accessModes" yaml:"accessModes"`
+	PersistentVolumeReclaimPolicy string            `json:"reclaimPolicy" yaml:"reclaimPolicy"`
+	StorageClassName              string            `json:"storageClassName" yaml:"storageClassName"`
+	MountOptions                  []string          `json:"mountOptions" yaml:"mountOptions"`
 }
 
 type PersistentVolumeClaimSpec struct {
-	Name string `json:"name" yaml:"name"`
-    AccessModes []string `json:"accessModes" yaml:"accessModes"`
-    Resources Resources `json:"resources" yaml:"resources"`
-    VolumeName string `json:"volumeName" yaml:"volumeName"`
+	ObjectMeta  `yaml:",inline"`
+	AccessModes []string  `json:"accessModes" yaml:"accessModes"`
+	Resources   Resources `json:"resources" yaml:"resources"`
+	VolumeName  string    `json:"volumeName" yaml:"volumeName"`
 }
 
 type PersistentVolumeClaim struct {
-	ID string `json:"id" yaml:"id"`
-	Name string `json:"name" yaml:"name"`
-    AccessModes []string `json:"accessModes" yaml:"accessModes"`
-    Resources Resources `json:"resources" yaml:"resources"`
-    VolumeName string `json:"volumeName" yaml:"volumeName"`
+	ID              string `json:"id" yaml:"id"`
+	ObjectMeta      `yaml:",inline"`
+	ResourceVersion int64     `json:"resourceVersion" yaml:"resourceVersion"`
+	AccessModes     []string  `json:"accessModes" yaml:"accessModes"`
+	Resources       Resources `json:"resources" yaml:"resources"`
+	VolumeName      string    `json:"volumeName" yaml:"volumeName"`
 }
 
-// Other 
+// Other
 type ScaleRequest struct {
 	Replicas int `json:"replicas"`
-}
\ No newline at end of file
+}
+
+type RollbackRequest struct {
+	Revision int `json:"revision"` // 0 rolls back to the previous revision
+}
+
+// Watches
+type WatchEventType int
+
+const (
+	WatchEventCreate WatchEventType = iota
+	WatchEventUpdate
+	WatchEventDelete
+	WatchEventError // Last event of a failed watch, the objects have to be listed anew
+)
+
+// Change of a stored object. Object is the state after the change; for deletes it is the last state before it
+type WatchEvent[T any] struct {
+	Type       WatchEventType
+	Object     *T
+	PrevObject *T    // Only set for updates
+	Err        error // Only set for errors, Object is nil then
+}
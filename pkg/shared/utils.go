@@ -1,7 +1,15 @@
 package shared
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
 	"math/rand"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -16,4 +24,244 @@ func GenerateRandomString(length int) string {
       b[i] = charset[seededRand.Intn(len(charset))]
    }
    return string(b)
-}
\ No newline at end of file
+}
+
+// Hashing
+func ComputeTemplateHash(template PodTemplate) string {
+	templateData, err := json.Marshal(template)
+	if err != nil {
+		return ""
+	}
+
+	hasher := fnv.New32a()
+	hasher.Write(templateData)
+	return fmt.Sprintf("%08x", hasher.Sum32())
+}
+
+// Resources
+// What the scheduler reserves for a pod: the resources of the pod spec if given, the sum of the requests of its containers otherwise.
+// Init containers run one at a time before the containers, so the largest of their requests is enough for them
+func PodResourceRequests(spec PodSpec) Resources {
+	if spec.Resources != (Resources{}) {
+		return spec.Resources
+	}
+
+	var requests Resources
+	for _, container := range spec.Containers {
+		requests.CPU += container.Resources.Requests.CPU
+		requests.Memory += container.Resources.Requests.Memory
+	}
+	for _, container := range spec.InitContainers {
+		requests.CPU = max(requests.CPU, container.Resources.Requests.CPU)
+		requests.Memory = max(requests.Memory, container.Resources.Requests.Memory)
+	}
+	return requests
+}
+
+// Conditions
+// Replaces the condition of the same type, keeping its transition time if the status did not change
+func SetPodCondition(pod *Pod, condition PodCondition) {
+	for i, existing := range pod.Status.Conditions {
+		if existing.Type != condition.Type {
+			continue
+		}
+
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		pod.Status.Conditions[i] = condition
+		return
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions, condition)
+}
+
+// Running pods whose containers all passed their readiness checks, the ones services send traffic to
+func IsPodReady(pod Pod) bool {
+	if pod.Status.Phase != PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == PodReadyCondition {
+			return condition.Status
+		}
+	}
+	return false
+}
+
+// Events
+// Pods keep their most recent events only
+const MaxPodEvents = 20
+
+func RecordPodEvent(pod *Pod, eventType PodEventType, reason string, message string) {
+	pod.Status.Events = append(pod.Status.Events, PodEvent{Type: eventType, Reason: reason, Message: message, Timestamp: time.Now()})
+	if len(pod.Status.Events) > MaxPodEvents {
+		pod.Status.Events = pod.Status.Events[len(pod.Status.Events)-MaxPodEvents:]
+	}
+}
+
+// Namespaces
+// Objects submitted without a namespace end up in the default one
+func NamespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return DefaultNamespace
+	}
+	return namespace
+}
+
+// Metadata
+// Labels and annotations are the only metadata that can change once an object exists
+func IsMetadataUpdated(existing ObjectMeta, spec ObjectMeta) bool {
+	return !maps.Equal(existing.Labels, spec.Labels) || !maps.Equal(existing.Annotations, spec.Annotations)
+}
+
+func UpdateMetadata(existing *ObjectMeta, spec ObjectMeta) {
+	existing.Labels = spec.Labels
+	existing.Annotations = spec.Annotations
+}
+
+// Owners
+func NewOwnerReference(kind ResourceType, owner ObjectMeta, controller bool) OwnerReference {
+	return OwnerReference{Kind: kind.String(), Name: owner.Name, UID: owner.UID, Controller: controller}
+}
+
+func (m *ObjectMeta) IsOwnedBy(ownerUID string) bool {
+	return slices.ContainsFunc(m.OwnerReferences, func(ref OwnerReference) bool { return ref.UID == ownerUID })
+}
+
+// The owner that manages the object, if it has one
+func (m *ObjectMeta) ControllerReference() (OwnerReference, bool) {
+	index := slices.IndexFunc(m.OwnerReferences, func(ref OwnerReference) bool { return ref.Controller })
+	if index < 0 {
+		return OwnerReference{}, false
+	}
+	return m.OwnerReferences[index], true
+}
+
+// Returns whether the reference was there
+func (m *ObjectMeta) RemoveOwnerReference(ownerUID string) bool {
+	length := len(m.OwnerReferences)
+	m.OwnerReferences = slices.DeleteFunc(m.OwnerReferences, func(ref OwnerReference) bool { return ref.UID == ownerUID })
+	return len(m.OwnerReferences) != length
+}
+
+// Labels
+// An empty selector matches every set of labels
+func MatchesLabelSelector(selector LabelSelector, labels map[string]string) bool {
+	for key, val := range selector.MatchLabels {
+		if labelVal, ok := labels[key]; !ok || labelVal != val {
+			return false
+		}
+	}
+	return true
+}
+
+// Parsed form of a label selector string such as "app=web,tier!=db,canary,!legacy", as given in list queries.
+// Every requirement has to hold: the label equals (= or ==) or differs from (!=) the value, exists, or does not exist (!)
+type Selector []labelRequirement
+
+type labelRequirement struct {
+	key      string
+	operator string
+	value    string
+}
+
+const (
+	labelEquals    = "="
+	labelNotEquals = "!="
+	labelExists    = "exists"
+	labelAbsent    = "!"
+)
+
+var labelTokenPattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$`)
+
+// An empty string parses into a selector that matches everything
+func ParseSelector(selector string) (Selector, error) {
+	parsed := Selector{}
+	if strings.TrimSpace(selector) == "" {
+		return parsed, nil
+	}
+
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		var requirement labelRequirement
+		if key, value, ok := strings.Cut(term, "!="); ok {
+			requirement = labelRequirement{key: key, operator: labelNotEquals, value: value}
+		} else if key, value, ok := strings.Cut(term, "=="); ok {
+			requirement = labelRequirement{key: key, operator: labelEquals, value: value}
+		} else if key, value, ok := strings.Cut(term, "="); ok {
+			requirement = labelRequirement{key: key, operator: labelEquals, value: value}
+		} else if key, ok := strings.CutPrefix(term, "!"); ok {
+			requirement = labelRequirement{key: key, operator: labelAbsent}
+		} else {
+			requirement = labelRequirement{key: term, operator: labelExists}
+		}
+
+		requirement.key = strings.TrimSpace(requirement.key)
+		requirement.value = strings.TrimSpace(requirement.value)
+		if !labelTokenPattern.MatchString(requirement.key) {
+			return nil, &ErrInvalidSelector{Selector: selector, Reason: fmt.Sprintf("invalid label key in %q", term)}
+		}
+		if requirement.value != "" && !labelTokenPattern.MatchString(requirement.value) {
+			return nil, &ErrInvalidSelector{Selector: selector, Reason: fmt.Sprintf("invalid label value in %q", term)}
+		}
+		parsed = append(parsed, requirement)
+	}
+	return parsed, nil
+}
+
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		value, ok := labels[requirement.key]
+		switch requirement.operator {
+		case labelEquals:
+			if !ok || value != requirement.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && value == requirement.value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelAbsent:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Keeps the objects whose labels match the selector. Objects that do not embed ObjectMeta have no labels
+func FilterBySelector[T any](objects []T, selector Selector) []T {
+	filtered := make([]T, 0, len(objects))
+	for i := range objects {
+		var labels map[string]string
+		if object, ok := any(&objects[i]).(Object); ok {
+			labels = object.GetObjectMeta().Labels
+		}
+		if selector.Matches(labels) {
+			filtered = append(filtered, objects[i])
+		}
+	}
+	return filtered
+}
+
+// Conflicts
+const maxConflictRetries = 5
+
+// Runs fn again as long as it fails with ErrConflict, up to maxConflictRetries times.
+// fn has to read the object anew on each attempt, otherwise it keeps conflicting
+func RetryOnConflict(fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= maxConflictRetries; attempt++ {
+		err = fn()
+		var errConflict *ErrConflict
+		if !errors.As(err, &errConflict) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelector(t *testing.T) {
+	labels := map[string]string{"app": "web", "tier": "frontend"}
+
+	tests := []struct {
+		selector string
+		matches  bool
+	}{
+		{"", true},
+		{"app=web", true},
+		{"app==web,tier=frontend", true},
+		{"app=web,tier!=db", true},
+		{"app!=web", false},
+		{"tier", true},
+		{"!tier", false},
+		{"canary", false},
+		{"!canary", true},
+		{"app=api", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			selector, err := ParseSelector(test.selector)
+			assert.NoError(t, err)
+			assert.Equal(t, test.matches, selector.Matches(labels))
+		})
+	}
+}
+
+func TestParseSelectorRejectsInvalidTerms(t *testing.T) {
+	for _, selector := range []string{"app=web,", "=web", "app=we b", "!"} {
+		_, err := ParseSelector(selector)
+		var errInvalid *ErrInvalidSelector
+		assert.ErrorAs(t, err, &errInvalid, selector)
+	}
+}
+
+func TestFilterBySelector(t *testing.T) {
+	pods := []Pod{
+		{ID: "1", ObjectMeta: ObjectMeta{Labels: map[string]string{"app": "web"}}},
+		{ID: "2", ObjectMeta: ObjectMeta{Labels: map[string]string{"app": "db"}}},
+		{ID: "3"},
+	}
+	selector, _ := ParseSelector("app!=db")
+
+	filtered := FilterBySelector(pods, selector)
+
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "1", filtered[0].ID)
+	assert.Equal(t, "3", filtered[1].ID)
+}
+
+func TestRemoveOwnerReference(t *testing.T) {
+	owner := ObjectMeta{Name: "web", UID: "uid-1"}
+	meta := ObjectMeta{OwnerReferences: []OwnerReference{NewOwnerReference(DeploymentResource, owner, true)}}
+
+	assert.True(t, meta.IsOwnedBy("uid-1"))
+	assert.False(t, meta.RemoveOwnerReference("uid-2"))
+	assert.True(t, meta.RemoveOwnerReference("uid-1"))
+	assert.False(t, meta.IsOwnedBy("uid-1"))
+	assert.Empty(t, meta.OwnerReferences)
+}
+
+func TestControllerReference(t *testing.T) {
+	volume := ObjectMeta{Name: "data", UID: "uid-1"}
+	deployment := ObjectMeta{Name: "web", UID: "uid-2"}
+	meta := ObjectMeta{OwnerReferences: []OwnerReference{
+		NewOwnerReference(PersistentVolumeResource, volume, false),
+		NewOwnerReference(DeploymentResource, deployment, true),
+	}}
+
+	owner, ok := meta.ControllerReference()
+	_, okWithoutController := (&ObjectMeta{Name: "standalone"}).ControllerReference()
+
+	assert.True(t, ok)
+	assert.Equal(t, "web", owner.Name)
+	assert.Equal(t, DeploymentResource.String(), owner.Kind)
+	assert.False(t, okWithoutController)
+}
+
+func TestPodResourceRequests(t *testing.T) {
+	containers := []Container{
+		{Resources: ResourceRequirements{Requests: Resources{CPU: 1, Memory: 256}}},
+		{Resources: ResourceRequirements{Requests: Resources{CPU: 2, Memory: 512}, Limits: Resources{CPU: 4}}},
+	}
+
+	assert.Equal(t, Resources{CPU: 3, Memory: 768}, PodResourceRequests(PodSpec{Containers: containers}))
+	assert.Equal(t, Resources{CPU: 1, Memory: 128}, PodResourceRequests(PodSpec{Containers: containers, Resources: Resources{CPU: 1, Memory: 128}}))
+
+	initContainers := []Container{{Resources: ResourceRequirements{Requests: Resources{CPU: 1, Memory: 1024}}}}
+	assert.Equal(t, Resources{CPU: 3, Memory: 1024}, PodResourceRequests(PodSpec{InitContainers: initContainers, Containers: containers}))
+}
+
+func TestIsPodReady(t *testing.T) {
+	ready := Pod{Status: PodStatus{Phase: PodRunning, Conditions: []PodCondition{{Type: PodReadyCondition, Status: true}}}}
+	notReady := Pod{Status: PodStatus{Phase: PodRunning, Conditions: []PodCondition{{Type: PodReadyCondition, Status: false}}}}
+	failed := Pod{Status: PodStatus{Phase: PodFailed, Conditions: []PodCondition{{Type: PodReadyCondition, Status: true}}}}
+
+	assert.True(t, IsPodReady(ready))
+	assert.False(t, IsPodReady(notReady))
+	assert.False(t, IsPodReady(failed))
+	assert.False(t, IsPodReady(Pod{Status: PodStatus{Phase: PodRunning}}))
+}